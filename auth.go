@@ -2,8 +2,8 @@ package unifi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"net/http"
 )
@@ -18,7 +18,31 @@ type LoginResponse struct {
 // if remember=true for long-running sessions.
 // the API will return HTTP200 for success and a cookie that is your session,
 // this method will store this for future commands automatically. Though it is not thread-safe.
-func (c *Client) Login(username string, password string, remember bool) error {
+// UniFi OS consoles are detected automatically and use their own authentication endpoint.
+// The credentials are kept in memory to transparently re-login when the session expires, see SetAutoRelogin.
+func (c *Client) Login(ctx context.Context, username string, password string, remember bool) error {
+	err := c.login(ctx, username, password, remember)
+	if err != nil {
+		c.log().Warnw("login failed", "username", username, "error", err)
+		return err
+	}
+	c.log().Infow("logged in", "username", username, "unifi_os", c.IsUniFiOS())
+	c.setCredentials(username, password)
+	c.persistSession(ctx)
+	return nil
+}
+
+// login performs the actual login without storing the credentials
+func (c *Client) login(ctx context.Context, username string, password string, remember bool) error {
+	if detected, _ := c.unifiOSState(); !detected {
+		if _, err := c.DetectUniFiOS(ctx); err != nil {
+			return err
+		}
+	}
+	if c.IsUniFiOS() {
+		return c.loginUniFiOS(ctx, username, password, remember)
+	}
+
 	// we do this one manually to acquire cookies
 	rememberStr := "false"
 	if remember {
@@ -32,16 +56,21 @@ func (c *Client) Login(username string, password string, remember bool) error {
 	}
 	data, _ := json.Marshal(auth)
 
-	req, err := http.NewRequest(http.MethodPost, u.String(), bytes.NewReader(data))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
 	c.SetHeaders(req)
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return newAPIError(resp)
+	}
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
@@ -55,20 +84,29 @@ func (c *Client) Login(username string, password string, remember bool) error {
 	}
 
 	if !loginResponse.Meta.ResponseCode.Equal(ResponseCodeOK) {
-		return fmt.Errorf("unable to login, response code: %s", loginResponse.Meta.ResponseCode)
+		return &APIError{
+			StatusCode:   resp.StatusCode,
+			ResponseCode: loginResponse.Meta.ResponseCode,
+			Message:      loginResponse.Meta.ResponseCodeMessage,
+		}
 	}
-	c.authCookies = resp.Cookies()
-	c.longRunningSession = remember
+	c.setSession(resp.Cookies(), "", remember)
 	return nil
 }
 
 // Logout destroys the sever side session id which will make future attempts with that cookie fail
-func (c *Client) Logout() error {
-	if !c.longRunningSession {
+func (c *Client) Logout(ctx context.Context) error {
+	// never re-login after an explicit logout
+	c.setCredentials("", "")
+	c.forgetSession(ctx)
+	if c.IsUniFiOS() {
+		return c.logoutUniFiOS(ctx)
+	}
+	if !c.isLongRunningSession() {
 		// nothing to do, this will be invalid
 		return nil
 	}
-	return c.doRequest(http.MethodGet, "/api/logout", nil, &LoginResponse{})
+	return c.doRequest(ctx, http.MethodGet, "/api/logout", nil, &LoginResponse{})
 }
 
 // SelfResponseData is the self response data structure
@@ -96,8 +134,8 @@ type SelfResponse struct {
 }
 
 // Self returns the logged in user.
-func (c *Client) Self() (*SelfResponse, error) {
+func (c *Client) Self(ctx context.Context) (*SelfResponse, error) {
 	var selfResponse SelfResponse
-	err := c.doRequest(http.MethodGet, "/api/self", nil, &selfResponse)
+	err := c.doRequest(ctx, http.MethodGet, "/api/self", nil, &selfResponse)
 	return &selfResponse, err
 }
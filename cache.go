@@ -0,0 +1,240 @@
+package unifi
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTLs are the cache TTLs per endpoint used when CacheConfig.TTLs is nil, keyed by the path below
+// the site, e.g. `stat/device`. Frequently changing statistics are cached briefly, configuration for longer.
+var DefaultCacheTTLs = map[string]time.Duration{
+	"stat/device":        10 * time.Second,
+	"stat/device-basic":  10 * time.Second,
+	"stat/sta":           10 * time.Second,
+	"stat/health":        10 * time.Second,
+	"stat/sysinfo":       time.Minute,
+	"rest/setting":       5 * time.Minute,
+	"get/setting":        5 * time.Minute,
+	"rest/networkconf":   5 * time.Minute,
+	"rest/wlanconf":      5 * time.Minute,
+	"rest/firewallrule":  5 * time.Minute,
+	"rest/firewallgroup": 5 * time.Minute,
+	"rest/portconf":      5 * time.Minute,
+	"rest/usergroup":     5 * time.Minute,
+}
+
+// CacheConfig configures the response cache
+type CacheConfig struct {
+	TTLs       map[string]time.Duration // TTL per path prefix below the site, the longest matching prefix wins, defaults to DefaultCacheTTLs
+	DefaultTTL time.Duration            // TTL of read requests without a matching prefix, 0 does not cache them
+	MaxEntries int                      // the maximum cached responses, 0 for no limit
+}
+
+// cacheEntry is a single cached response
+type cacheEntry struct {
+	site       string
+	statusCode int
+	header     http.Header
+	body       []byte
+	expires    time.Time
+}
+
+// sitePathPattern extracts the site and the path below the site from the request path
+var sitePathPattern = regexp.MustCompile(`/(?:api/s|v2/api/site)/([^/]+)/(.*)$`)
+
+// Cache is an in-memory response cache for read requests, responses are cached per endpoint and site.
+// Any write request to a site invalidates the cached responses of that site.
+// Expired responses with an ETag or Last-Modified header are revalidated with a conditional request.
+type Cache struct {
+	config CacheConfig
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+// NewCache returns an empty cache, add it to a client with WithCache or Client.Use(cache.Middleware())
+func NewCache(config CacheConfig) *Cache {
+	if config.TTLs == nil {
+		config.TTLs = DefaultCacheTTLs
+	}
+	return &Cache{config: config, entries: make(map[string]*cacheEntry)}
+}
+
+// WithCache caches the responses of read requests, see Cache
+func WithCache(cache *Cache) Option {
+	return func(o *clientOptions) {
+		o.middlewares = append(o.middlewares, cache.Middleware())
+	}
+}
+
+// Invalidate drops the cached responses of the site
+func (c *Cache) Invalidate(site string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if entry.site == site {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// InvalidateAll drops every cached response
+func (c *Cache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*cacheEntry)
+}
+
+// ttl returns the TTL of the path below the site
+func (c *Cache) ttl(sitePath string) time.Duration {
+	ttl, matched := c.config.DefaultTTL, 0
+	for prefix, t := range c.config.TTLs {
+		if strings.HasPrefix(sitePath, prefix) && len(prefix) > matched {
+			ttl, matched = t, len(prefix)
+		}
+	}
+	return ttl
+}
+
+// isRead returns true if the request does not modify the controller, statistics are also read with POST
+func isRead(method string, sitePath string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		return strings.HasPrefix(sitePath, "stat/") || strings.HasPrefix(sitePath, "list/")
+	default:
+		return false
+	}
+}
+
+// Middleware returns the middleware serving and storing the cached responses
+func (c *Cache) Middleware() Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			m := sitePathPattern.FindStringSubmatch(req.URL.Path)
+			if m == nil {
+				return next(req)
+			}
+			site, sitePath := m[1], m[2]
+			if !isRead(req.Method, sitePath) {
+				resp, err := next(req)
+				if err == nil && resp.StatusCode < http.StatusBadRequest {
+					c.Invalidate(site)
+				}
+				return resp, err
+			}
+			ttl := c.ttl(sitePath)
+			if ttl <= 0 {
+				return next(req)
+			}
+
+			key, err := cacheKey(req)
+			if err != nil {
+				return nil, err
+			}
+			c.mu.Lock()
+			entry := c.entries[key]
+			c.mu.Unlock()
+			if entry != nil && time.Now().Before(entry.expires) {
+				return entry.response(req), nil
+			}
+			if entry != nil {
+				if etag := entry.header.Get("ETag"); etag != "" {
+					req.Header.Set("If-None-Match", etag)
+				}
+				if lastModified := entry.header.Get("Last-Modified"); lastModified != "" {
+					req.Header.Set("If-Modified-Since", lastModified)
+				}
+			}
+
+			resp, err := next(req)
+			if err != nil {
+				return nil, err
+			}
+			if resp.StatusCode == http.StatusNotModified && entry != nil {
+				resp.Body.Close()
+				c.store(key, &cacheEntry{site: site, statusCode: entry.statusCode, header: entry.header, body: entry.body, expires: time.Now().Add(ttl)})
+				return entry.response(req), nil
+			}
+			if resp.StatusCode != http.StatusOK {
+				return resp, nil
+			}
+			body, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			entry = &cacheEntry{site: site, statusCode: resp.StatusCode, header: resp.Header.Clone(), body: body, expires: time.Now().Add(ttl)}
+			c.store(key, entry)
+			return entry.response(req), nil
+		}
+	}
+}
+
+// store adds the entry, evicting expired entries and then the entry expiring first when the cache is full
+func (c *Cache) store(key string, entry *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.config.MaxEntries > 0 && len(c.entries) >= c.config.MaxEntries {
+		now := time.Now()
+		var oldestKey string
+		var oldest time.Time
+		for k, e := range c.entries {
+			if now.After(e.expires) {
+				delete(c.entries, k)
+				continue
+			}
+			if oldestKey == "" || e.expires.Before(oldest) {
+				oldestKey, oldest = k, e.expires
+			}
+		}
+		if len(c.entries) >= c.config.MaxEntries {
+			delete(c.entries, oldestKey)
+		}
+	}
+	c.entries[key] = entry
+}
+
+// response returns a new response serving the cached body
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(e.statusCode),
+		StatusCode:    e.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// cacheKey returns the key of the request, the body is part of the key as statistics are read with POST.
+// The API key and session cookies are part of the key too, so a cache shared by clients of different users
+// does not serve the responses of one user to another.
+func cacheKey(req *http.Request) (string, error) {
+	h := sha256.New()
+	io.WriteString(h, req.Method+" "+req.URL.String()+"\n")
+	io.WriteString(h, req.Header.Get(APIKeyHeader)+"\n")
+	io.WriteString(h, strings.Join(req.Header.Values("Cookie"), "; ")+"\n")
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return "", err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		h.Write(body)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,47 @@
+package unifi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCacheIsKeyedByIdentity(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"hostname":"` + r.Header.Get(APIKeyHeader) + `"}]}`))
+	}))
+	defer srv.Close()
+
+	cache := NewCache(CacheConfig{})
+	client := func(apiKey string) *Client {
+		c, err := NewClient(srv.URL, WithCache(cache))
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.SetAPIKey(apiKey)
+		return c
+	}
+	alice, bob := client("alice"), client("bob")
+
+	ctx := context.Background()
+	for _, tt := range []struct {
+		c    *Client
+		want string
+	}{{alice, "alice"}, {bob, "bob"}, {alice, "alice"}, {bob, "bob"}} {
+		resp, err := tt.c.SiteSysInfo(ctx, "default")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := resp.Data[0].HostName; got != tt.want {
+			t.Errorf("got the response of %s, want %s", got, tt.want)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+}
@@ -0,0 +1,168 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CapabilitiesRetryInterval is how long a failed capabilities probe is cached before the controller is probed again
+const CapabilitiesRetryInterval = time.Minute
+
+// ErrUnsupported indicates the controller does not support the requested feature, see UnsupportedError
+var ErrUnsupported = fmt.Errorf("feature not supported by the controller")
+
+// Feature defines a controller feature that depends on the controller version or type
+type Feature string
+
+// The features gated by Capabilities
+const (
+	FeatureV2API          Feature = "v2-api"          // the /v2/api endpoints
+	FeatureTrafficRules   Feature = "traffic-rules"   // traffic rules and routes
+	FeatureWireGuard      Feature = "wireguard"       // the WireGuard VPN server
+	FeatureZoneFirewall   Feature = "zone-firewall"   // firewall zones and policies
+	FeatureIntegrationAPI Feature = "integration-api" // the official integration API
+)
+
+// featureRequirement is the minimum controller version of a feature
+type featureRequirement struct {
+	minVersion string
+	unifiOS    bool // only available on UniFi OS consoles
+}
+
+// featureRequirements lists the requirements of every feature
+var featureRequirements = map[Feature]featureRequirement{
+	FeatureV2API:          {minVersion: "6.0.0"},
+	FeatureTrafficRules:   {minVersion: "7.2.0"},
+	FeatureWireGuard:      {minVersion: "7.1.0"},
+	FeatureZoneFirewall:   {minVersion: "9.0.0"},
+	FeatureIntegrationAPI: {minVersion: "9.0.0", unifiOS: true},
+}
+
+// v2Features maps the v2 API path prefixes to the feature they require in addition to FeatureV2API
+var v2Features = map[string]Feature{
+	"trafficrules":      FeatureTrafficRules,
+	"trafficroutes":     FeatureTrafficRules,
+	"wireguard":         FeatureWireGuard,
+	"firewall/zone":     FeatureZoneFirewall,
+	"firewall-policies": FeatureZoneFirewall,
+}
+
+// UnsupportedError is returned when the controller does not support a feature, it wraps ErrUnsupported
+type UnsupportedError struct {
+	Feature         Feature
+	MinVersion      string // the minimum controller version of the feature
+	Version         string // the controller version
+	RequiresUniFiOS bool   // the feature is only available on UniFi OS consoles
+}
+
+// Error implements error
+func (e *UnsupportedError) Error() string {
+	if e.RequiresUniFiOS {
+		return fmt.Sprintf("%s requires a UniFi OS console with network %s or newer (controller: %s)", e.Feature, e.MinVersion, e.Version)
+	}
+	return fmt.Sprintf("%s requires controller version %s or newer (controller: %s)", e.Feature, e.MinVersion, e.Version)
+}
+
+// Unwrap returns ErrUnsupported
+func (e *UnsupportedError) Unwrap() error {
+	return ErrUnsupported
+}
+
+// Capabilities describes the controller version and type
+type Capabilities struct {
+	Version string // the network application version, e.g. `8.0.24`
+	Build   string
+	UniFiOS bool // the controller is a UniFi OS console
+}
+
+// Supports returns true if the controller supports the feature
+func (c *Capabilities) Supports(feature Feature) bool {
+	return c.Require(feature) == nil
+}
+
+// Require returns an *UnsupportedError if the controller does not support the feature
+func (c *Capabilities) Require(feature Feature) error {
+	req, ok := featureRequirements[feature]
+	if !ok {
+		return fmt.Errorf("invalid feature specified: %s", feature)
+	}
+	if compareVersions(c.Version, req.minVersion) < 0 || (req.unifiOS && !c.UniFiOS) {
+		return &UnsupportedError{Feature: feature, MinVersion: req.minVersion, Version: c.Version, RequiresUniFiOS: req.unifiOS}
+	}
+	return nil
+}
+
+// Capabilities returns the controller capabilities, read from the system info and cached for the lifetime of
+// the client. The UniFi OS detection runs first when it has not happened yet, see DetectUniFiOS.
+// A failed probe is cached for CapabilitiesRetryInterval, the error is returned until then.
+func (c *Client) Capabilities(ctx context.Context) (*Capabilities, error) {
+	c.mu.RLock()
+	capabilities, probeErr, probeErrAt := c.capabilities, c.capabilitiesErr, c.capabilitiesErrAt
+	c.mu.RUnlock()
+	if capabilities != nil {
+		return capabilities, nil
+	}
+	if probeErr != nil && time.Since(probeErrAt) < CapabilitiesRetryInterval {
+		return nil, probeErr
+	}
+
+	capabilities, err := c.probeCapabilities(ctx)
+	if err != nil {
+		// a cancelled or expired context says nothing about the controller
+		if ctx.Err() == nil {
+			c.mu.Lock()
+			c.capabilitiesErr, c.capabilitiesErrAt = err, time.Now()
+			c.mu.Unlock()
+		}
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.capabilities = capabilities
+	c.capabilitiesErr = nil
+	return capabilities, nil
+}
+
+// probeCapabilities reads the capabilities from the controller
+func (c *Client) probeCapabilities(ctx context.Context) (*Capabilities, error) {
+	if detected, _ := c.unifiOSState(); !detected {
+		if _, err := c.DetectUniFiOS(ctx); err != nil {
+			return nil, err
+		}
+	}
+	info, err := c.SysInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Capabilities{Version: info.Version, Build: info.Build, UniFiOS: c.IsUniFiOS()}, nil
+}
+
+// requireFeature returns an *UnsupportedError if the controller does not support the feature.
+// The check is best effort, requests are not blocked when the capabilities can not be read.
+func (c *Client) requireFeature(ctx context.Context, feature Feature) error {
+	capabilities, err := c.Capabilities(ctx)
+	if err != nil {
+		c.log().Debugw("unable to read the controller capabilities", "feature", feature, "error", err)
+		return nil
+	}
+	if capabilities.Version == "" {
+		return nil
+	}
+	return capabilities.Require(feature)
+}
+
+// requireV2Features checks the features required by the v2 API path
+func (c *Client) requireV2Features(ctx context.Context, extPath string) error {
+	if err := c.requireFeature(ctx, FeatureV2API); err != nil {
+		return err
+	}
+	for prefix, feature := range v2Features {
+		if strings.HasPrefix(extPath, prefix) {
+			return c.requireFeature(ctx, feature)
+		}
+	}
+	return nil
+}
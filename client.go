@@ -1,7 +1,7 @@
 package unifi
 
 import (
-	"crypto/tls"
+	"context"
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"path"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -18,6 +19,9 @@ import (
 
 // Client is the object that handles talking to the Unifi Controller API. This maintains
 // state information for a particular application connection.
+// Every request-issuing method accepts a context.Context to allow cancellation and deadlines.
+// A Client is safe for concurrent use by multiple goroutines, the session state is guarded by a mutex.
+// The Set*, Use and On* configuration methods are not, call them before issuing requests.
 type Client struct {
 	baseURLStr string
 	baseURL    *url.URL
@@ -26,8 +30,40 @@ type Client struct {
 	HTTPClient   *http.Client
 	RetryTimeout time.Duration
 
+	mu                 sync.RWMutex // guards the session state, credentials and UniFi OS detection
 	authCookies        []*http.Cookie
 	longRunningSession bool
+	sessionGeneration  uint64 // incremented on every login
+	reloginMu          sync.Mutex
+
+	unifiOSDetected bool
+	isUniFiOS       bool
+	csrfToken       string
+	apiKey          string
+
+	username       string
+	password       string
+	disableRelogin bool
+	reloginHook    ReloginHook
+
+	credentialsProvider CredentialsProvider
+	sessionStore        SessionStore
+	capabilities        *Capabilities
+	capabilitiesErr     error     // the last failed capabilities probe, see CapabilitiesRetryInterval
+	capabilitiesErrAt   time.Time // when the last probe failed
+
+	rateLimiter RateLimiter
+	middlewares []Middleware
+	logger      Logger
+	userAgent   string
+	defaultSite string
+}
+
+// RateLimiter limits the rate of outbound requests to the controller.
+// A *rate.Limiter from golang.org/x/time/rate satisfies this interface.
+type RateLimiter interface {
+	// Wait blocks until the next request is allowed or the context is done
+	Wait(ctx context.Context) error
 }
 
 // CertificationConfig overrides the default HTTP client behavior with certificates.
@@ -46,62 +82,50 @@ type CertificationConfig struct {
 }
 
 // NewClient will create a new UniFi http(s) client.
-func NewClient(baseURL string, certConfig *CertificationConfig, timeout time.Duration) (*Client, error) {
-	httpClient := http.DefaultClient
-	if certConfig != nil {
-		defaultTransport := http.DefaultTransport.(*http.Transport)
-		var tlsConfig *tls.Config
-		if certConfig.DisableCertCheck {
-			tlsConfig = &tls.Config{
-				InsecureSkipVerify: true,
-			}
-		} else if certConfig.PEMCert != "" {
-			cert, err := ioutil.ReadFile(certConfig.PEMCert)
-			if err != nil {
-				return nil, err
-			}
-			certPool := x509.NewCertPool()
-			certPool.AppendCertsFromPEM(cert)
-			tlsConfig = &tls.Config{
-				RootCAs: certPool,
-			}
-		} else if certConfig.Certificates != nil && len(certConfig.Certificates) > 0 {
-			certPool := x509.NewCertPool()
-			for _, cert := range certConfig.Certificates {
-				if cert != nil {
-					certPool.AddCert(cert)
-				}
-			}
-			tlsConfig = &tls.Config{
-				RootCAs: certPool,
-			}
-		}
+// Without options the default certificate checks and a timeout of DefaultTimeout are used, e.g.
+//   c, err := unifi.NewClient(baseURL, unifi.WithInsecureSkipVerify(), unifi.WithTimeout(10*time.Second))
+func NewClient(baseURL string, opts ...Option) (*Client, error) {
+	o := &clientOptions{
+		timeout:     DefaultTimeout,
+		userAgent:   UserAgentHeader,
+		defaultSite: DefaultSite,
 
-		tr := &http.Transport{
-			Proxy:                 defaultTransport.Proxy,
-			DialContext:           defaultTransport.DialContext,
-			MaxIdleConns:          defaultTransport.MaxIdleConns,
-			IdleConnTimeout:       defaultTransport.IdleConnTimeout,
-			ExpectContinueTimeout: defaultTransport.ExpectContinueTimeout,
-			TLSHandshakeTimeout:   defaultTransport.TLSHandshakeTimeout,
-			TLSClientConfig:       tlsConfig,
-		}
-		httpClient = &http.Client{Transport: tr}
+		maxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+	}
+	for _, opt := range opts {
+		opt(o)
 	}
-	httpClient.Timeout = timeout
 
 	u, err := url.Parse(baseURL)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Client{
+	httpClient, err := o.buildHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
 		baseURLStr:   baseURL,
 		baseURL:      u,
-		certConfig:   certConfig,
+		certConfig:   o.certConfig,
 		HTTPClient:   httpClient,
-		RetryTimeout: timeout,
-	}, nil
+		RetryTimeout: o.timeout,
+		userAgent:    o.userAgent,
+		defaultSite:  o.defaultSite,
+		middlewares:  o.middlewares,
+
+		credentialsProvider: o.credentialsProvider,
+		sessionStore:        o.sessionStore,
+	}
+	c.restoreSession(context.Background())
+	return c, nil
+}
+
+// DefaultSite returns the site used by site requests issued with an empty site name.
+func (c *Client) DefaultSite() string {
+	return c.defaultSite
 }
 
 // SetBaseURL changes the value of baseURL.
@@ -120,17 +144,27 @@ func (c *Client) GetBaseURL() string {
 	return c.baseURLStr
 }
 
+// SetRateLimiter applies a rate limiter to all outbound requests, set to nil to disable rate limiting.
+func (c *Client) SetRateLimiter(limiter RateLimiter) {
+	c.rateLimiter = limiter
+}
+
 // SetHeaders will set the client headers for auth along with additional pre-defined API headers.
 func (c *Client) SetHeaders(r *http.Request) {
 	r.Header.Set("Content-Type", ContentTypeHeader)
 	r.Header.Set("Accept", ContentTypeHeader)
 	r.Header.Set("Cache-Control", "no-cache")
 	r.Header.Set("Accept-Charset", "utf-8")
-	r.Header.Set("User-Agent", UserAgentHeader)
-	if c.authCookies != nil {
-		for _, cookie := range c.authCookies {
-			r.AddCookie(cookie)
-		}
+	r.Header.Set("User-Agent", c.userAgent)
+	if c.apiKey != "" {
+		r.Header.Set(APIKeyHeader, c.apiKey)
+	}
+	cookies, csrfToken, _ := c.sessionState()
+	if csrfToken != "" {
+		r.Header.Set(CSRFTokenHeader, csrfToken)
+	}
+	for _, cookie := range cookies {
+		r.AddCookie(cookie)
 	}
 }
 
@@ -162,26 +196,50 @@ type ResponseMessageTrait interface {
 	GetResponseMessage() string
 }
 
-func (c *Client) doRequest(method string, extPath string, sendBody io.Reader, ret interface{}, queryParamsPairs ...string) error {
-	u := c.WithPathAndQueryParams(extPath)
+func (c *Client) doRequest(ctx context.Context, method string, extPath string, sendBody io.Reader, ret interface{}, queryParamsPairs ...string) error {
+	opts := requestOptionsFrom(ctx)
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
+	u := c.WithPathAndQueryParams(c.apiPath(extPath), queryParamsPairs...)
 
 	rv := reflect.ValueOf(ret)
-	if !rv.IsNil() && rv.Kind() != reflect.Ptr {
-		return fmt.Errorf("non nil-response handlers should be a pointer: kind:%v nil:%t", rv.Kind(), rv.IsNil())
+	if ret != nil && rv.Kind() != reflect.Ptr {
+		return fmt.Errorf("non nil-response handlers should be a pointer: kind:%v", rv.Kind())
 	}
 
-	req, err := http.NewRequest(method, u.String(), sendBody)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), sendBody)
 	if err != nil {
 		return err
 	}
 	c.SetHeaders(req)
+	if opts.contentType != "" {
+		req.Header.Set("Content-Type", opts.contentType)
+	}
+	_, _, generation := c.sessionState()
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.doWithRetry(req, opts.retry)
 	if err != nil {
 		return err
 	}
+	if resp.StatusCode == http.StatusUnauthorized && c.canRelogin() {
+		resp.Body.Close()
+		resp, err = c.relogin(req, generation)
+		if err != nil {
+			return err
+		}
+	}
+	defer resp.Body.Close()
 
-	if !rv.IsNil() {
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := newAPIError(resp)
+		c.log().Warnw("controller returned an error", "method", method, "path", u.Path, "error", apiErr)
+		return apiErr
+	}
+
+	if ret != nil && !rv.IsNil() {
 		body, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
 			return errors.Wrap(err, ErrInvalidResponseBody.Error())
@@ -191,29 +249,135 @@ func (c *Client) doRequest(method string, extPath string, sendBody io.Reader, re
 		if err != nil {
 			return errors.Wrap(err, ErrJSONDecode.Error())
 		}
-		if rv.Kind() == reflect.Struct {
-			metaField := rv.FieldByName("Meta")
-
-			if metaField.IsValid() {
-				if retRespCodeTrait, ok := metaField.Interface().(ResponseCodeTrait); ok {
-					rc := retRespCodeTrait.GetResponseCode()
-					if !rc.Equal(ResponseCodeOK) {
-						if retRespCodeMsgTrait, ok := metaField.Interface().(ResponseMessageTrait); ok {
-							msg := retRespCodeMsgTrait.GetResponseMessage()
-							if msg != "" {
-								return fmt.Errorf(msg)
-							}
-						}
-						return fmt.Errorf("non-ok status code: %v - %v", rc, ret)
-					}
-				}
-			}
+		if err := metaError(resp.StatusCode, rv); err != nil {
+			c.log().Warnw("controller returned an error", "method", method, "path", u.Path, "error", err)
+			return err
 		}
 	}
 
 	return nil
 }
 
-func (c *Client) doSiteRequest(method string, site string, extPath string, sendBody io.Reader, ret interface{}, queryParamsPairs ...string) error {
-	return c.doRequest(method, fmt.Sprintf("/api/s/%s/%s", site, extPath), sendBody, ret, queryParamsPairs...)
+// metaError returns an *APIError when the response has a meta envelope with a response code other than `ok`.
+// Responses without a meta envelope, or with an empty response code, are not checked.
+func metaError(statusCode int, rv reflect.Value) error {
+	rv = reflect.Indirect(rv)
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	metaField := rv.FieldByName("Meta")
+	if !metaField.IsValid() {
+		return nil
+	}
+	meta := metaField.Interface()
+	if metaField.CanAddr() {
+		// the traits are implemented on the pointer
+		meta = metaField.Addr().Interface()
+	}
+	retRespCodeTrait, ok := meta.(ResponseCodeTrait)
+	if !ok {
+		return nil
+	}
+	rc := retRespCodeTrait.GetResponseCode()
+	if rc == "" || rc.Equal(ResponseCodeOK) {
+		return nil
+	}
+	apiErr := &APIError{StatusCode: statusCode, ResponseCode: rc}
+	if retRespCodeMsgTrait, ok := meta.(ResponseMessageTrait); ok {
+		apiErr.Message = retRespCodeMsgTrait.GetResponseMessage()
+	}
+	return apiErr
+}
+
+// do sends the request through the middlewares, waiting on the rate limiter when one is configured
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+	start := time.Now()
+	resp, err := c.roundTrip(req)
+	if err != nil {
+		c.log().Warnw("request failed", "method", req.Method, "path", req.URL.Path, "error", err)
+		return nil, err
+	}
+	c.log().Debugw("request", "method", req.Method, "path", req.URL.Path, "status", resp.StatusCode, "duration", time.Since(start))
+	if token := resp.Header.Get(UpdatedCSRFTokenHeader); token != "" {
+		if _, current, _ := c.sessionState(); token != current {
+			c.setCSRFToken(token)
+			c.persistSession(req.Context())
+		}
+	}
+	return resp, nil
+}
+
+// doDownload issues a GET for the controller file path and returns the response body, the caller must close it
+func (c *Client) doDownload(ctx context.Context, extPath string) (io.ReadCloser, error) {
+	opts := requestOptionsFrom(ctx)
+	cancel := context.CancelFunc(func() {})
+	if opts.timeout > 0 {
+		// the timeout also bounds reading the body, it is released once the body is closed
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+	}
+	u := c.WithPathAndQueryParams(c.apiPath(extPath))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	c.SetHeaders(req)
+	req.Header.Set("Accept", "*/*")
+	_, _, generation := c.sessionState()
+
+	resp, err := c.doWithRetry(req, opts.retry)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && c.canRelogin() {
+		resp.Body.Close()
+		resp, err = c.relogin(req, generation)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer cancel()
+		defer resp.Body.Close()
+		return nil, newAPIError(resp)
+	}
+	return &cancelReadCloser{ReadCloser: resp.Body, cancel: cancel}, nil
+}
+
+// cancelReadCloser releases the request context once the body is closed
+type cancelReadCloser struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and releases the request context
+func (r *cancelReadCloser) Close() error {
+	defer r.cancel()
+	return r.ReadCloser.Close()
+}
+
+// doSiteRequest issues a request against the site API, the default site is used when site is empty
+func (c *Client) doSiteRequest(ctx context.Context, method string, site string, extPath string, sendBody io.Reader, ret interface{}, queryParamsPairs ...string) error {
+	if site == "" {
+		site = c.defaultSite
+	}
+	return c.doRequest(ctx, method, fmt.Sprintf("/api/s/%s/%s", site, extPath), sendBody, ret, queryParamsPairs...)
+}
+
+// doSiteV2Request issues a request against the v2 API of newer controllers, these responses have no meta envelope
+func (c *Client) doSiteV2Request(ctx context.Context, method string, site string, extPath string, sendBody io.Reader, ret interface{}, queryParamsPairs ...string) error {
+	if site == "" {
+		site = c.defaultSite
+	}
+	if err := c.requireV2Features(ctx, extPath); err != nil {
+		return err
+	}
+	return c.doRequest(ctx, method, fmt.Sprintf("/v2/api/site/%s/%s", site, extPath), sendBody, ret, queryParamsPairs...)
 }
@@ -0,0 +1,198 @@
+package unifi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestController returns a classic controller serving login, the sites and the site reports
+func newTestController(tb testing.TB) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/":
+			http.Redirect(w, r, "/manage", http.StatusFound)
+		case r.URL.Path == "/api/login":
+			http.SetCookie(w, &http.Cookie{Name: "unifises", Value: "session"})
+			_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+		case r.URL.Path == "/api/self/sites":
+			_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"1","name":"default","desc":"Default"}]}`))
+		case strings.HasPrefix(r.URL.Path, "/api/s/default/stat/report/"):
+			_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"time":1600000000000,"num_sta":4,"wan-tx_bytes":1024.5}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	tb.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestClient(tb testing.TB) *Client {
+	c, err := NewClient(newTestController(tb).URL)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return c
+}
+
+// TestClientConcurrentUse is meant to run with -race, it mixes the lazy UniFi OS detection, logins and
+// requests that read the session and detection state
+func TestClientConcurrentUse(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	end := time.Now()
+	start := end.Add(-time.Hour)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for i := 0; i < 8; i++ {
+		wg.Add(4)
+		go func() {
+			defer wg.Done()
+			if _, err := c.DetectUniFiOS(ctx); err != nil {
+				errs <- err
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if err := c.Login(ctx, "admin", "secret", false); err != nil {
+				errs <- err
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := c.ListSites(ctx); err != nil {
+				errs <- err
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := c.SiteReport(ctx, "default", start, end, ReportInterval5Min, ReportTypeSite,
+				[]ReportAttribute{ReportAttributeNumberSTA, ReportAttributeWANTXBytes})
+			if err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	if c.IsUniFiOS() {
+		t.Error("expected a classic controller")
+	}
+}
+
+func TestSetUniFiOSConcurrentWithRequests(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			c.SetUniFiOS(false)
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := c.ListSites(ctx); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkSiteReport(b *testing.B) {
+	c := newTestClient(b)
+	ctx := context.Background()
+	end := time.Now()
+	start := end.Add(-time.Hour)
+	attributes := []ReportAttribute{ReportAttributeNumberSTA, ReportAttributeWANTXBytes}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := c.SiteReport(ctx, "default", start, end, ReportInterval5Min, ReportTypeSite, attributes); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSiteReportParallel(b *testing.B) {
+	c := newTestClient(b)
+	ctx := context.Background()
+	end := time.Now()
+	start := end.Add(-time.Hour)
+	attributes := []ReportAttribute{ReportAttributeNumberSTA, ReportAttributeWANTXBytes}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := c.SiteReport(ctx, "default", start, end, ReportInterval5Min, ReportTypeSite, attributes); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+func TestCapabilitiesCachesFailedProbe(t *testing.T) {
+	var probes int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			http.Redirect(w, r, "/manage", http.StatusFound)
+			return
+		}
+		atomic.AddInt32(&probes, 1)
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	c, err := NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if _, err := c.Capabilities(ctx); err == nil {
+			t.Fatal("expected the probe to fail")
+		}
+	}
+	if n := atomic.LoadInt32(&probes); n != 1 {
+		t.Errorf("expected 1 probe, got %d", n)
+	}
+}
+
+func TestDetectUniFiOSUsesMiddlewares(t *testing.T) {
+	c := newTestClient(t)
+	var seen int32
+	c.Use(func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/" {
+				atomic.AddInt32(&seen, 1)
+			}
+			return next(req)
+		}
+	})
+
+	enabled, err := c.DetectUniFiOS(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if enabled {
+		t.Error("expected the redirect of a classic controller not to be followed")
+	}
+	if n := atomic.LoadInt32(&seen); n != 1 {
+		t.Errorf("expected the middleware to see 1 detection request, got %d", n)
+	}
+}
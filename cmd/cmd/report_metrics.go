@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -161,7 +162,7 @@ func getWorkerCount(cmd *cobra.Command) int {
 }
 
 func collectAndReportStats(workerCount int, reporters reporters.Reporters, siteCfg map[string]siteConfig) {
-	sites, err := client.AvailableSites()
+	sites, err := client.AvailableSites(context.Background())
 	if err != nil {
 		logger.Error("unable to query sites", zap.Error(err))
 		return
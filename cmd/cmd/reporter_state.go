@@ -36,8 +36,8 @@ func initReporterState() {
 				logger.Error("unable to create state directory", zap.String("directory", stateDir), zap.Error(err))
 				os.Exit(-1)
 			}
-			os.Chown(stateDir, os.Getuid(), os.Getgid())
-			// if err != nil {
+			err = os.Chown(stateDir, os.Getuid(), os.Getgid())
+			if err != nil {
 				logger.Error("unable to create state directory", zap.String("directory", stateDir), zap.Error(err))
 				os.Exit(-1)
 			}
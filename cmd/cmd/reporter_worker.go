@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"sync"
@@ -18,14 +19,15 @@ type reporterWorker struct {
 
 func (w *reporterWorker) ReportAlarmStats() {
 	logger.Debug("collecting alarm stats", zap.String("site", w.site.Name))
+	ctx := context.Background()
 	// load last alarm state
 	alarmLastTimestamp := db.LastAlarmTimestamp(w.site.ID)
 
-	alarmsResp, err := client.SiteAlarmsCount(w.site.ID, 24, false)
+	alarmsResp, err := client.SiteAlarmsCount(ctx, w.site.ID, 24, false)
 	if err == nil {
 		w.reporters.ReportMetric(reporters.CountMetricType, "alarm.count", float64(alarmsResp.Meta.Count), "archived:false")
 	}
-	alarmsResp, err = client.SiteAlarmsCount(w.site.ID, 24, true)
+	alarmsResp, err = client.SiteAlarmsCount(ctx, w.site.ID, 24, true)
 	if err == nil {
 		w.reporters.ReportMetric(reporters.CountMetricType, "alarm.count", float64(alarmsResp.Meta.Count), "archived:true")
 	}
@@ -35,7 +37,7 @@ func (w *reporterWorker) ReportAlarmStats() {
 	now := time.Now().UTC()
 	// max 1 week (768 hours)
 	historyHours := math.Min(math.Ceil((-time.Duration(now.Unix() - alarmLastTimestamp) * time.Second).Hours()), 768)
-	newAlarmsData, err := client.SiteAlarms(w.site.ID, int(historyHours), offset, limit, unifi.EventSortOrderTimeDescending, false)
+	newAlarmsData, err := client.SiteAlarms(ctx, w.site.ID, int(historyHours), offset, limit, unifi.EventSortOrderTimeDescending, false)
 	if err == nil {
 		newCount := 0
 		for _, alarm := range newAlarmsData.Data {
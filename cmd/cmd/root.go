@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"crypto/x509"
 	"fmt"
 	"io/ioutil"
@@ -151,13 +152,14 @@ func initConfig() {
 	if viper.GetString("pemCert") != "" {
 		certConfig.PEMCert = viper.GetString("pemCert")
 	}
-	client, err = unifi.NewClient(baseURL.String(), &certConfig, viper.GetDuration("timeout"))
+	client, err = unifi.NewClient(baseURL.String(), unifi.WithCertificationConfig(&certConfig), unifi.WithTimeout(viper.GetDuration("timeout")))
 	if err != nil {
 		logger.Error("unable to initialize client", zap.Error(err))
 	} else {
 		logger.Debug("initialized client")
+		client.SetLogger(logger.Sugar())
 	}
-	err = client.Login(viper.GetString("username"), viper.GetString("password"), false)
+	err = client.Login(context.Background(), viper.GetString("username"), viper.GetString("password"), false)
 	if err != nil {
 		logger.Error("unable to authenticate against controller", zap.Error(err))
 	} else {
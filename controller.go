@@ -1,6 +1,7 @@
 package unifi
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 )
@@ -32,9 +33,9 @@ type ControllerStatus struct {
 
 // ControllerStatus returns some very basic server information
 // This appears to be the only endpoint that can be reached without an authentication
-func (c *Client) ControllerStatus() (*ControllerStatus, error) {
+func (c *Client) ControllerStatus(ctx context.Context) (*ControllerStatus, error) {
 	var status ControllerStatus
-	err := c.doRequest(http.MethodGet, "/status", nil, &status)
+	err := c.doRequest(ctx, http.MethodGet, "/status", nil, &status)
 	return &status, err
 }
 
@@ -60,12 +61,32 @@ type SitesResponse struct {
 }
 
 // AvailableSites returns the available sites for the controller.
-func (c *Client) AvailableSites() (*SitesResponse, error) {
+func (c *Client) AvailableSites(ctx context.Context) (*SitesResponse, error) {
 	var ret SitesResponse
-	err := c.doRequest(http.MethodGet, "/api/self/sites", nil, &ret)
+	err := c.doRequest(ctx, http.MethodGet, "/api/self/sites", nil, &ret)
 	return &ret, err
 }
 
+// ListSites lists the sites available to the logged in user.
+func (c *Client) ListSites(ctx context.Context) (*SitesResponse, error) {
+	return c.AvailableSites(ctx)
+}
+
+// GetSite returns a single site by its name, e.g. `default`
+// name - the site name
+func (c *Client) GetSite(ctx context.Context, name string) (*SitesResponseData, error) {
+	resp, err := c.AvailableSites(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range resp.Data {
+		if resp.Data[i].Name == name {
+			return &resp.Data[i], nil
+		}
+	}
+	return nil, ErrSiteNotFound
+}
+
 // SitesVerboseGatewaySystemStats contains gateway system stats
 type SitesVerboseGatewaySystemStats struct {
 	CPUUsage    interface{} `json:"cpu"`    // these come back as strings >.<
@@ -183,12 +204,27 @@ type SitesVerboseResponse struct {
 }
 
 // AvailableSitesVerbose returns the available sites with verbose health data
-func (c *Client) AvailableSitesVerbose() (*SitesVerboseResponse, error) {
+func (c *Client) AvailableSitesVerbose(ctx context.Context) (*SitesVerboseResponse, error) {
 	var ret SitesVerboseResponse
-	err := c.doRequest(http.MethodGet, "/api/stat/sites", nil, &ret)
+	err := c.doRequest(ctx, http.MethodGet, "/api/stat/sites", nil, &ret)
 	return &ret, err
 }
 
+// SiteHealthSummary returns the site with its health summary from stat/sites
+// site - the site name
+func (c *Client) SiteHealthSummary(ctx context.Context, site string) (*SitesVerboseResponseData, error) {
+	resp, err := c.AvailableSitesVerbose(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range resp.Data {
+		if resp.Data[i].Name == site {
+			return &resp.Data[i], nil
+		}
+	}
+	return nil, ErrSiteNotFound
+}
+
 // SiteAdminsResponse returns the stat/admin response data
 type SiteAdminsResponse struct {
 	Meta CommonMeta               `json:"meta"`
@@ -196,8 +232,8 @@ type SiteAdminsResponse struct {
 }
 
 // SiteAdmins returns a list of administrators and permissions for all sites
-func (c *Client) SiteAdmins() (*SiteAdminsResponse, error) {
+func (c *Client) SiteAdmins(ctx context.Context) (*SiteAdminsResponse, error) {
 	var resp SiteAdminsResponse
-	err := c.doRequest(http.MethodGet, "/api/stat/admin", nil, &resp)
+	err := c.doRequest(ctx, http.MethodGet, "/api/stat/admin", nil, &resp)
 	return &resp, err
 }
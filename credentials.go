@@ -0,0 +1,146 @@
+package unifi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// Default environment variables of EnvCredentials
+const (
+	DefaultUsernameEnv = "UNIFI_USERNAME"
+	DefaultPasswordEnv = "UNIFI_PASSWORD"
+)
+
+// Credentials are the username and password used to login
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// CredentialsProvider returns the credentials used to login. It is called on every login and re-login,
+// so credentials rotated in e.g. a secret store are picked up without recreating the client.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// CredentialsProviderFunc adapts a function to a CredentialsProvider
+type CredentialsProviderFunc func(ctx context.Context) (Credentials, error)
+
+// Credentials implements CredentialsProvider
+func (f CredentialsProviderFunc) Credentials(ctx context.Context) (Credentials, error) {
+	return f(ctx)
+}
+
+// StaticCredentials returns a provider always returning the same credentials
+func StaticCredentials(username string, password string) CredentialsProvider {
+	return CredentialsProviderFunc(func(ctx context.Context) (Credentials, error) {
+		return Credentials{Username: username, Password: password}, nil
+	})
+}
+
+// EnvCredentials returns a provider reading the credentials from environment variables on every call
+// usernameEnv - the username variable, DefaultUsernameEnv when empty
+// passwordEnv - the password variable, DefaultPasswordEnv when empty
+func EnvCredentials(usernameEnv string, passwordEnv string) CredentialsProvider {
+	if usernameEnv == "" {
+		usernameEnv = DefaultUsernameEnv
+	}
+	if passwordEnv == "" {
+		passwordEnv = DefaultPasswordEnv
+	}
+	return CredentialsProviderFunc(func(ctx context.Context) (Credentials, error) {
+		username := os.Getenv(usernameEnv)
+		if username == "" {
+			return Credentials{}, fmt.Errorf("environment variable %s is not set", usernameEnv)
+		}
+		return Credentials{Username: username, Password: os.Getenv(passwordEnv)}, nil
+	})
+}
+
+// FileCredentials returns a provider reading the credentials from a file on every call, e.g. a mounted secret.
+// The file is either a JSON object with username and password, or the username and password on the first two lines.
+// path - the credentials file
+func FileCredentials(path string) CredentialsProvider {
+	return CredentialsProviderFunc(func(ctx context.Context) (Credentials, error) {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return Credentials{}, err
+		}
+
+		var creds Credentials
+		if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+			if err := json.Unmarshal(trimmed, &creds); err != nil {
+				return Credentials{}, fmt.Errorf("invalid credentials file %s: %v", path, err)
+			}
+		} else {
+			scanner := bufio.NewScanner(bytes.NewReader(data))
+			if scanner.Scan() {
+				creds.Username = strings.TrimSpace(scanner.Text())
+			}
+			if scanner.Scan() {
+				creds.Password = strings.TrimRight(scanner.Text(), "\r")
+			}
+		}
+		if creds.Username == "" {
+			return Credentials{}, fmt.Errorf("credentials file %s has no username", path)
+		}
+		return creds, nil
+	})
+}
+
+// WithCredentialsProvider sets the provider used by LoginWithProvider and by every re-login, see SetCredentialsProvider
+func WithCredentialsProvider(provider CredentialsProvider) Option {
+	return func(o *clientOptions) {
+		o.credentialsProvider = provider
+	}
+}
+
+// SetCredentialsProvider sets the provider used by LoginWithProvider and by every re-login,
+// set to nil to re-login with the credentials of the last login.
+func (c *Client) SetCredentialsProvider(provider CredentialsProvider) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.credentialsProvider = provider
+}
+
+// LoginWithProvider will login with the credentials of the configured provider, see Login.
+// Re-logins query the provider again, so rotated credentials are picked up.
+// if remember=true for long-running sessions.
+func (c *Client) LoginWithProvider(ctx context.Context, remember bool) error {
+	provider := c.provider()
+	if provider == nil {
+		return fmt.Errorf("no credentials provider configured")
+	}
+	creds, err := provider.Credentials(ctx)
+	if err != nil {
+		return err
+	}
+	return c.Login(ctx, creds.Username, creds.Password, remember)
+}
+
+// provider returns the configured credentials provider
+func (c *Client) provider() CredentialsProvider {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.credentialsProvider
+}
+
+// reloginCredentials returns the credentials of a re-login, the provider takes precedence over the stored credentials
+func (c *Client) reloginCredentials(ctx context.Context) (string, string, error) {
+	if provider := c.provider(); provider != nil {
+		creds, err := provider.Credentials(ctx)
+		if err != nil {
+			return "", "", err
+		}
+		c.setCredentials(creds.Username, creds.Password)
+		return creds.Username, creds.Password, nil
+	}
+	username, password := c.credentials()
+	return username, password, nil
+}
@@ -1,6 +1,7 @@
 package unifi
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -14,8 +15,13 @@ const (
 
 // Common errors
 const (
-	APINoPermissionError = "api.err.NoPermission"
-	APIInvalidError      = "api.err.Invalid"
+	APINoPermissionError   = "api.err.NoPermission"
+	APIInvalidError        = "api.err.Invalid"
+	APILoginRequiredError  = "api.err.LoginRequired"
+	APINoSiteContextError  = "api.err.NoSiteContext"
+	APIInvalidObjectError  = "api.err.InvalidObject"
+	APIIDInvalidError      = "api.err.IdInvalid"
+	APIInvalidPayloadError = "api.err.InvalidPayload"
 )
 
 // ErrInvalidResponseBody indicates and error with the body of the response
@@ -24,12 +30,21 @@ var ErrInvalidResponseBody = fmt.Errorf("invalid response body")
 // ErrJSONDecode indicates an unexpected unmarshal problem from the API, check this is a valid endpoint.
 var ErrJSONDecode = fmt.Errorf("unable to unmarshal json response")
 
+// ErrDeviceNotFound indicates the requested device is not known to the site.
+var ErrDeviceNotFound = fmt.Errorf("device not found")
+
+// ErrSiteNotFound indicates the requested site is not available to the logged in user.
+var ErrSiteNotFound = fmt.Errorf("site not found")
+
+// ErrUserGroupNotFound indicates the requested user group does not exist on the site.
+var ErrUserGroupNotFound = fmt.Errorf("user group not found")
+
 // ResponseCode is the api response code, typically just `ok` or `err`
 type ResponseCode string
 
 // MarshalJSON implements json.Marshaler
 func (r ResponseCode) MarshalJSON() ([]byte, error) {
-	return []byte(string(r)), nil
+	return json.Marshal(string(r))
 }
 
 // UnmarshalJSON implements json.Unmarshaler
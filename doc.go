@@ -0,0 +1,18 @@
+// Package unifi is a client for the Ubiquiti UniFi network controller, classic controllers and UniFi OS consoles.
+//
+// # Per-request options
+//
+// Timeouts and retries of a single request are set with RequestOption values. The generic Do, Get, Post, DoV2
+// and Client.Raw take them as a variadic parameter:
+//
+//	health, err := unifi.Get[unifi.SiteHealthData](ctx, c, "default", "stat/health", unifi.WithRequestTimeout(2*time.Second))
+//
+// The typed methods, e.g. ListDevices, Report or ListUsers, do not take an options parameter. The context is
+// the supported way to pass options to them, see WithRequestOptions:
+//
+//	ctx = unifi.WithRequestOptions(ctx, unifi.WithRetryPolicy(unifi.RetryPolicy{MaxAttempts: 3}))
+//	devices, err := c.ListDevices(ctx, "default")
+//
+// Options carried by the context apply to every request issued with it, including the requests of methods
+// issuing several requests, e.g. the chunks of a report query.
+package unifi
@@ -0,0 +1,108 @@
+package unifi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// APIError is returned when the controller rejects a request, either with a non-2xx HTTP status
+// or with a non-ok meta response code.
+type APIError struct {
+	StatusCode   int          // the HTTP status code
+	ResponseCode ResponseCode // the meta.rc value, typically `error`
+	Message      string       // the meta.msg value, e.g. `api.err.LoginRequired`
+}
+
+// Error implements error
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("unifi api error: %s (status: %d, rc: %s)", e.Message, e.StatusCode, e.ResponseCode)
+	}
+	return fmt.Sprintf("unifi api error: status: %d, rc: %s", e.StatusCode, e.ResponseCode)
+}
+
+// newAPIError builds the error from a failed response, the body is consumed.
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode:   resp.StatusCode,
+		ResponseCode: ResponseCodeError,
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return apiErr
+	}
+	var errResp struct {
+		Meta    CommonMeta `json:"meta"`
+		Message string     `json:"message"` // UniFi OS and integration API errors
+	}
+	if json.Unmarshal(body, &errResp) != nil {
+		return apiErr
+	}
+	if errResp.Meta.ResponseCode != "" {
+		apiErr.ResponseCode = errResp.Meta.ResponseCode
+	}
+	apiErr.Message = errResp.Meta.ResponseCodeMessage
+	if apiErr.Message == "" {
+		apiErr.Message = errResp.Message
+	}
+	return apiErr
+}
+
+// AsAPIError returns the APIError wrapped in err, if any
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// hasAPIMessage returns true if err is an APIError with the given meta.msg
+func hasAPIMessage(err error, msg string) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.Message == msg
+}
+
+// IsLoginRequired returns true if the session is missing or expired
+func IsLoginRequired(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && (apiErr.Message == APILoginRequiredError || apiErr.StatusCode == http.StatusUnauthorized)
+}
+
+// IsNoPermission returns true if the logged in user is not allowed to perform the request
+func IsNoPermission(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && (apiErr.Message == APINoPermissionError || apiErr.StatusCode == http.StatusForbidden)
+}
+
+// IsNoSiteContext returns true if the requested site does not exist
+func IsNoSiteContext(err error) bool {
+	return hasAPIMessage(err, APINoSiteContextError)
+}
+
+// IsInvalid returns true if the controller rejected the request payload
+func IsInvalid(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		return false
+	}
+	switch apiErr.Message {
+	case APIInvalidError, APIInvalidObjectError, APIIDInvalidError, APIInvalidPayloadError:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsNotFound returns true if the requested resource does not exist
+func IsNotFound(err error) bool {
+	if err == ErrDeviceNotFound || err == ErrSiteNotFound || err == ErrUserGroupNotFound {
+		return true
+	}
+	apiErr, ok := AsAPIError(err)
+	return ok && (apiErr.StatusCode == http.StatusNotFound || apiErr.Message == APIIDInvalidError)
+}
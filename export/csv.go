@@ -0,0 +1,52 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/platinummonkey/unifi"
+)
+
+// WriteCSV writes the table as CSV with a header row.
+// Times are written as RFC3339 with their offset, missing values are written as empty fields.
+func WriteCSV(w io.Writer, table *Table) error {
+	cw := csv.NewWriter(w)
+	header := make([]string, len(table.Columns))
+	for i, column := range table.Columns {
+		header[i] = column.Name
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(table.Columns))
+	for _, row := range table.Rows {
+		for i, v := range row {
+			switch t := v.(type) {
+			case time.Time:
+				record[i] = t.Format(time.RFC3339)
+			case float64:
+				record[i] = strconv.FormatFloat(t, 'f', -1, 64)
+			case bool:
+				record[i] = strconv.FormatBool(t)
+			case string:
+				record[i] = t
+			default:
+				record[i] = ""
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteReportCSV writes the report data as CSV, see ReportTable for the columns
+// resp - the report response
+func WriteReportCSV(w io.Writer, resp *unifi.SiteReportsResponse) error {
+	return WriteCSV(w, ReportTable(resp))
+}
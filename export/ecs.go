@@ -0,0 +1,159 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/platinummonkey/unifi"
+)
+
+// ECSVersion is the Elastic Common Schema version of the documents
+const ECSVersion = "8.11.0"
+
+// ECSDocument is an intrusion alert in the Elastic Common Schema, ready for ingestion by Elasticsearch or
+// any SIEM accepting ECS JSON
+type ECSDocument struct {
+	Timestamp time.Time `json:"@timestamp"`
+	ECS       struct {
+		Version string `json:"version"`
+	} `json:"ecs"`
+	Event       ECSEvent          `json:"event"`
+	Rule        ECSRule           `json:"rule"`
+	Source      ECSEndpoint       `json:"source"`
+	Destination ECSEndpoint       `json:"destination"`
+	Network     ECSNetwork        `json:"network"`
+	Observer    ECSObserver       `json:"observer"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Suricata    struct {
+		EVE unifi.EVERecord `json:"eve"`
+	} `json:"suricata"`
+}
+
+// ECSEvent is the event field set
+type ECSEvent struct {
+	Kind     string   `json:"kind"`
+	Category []string `json:"category"`
+	Type     []string `json:"type"`
+	Action   string   `json:"action,omitempty"`
+	Severity int      `json:"severity,omitempty"`
+	Module   string   `json:"module"`
+	Dataset  string   `json:"dataset"`
+	ID       string   `json:"id,omitempty"`
+}
+
+// ECSRule is the rule field set, describing the signature that matched
+type ECSRule struct {
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Category string `json:"category,omitempty"`
+	Version  string `json:"version,omitempty"`
+}
+
+// ECSEndpoint is the source or destination field set
+type ECSEndpoint struct {
+	IP   string `json:"ip,omitempty"`
+	Port int    `json:"port,omitempty"`
+	MAC  string `json:"mac,omitempty"`
+}
+
+// ECSNetwork is the network field set
+type ECSNetwork struct {
+	Transport string `json:"transport,omitempty"`
+	Protocol  string `json:"protocol,omitempty"`
+}
+
+// ECSObserver is the observer field set, the gateway that raised the alert
+type ECSObserver struct {
+	Vendor   string `json:"vendor"`
+	Product  string `json:"product"`
+	Type     string `json:"type"`
+	Hostname string `json:"hostname,omitempty"`
+	MAC      string `json:"mac,omitempty"`
+	Ingress  struct {
+		Interface struct {
+			Name string `json:"name,omitempty"`
+		} `json:"interface"`
+	} `json:"ingress"`
+}
+
+// ECSFromIPSAlert maps the intrusion alert to an ECS document, the original Suricata EVE record is kept in
+// `suricata.eve` as the Elastic Suricata module does
+// site - the site name added as the `site` label
+// alert - the intrusion alert
+func ECSFromIPSAlert(site string, alert unifi.EventIPSAlert) ECSDocument {
+	doc := ECSDocument{
+		Timestamp: alert.EventTime(),
+		Event: ECSEvent{
+			Kind:     "alert",
+			Category: []string{"network", "intrusion_detection"},
+			Type:     []string{ecsEventType(alert.InnerAlertAction)},
+			Action:   alert.InnerAlertAction,
+			Severity: alert.InnerAlertSeverity,
+			Module:   "unifi",
+			Dataset:  "unifi.ips",
+			ID:       alert.UniqueAlertID,
+		},
+		Rule: ECSRule{
+			Name:     alert.InnerAlertSignature,
+			Category: alert.InnerAlertCategory,
+		},
+		Source:      ECSEndpoint{IP: alert.SourceIP, Port: alert.SourcePort, MAC: ecsMAC(alert.SourceMAC)},
+		Destination: ECSEndpoint{IP: alert.DestinationIP, Port: alert.DestinationPort, MAC: ecsMAC(alert.DestinationMAC)},
+		Network: ECSNetwork{
+			Transport: strings.ToLower(alert.Protocol),
+			Protocol:  strings.ToLower(alert.AppProtocol),
+		},
+		Observer: ECSObserver{
+			Vendor:   "Ubiquiti",
+			Product:  "UniFi",
+			Type:     "ids",
+			Hostname: alert.Host,
+			MAC:      ecsMAC(alert.Gateway),
+		},
+	}
+	if alert.InnerAlertSignatureID != 0 {
+		doc.Rule.ID = strconv.Itoa(alert.InnerAlertSignatureID)
+		doc.Rule.Version = strconv.Itoa(alert.InnerAlertRevision)
+	}
+	doc.ECS.Version = ECSVersion
+	doc.Suricata.EVE = alert.EVE()
+	doc.Observer.Ingress.Interface.Name = alert.InterfaceIn
+	if site != "" {
+		doc.Labels = map[string]string{"site": site}
+	}
+	return doc
+}
+
+// WriteECS writes the intrusion alerts as newline delimited ECS JSON, the format of the Elasticsearch bulk
+// and file based ingestion
+// site - the site name added as the `site` label
+// alerts - the intrusion alerts, e.g. from ListIPSEvents
+func WriteECS(w io.Writer, site string, alerts []unifi.EventIPSAlert) error {
+	enc := json.NewEncoder(w)
+	for _, alert := range alerts {
+		if err := enc.Encode(ECSFromIPSAlert(site, alert)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ecsEventType returns the ECS event type of the alert action
+func ecsEventType(action string) string {
+	switch strings.ToLower(action) {
+	case "blocked", "drop", "dropped", "rejected":
+		return "denied"
+	case "allowed":
+		return "allowed"
+	default:
+		return "info"
+	}
+}
+
+// ecsMAC formats the mac as ECS expects, upper case separated by hyphens
+func ecsMAC(mac string) string {
+	return strings.ToUpper(strings.ReplaceAll(mac, ":", "-"))
+}
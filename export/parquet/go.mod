@@ -0,0 +1,20 @@
+module github.com/platinummonkey/unifi/export/parquet
+
+go 1.20
+
+require (
+	github.com/platinummonkey/unifi v0.0.0-20261015081524-23943a0d633c
+	github.com/xitongsys/parquet-go v1.5.2
+)
+
+require (
+	github.com/apache/thrift v0.0.0-20181112125854-24918abba929 // indirect
+	github.com/golang/snappy v0.0.0-20180518054509-2e65f85255db // indirect
+	github.com/gorilla/websocket v1.4.2 // indirect
+	github.com/klauspost/compress v1.9.7 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	gopkg.in/yaml.v2 v2.2.5 // indirect
+)
+
+// local development against the client in the same repository, drop it to build against the required version
+replace github.com/platinummonkey/unifi => ../../
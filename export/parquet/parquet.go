@@ -0,0 +1,121 @@
+// Package parquet writes export tables as Parquet files. It is a separate module so the parquet dependencies
+// are only pulled in by its users.
+package parquet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/platinummonkey/unifi"
+	"github.com/platinummonkey/unifi/export"
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/source"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// parquetColumnTypes maps the column types to the parquet schema types
+var parquetColumnTypes = map[export.ColumnType]string{
+	export.ColumnTypeTime:   "type=TIMESTAMP_MILLIS",
+	export.ColumnTypeString: "type=UTF8, encoding=PLAIN_DICTIONARY",
+	export.ColumnTypeFloat:  "type=DOUBLE",
+	export.ColumnTypeBool:   "type=BOOLEAN",
+}
+
+// Write writes the table as a Parquet file with the column names of the table.
+// Times are written as TIMESTAMP_MILLIS in UTC, `time` is required and every other column is optional.
+func Write(w io.Writer, table *export.Table) error {
+	schema := parquetSchema{Tag: "name=parquet_go_root, repetitiontype=REQUIRED"}
+	for _, column := range table.Columns {
+		t, ok := parquetColumnTypes[column.Type]
+		if !ok {
+			return fmt.Errorf("invalid column type specified: %s", column.Type)
+		}
+		repetition := "OPTIONAL"
+		if column.Name == "time" {
+			repetition = "REQUIRED"
+		}
+		// the writer matches the record keys by the inname, the name is written to the file
+		schema.Fields = append(schema.Fields, parquetSchema{
+			Tag: fmt.Sprintf("name=%s, inname=%s, %s, repetitiontype=%s", column.Name, common.StringToVariableName(column.Name), t, repetition),
+		})
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+
+	pw, err := writer.NewJSONWriter(string(data), &parquetWriterFile{w: w}, 1)
+	if err != nil {
+		return err
+	}
+	for _, row := range table.Rows {
+		record := make(map[string]interface{}, len(row))
+		for i, v := range row {
+			switch v := v.(type) {
+			case nil:
+				continue
+			case time.Time:
+				record[table.Columns[i].Name] = v.UTC().UnixNano() / int64(time.Millisecond)
+			case float64:
+				if math.IsNaN(v) || math.IsInf(v, 0) {
+					continue
+				}
+				record[table.Columns[i].Name] = v
+			default:
+				record[table.Columns[i].Name] = v
+			}
+		}
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		if err := pw.Write(string(data)); err != nil {
+			return err
+		}
+	}
+	return pw.WriteStop()
+}
+
+// parquetSchema is the JSON schema of the parquet JSON writer
+type parquetSchema struct {
+	Tag    string
+	Fields []parquetSchema `json:",omitempty"`
+}
+
+// WriteReport writes the report data as a Parquet file, see export.ReportTable for the columns
+// resp - the report response
+func WriteReport(w io.Writer, resp *unifi.SiteReportsResponse) error {
+	return Write(w, export.ReportTable(resp))
+}
+
+// parquetWriterFile adapts an io.Writer to the write only use of source.ParquetFile by the parquet writer
+type parquetWriterFile struct {
+	w io.Writer
+}
+
+func (f *parquetWriterFile) Write(p []byte) (int, error) {
+	return f.w.Write(p)
+}
+
+func (f *parquetWriterFile) Read([]byte) (int, error) {
+	return 0, fmt.Errorf("parquet writer file does not support reading")
+}
+
+func (f *parquetWriterFile) Seek(int64, int) (int64, error) {
+	return 0, fmt.Errorf("parquet writer file does not support seeking")
+}
+
+func (f *parquetWriterFile) Close() error {
+	return nil
+}
+
+func (f *parquetWriterFile) Open(string) (source.ParquetFile, error) {
+	return nil, fmt.Errorf("parquet writer file does not support opening")
+}
+
+func (f *parquetWriterFile) Create(string) (source.ParquetFile, error) {
+	return f, nil
+}
@@ -0,0 +1,123 @@
+// Package export converts report data and device statistics into time-series points and tables,
+// e.g. to feed InfluxDB or Telegraf-style pipelines or BI tools via CSV and Parquet, see the export/parquet module.
+package export
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Point is a single time-series data point
+type Point struct {
+	Measurement string
+	Tags        map[string]string
+	Fields      map[string]interface{} // float64, int64, bool or string values
+	Time        time.Time
+}
+
+// LineProtocol renders the point in the InfluxDB line protocol with nanosecond precision.
+// Fields without a line protocol representation, NaN and infinite floats, are omitted.
+// An empty string is returned for points without fields, they are not valid line protocol.
+func (p Point) LineProtocol() string {
+	if len(p.Fields) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(measurementEscaper.Replace(p.Measurement))
+
+	tagKeys := make([]string, 0, len(p.Tags))
+	for k, v := range p.Tags {
+		if k != "" && v != "" {
+			tagKeys = append(tagKeys, k)
+		}
+	}
+	sort.Strings(tagKeys)
+	for _, k := range tagKeys {
+		b.WriteByte(',')
+		b.WriteString(tagEscaper.Replace(k))
+		b.WriteByte('=')
+		b.WriteString(tagEscaper.Replace(p.Tags[k]))
+	}
+
+	fieldKeys := make([]string, 0, len(p.Fields))
+	for k := range p.Fields {
+		fieldKeys = append(fieldKeys, k)
+	}
+	sort.Strings(fieldKeys)
+	fields := 0
+	for _, k := range fieldKeys {
+		value, ok := formatField(p.Fields[k])
+		if !ok {
+			continue
+		}
+		if fields == 0 {
+			b.WriteByte(' ')
+		} else {
+			b.WriteByte(',')
+		}
+		fields++
+		b.WriteString(tagEscaper.Replace(k))
+		b.WriteByte('=')
+		b.WriteString(value)
+	}
+	if fields == 0 {
+		return ""
+	}
+
+	if !p.Time.IsZero() {
+		b.WriteByte(' ')
+		b.WriteString(strconv.FormatInt(p.Time.UnixNano(), 10))
+	}
+	return b.String()
+}
+
+// WriteLineProtocol writes the points in the InfluxDB line protocol, one per line
+// w - the writer to write to
+// points - the points to write
+func WriteLineProtocol(w io.Writer, points []Point) error {
+	for _, p := range points {
+		line := p.LineProtocol()
+		if line == "" {
+			continue
+		}
+		if _, err := io.WriteString(w, line+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	tagEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+	stringEscaper      = strings.NewReplacer(`"`, `\"`, `\`, `\\`)
+)
+
+// formatField renders a field value, false if the value can not be represented, e.g. NaN
+func formatField(v interface{}) (string, bool) {
+	switch t := v.(type) {
+	case float64:
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			return "", false
+		}
+		return strconv.FormatFloat(t, 'f', -1, 64), true
+	case float32:
+		return formatField(float64(t))
+	case int:
+		return strconv.FormatInt(int64(t), 10) + "i", true
+	case int64:
+		return strconv.FormatInt(t, 10) + "i", true
+	case bool:
+		return strconv.FormatBool(t), true
+	case string:
+		return `"` + stringEscaper.Replace(t) + `"`, true
+	default:
+		return `"` + stringEscaper.Replace(fmt.Sprint(t)) + `"`, true
+	}
+}
@@ -0,0 +1,75 @@
+package export
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestLineProtocol(t *testing.T) {
+	ts := time.Unix(1600000000, 5)
+	tests := []struct {
+		name  string
+		point Point
+		want  string
+	}{
+		{
+			name: "sorted tags and fields",
+			point: Point{
+				Measurement: "unifi_device",
+				Tags:        map[string]string{"site": "default", "mac": "aa:bb:cc:dd:ee:ff"},
+				Fields:      map[string]interface{}{"up": true, "clients": int64(3), "load": 0.5, "name": "ap"},
+				Time:        ts,
+			},
+			want: `unifi_device,mac=aa:bb:cc:dd:ee:ff,site=default clients=3i,load=0.5,name="ap",up=true 1600000000000000005`,
+		},
+		{
+			name: "escaping",
+			point: Point{
+				Measurement: "unifi device,x",
+				Tags:        map[string]string{"site name": "a=b,c", "empty": ""},
+				Fields:      map[string]interface{}{"field key": `say "hi" \o/`},
+			},
+			want: `unifi\ device\,x,site\ name=a\=b\,c field\ key="say \"hi\" \\o/"`,
+		},
+		{
+			name: "NaN and infinite fields are omitted",
+			point: Point{
+				Measurement: "unifi_radio",
+				Fields:      map[string]interface{}{"a": math.NaN(), "b": 1, "c": math.Inf(1)},
+			},
+			want: `unifi_radio b=1i`,
+		},
+		{
+			name: "no representable fields",
+			point: Point{
+				Measurement: "unifi_radio",
+				Fields:      map[string]interface{}{"a": math.NaN()},
+			},
+			want: ``,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.point.LineProtocol(); got != tt.want {
+				t.Errorf("LineProtocol() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteLineProtocol(t *testing.T) {
+	points := []Point{
+		{Measurement: "a", Fields: map[string]interface{}{"v": 1}},
+		{Measurement: "b"},
+		{Measurement: "c", Fields: map[string]interface{}{"v": false}},
+	}
+	var buf bytes.Buffer
+	if err := WriteLineProtocol(&buf, points); err != nil {
+		t.Fatal(err)
+	}
+	if want := "a v=1i\nc v=false\n"; buf.String() != want {
+		t.Errorf("WriteLineProtocol() = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,99 @@
+package export
+
+import (
+	"sort"
+
+	"github.com/platinummonkey/unifi"
+)
+
+// ColumnType defines the type of the values in a table column
+type ColumnType string
+
+// The supported column types
+const (
+	ColumnTypeTime   ColumnType = "time"
+	ColumnTypeString ColumnType = "string"
+	ColumnTypeFloat  ColumnType = "float"
+	ColumnTypeBool   ColumnType = "bool"
+)
+
+// Column is a single typed table column
+type Column struct {
+	Name string
+	Type ColumnType
+}
+
+// Table is report data in a tabular form, e.g. to write as CSV or Parquet
+type Table struct {
+	Columns []Column
+	Rows    [][]interface{} // time.Time, string, float64 or bool values matching the column type, nil when missing
+}
+
+// ReportTable converts the report data into a table with one row per data point in time order.
+// The first column is the data point `time`, followed by the identifying string columns and the numeric columns.
+// resp - the report response
+func ReportTable(resp *unifi.SiteReportsResponse) *Table {
+	points := resp.Points()
+
+	types := make(map[string]ColumnType)
+	for _, point := range points {
+		for k, v := range point {
+			if _, ok := types[k]; ok || k == "time" {
+				continue
+			}
+			switch v.(type) {
+			case float64:
+				types[k] = ColumnTypeFloat
+			case string:
+				types[k] = ColumnTypeString
+			case bool:
+				types[k] = ColumnTypeBool
+			}
+		}
+	}
+
+	table := &Table{Columns: []Column{{Name: "time", Type: ColumnTypeTime}}}
+	names := make([]string, 0, len(types))
+	for k := range types {
+		names = append(names, k)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		// string columns first, they identify the row
+		si, sj := types[names[i]] == ColumnTypeString, types[names[j]] == ColumnTypeString
+		if si != sj {
+			return si
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		table.Columns = append(table.Columns, Column{Name: name, Type: types[name]})
+	}
+
+	table.Rows = make([][]interface{}, 0, len(points))
+	for _, point := range points {
+		row := make([]interface{}, len(table.Columns))
+		row[0] = point.Time()
+		if resp.Location != nil {
+			row[0] = point.TimeIn(resp.Location)
+		}
+		for i, column := range table.Columns[1:] {
+			v := point[column.Name]
+			switch column.Type {
+			case ColumnTypeFloat:
+				if f, ok := v.(float64); ok {
+					row[i+1] = f
+				}
+			case ColumnTypeString:
+				if s, ok := v.(string); ok {
+					row[i+1] = s
+				}
+			case ColumnTypeBool:
+				if b, ok := v.(bool); ok {
+					row[i+1] = b
+				}
+			}
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
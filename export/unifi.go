@@ -0,0 +1,146 @@
+package export
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/platinummonkey/unifi"
+)
+
+// reportTagKeys are the report keys used as tags, every other numeric key becomes a field
+var reportTagKeys = map[string]string{
+	"oid":  "oid",
+	"o":    "origin",
+	"site": "site_id",
+	"ap":   "ap",
+	"user": "user",
+	"_id":  "id",
+}
+
+// FromSiteReports converts the report data into points, the measurement is `unifi_report_<origin>`.
+// Numeric values become fields, the identifying keys become tags.
+// site - the site name added as a tag
+// resp - the report response
+func FromSiteReports(site string, resp *unifi.SiteReportsResponse) []Point {
+	points := make([]Point, 0, len(resp.Data))
+	for _, report := range resp.Data {
+		p := Point{
+			Measurement: "unifi_report",
+			Tags:        map[string]string{"site": site},
+			Fields:      make(map[string]interface{}),
+		}
+		for k, v := range report {
+			if k == "time" {
+				if ms, ok := v.(float64); ok {
+					p.Time = time.Unix(0, int64(ms)*int64(time.Millisecond)).UTC()
+				}
+				continue
+			}
+			if tag, ok := reportTagKeys[k]; ok {
+				if s, ok := v.(string); ok {
+					p.Tags[tag] = s
+				}
+				continue
+			}
+			switch t := v.(type) {
+			case float64:
+				p.Fields[k] = t
+			case bool:
+				p.Fields[k] = t
+			}
+		}
+		if origin := p.Tags["origin"]; origin != "" {
+			p.Measurement = "unifi_report_" + origin
+		}
+		points = append(points, p)
+	}
+	return points
+}
+
+// FromDeviceStats converts the device statistics into points, one `unifi_device` point per device
+// plus `unifi_radio` and `unifi_port` points for the radios and ports.
+// site - the site name added as a tag
+// devices - the device statistics, see unifi.Client.ListDeviceStats
+// at - the time of the points, usually the time the statistics were fetched
+func FromDeviceStats(site string, devices []unifi.DeviceStats, at time.Time) []Point {
+	points := make([]Point, 0, len(devices))
+	for _, d := range devices {
+		name := d.Name
+		if name == "" {
+			name = d.MAC
+		}
+		tags := map[string]string{
+			"site":  site,
+			"mac":   d.MAC,
+			"name":  name,
+			"type":  string(d.Type),
+			"model": d.Model,
+		}
+		fields := map[string]interface{}{
+			"state":        int64(d.State),
+			"uptime":       d.Uptime,
+			"cpu":          d.CPU(),
+			"mem":          d.Memory(),
+			"num_sta":      int64(d.NumberSTA),
+			"rx_bytes":     d.RXBytes,
+			"tx_bytes":     d.TXBytes,
+			"bytes":        d.Bytes,
+			"satisfaction": int64(d.Satisfaction),
+		}
+		if d.HasTemperature {
+			fields["temperature"] = d.Temperature()
+		}
+		points = append(points, Point{Measurement: "unifi_device", Tags: tags, Fields: fields, Time: at})
+
+		for _, r := range d.RadioTableStats {
+			points = append(points, Point{
+				Measurement: "unifi_radio",
+				Tags:        withTags(tags, "radio", r.Radio),
+				Fields: map[string]interface{}{
+					"num_sta":    int64(r.NumberSTA),
+					"cu_total":   int64(r.CUTotal),
+					"cu_self_rx": int64(r.CUSelfRX),
+					"cu_self_tx": int64(r.CUSelfTX),
+					"tx_packets": r.TXPackets,
+					"tx_retries": r.TXRetries,
+					"rx_bytes":   r.RXBytes,
+					"tx_bytes":   r.TXBytes,
+				},
+				Time: at,
+			})
+		}
+
+		for _, port := range d.PortTable {
+			points = append(points, Point{
+				Measurement: "unifi_port",
+				Tags:        withTags(tags, "port", strconv.Itoa(port.PortIdx), "port_name", port.Name),
+				Fields: map[string]interface{}{
+					"up":         port.Up,
+					"speed":      int64(port.Speed),
+					"rx_bytes":   port.RXBytes,
+					"tx_bytes":   port.TXBytes,
+					"rx_packets": port.RXPackets,
+					"tx_packets": port.TXPackets,
+					"rx_errors":  port.RXErrors,
+					"tx_errors":  port.TXErrors,
+					"rx_dropped": port.RXDropped,
+					"tx_dropped": port.TXDropped,
+				},
+				Time: at,
+			})
+		}
+	}
+	return points
+}
+
+// withTags returns a copy of the tags with the additional key value pairs
+func withTags(tags map[string]string, keyValues ...string) map[string]string {
+	ret := make(map[string]string, len(tags)+len(keyValues)/2)
+	for k, v := range tags {
+		ret[k] = v
+	}
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		ret[keyValues[i]] = keyValues[i+1]
+	}
+	return ret
+}
@@ -1,16 +1,43 @@
 module github.com/platinummonkey/unifi
 
-go 1.14
+go 1.20
 
 require (
 	github.com/DataDog/datadog-go v3.7.2+incompatible
+	github.com/gobuffalo/velvet v0.0.0-20170320144106-d97471bf5d8f
+	github.com/gorilla/websocket v1.4.2
+	github.com/mitchellh/go-homedir v1.1.0
+	github.com/pkg/errors v0.9.1
+	github.com/spf13/cobra v1.0.0
+	github.com/spf13/viper v1.7.0
+	github.com/timshannon/badgerhold v0.0.0-20200316131017-7bcffb989f0d
+	github.com/zorkian/go-datadog-api v2.29.0+incompatible
+	go.uber.org/zap v1.15.0
+	gopkg.in/yaml.v2 v2.2.5
+)
+
+require (
+	github.com/AndreasBriese/bbloom v0.0.0-20190825152654-46b345b51c96 // indirect
+	github.com/aymerick/douceur v0.2.0 // indirect
 	github.com/aymerick/raymond v2.0.2+incompatible // indirect
 	github.com/cenkalti/backoff v2.2.1+incompatible // indirect
-	github.com/gobuffalo/velvet v0.0.0-20170320144106-d97471bf5d8f
+	github.com/chris-ramon/douceur v0.2.0 // indirect
+	github.com/dgraph-io/badger v1.6.0 // indirect
+	github.com/dgryski/go-farm v0.0.0-20190423205320-6a90982ecee2 // indirect
+	github.com/dustin/go-humanize v1.0.0 // indirect
+	github.com/fsnotify/fsnotify v1.4.7 // indirect
+	github.com/gobuffalo/envy v1.6.5 // indirect
+	github.com/golang/protobuf v1.4.2 // indirect
+	github.com/gorilla/css v1.0.0 // indirect
+	github.com/hashicorp/hcl v1.0.0 // indirect
+	github.com/inconshreveable/mousetrap v1.0.0 // indirect
+	github.com/joho/godotenv v1.3.0 // indirect
+	github.com/magiconair/properties v1.8.1 // indirect
 	github.com/markbates/inflect v1.0.4 // indirect
 	github.com/microcosm-cc/bluemonday v1.0.3 // indirect
-	github.com/mitchellh/go-homedir v1.1.0
-	github.com/pkg/errors v0.9.1
+	github.com/mitchellh/mapstructure v1.1.2 // indirect
+	github.com/pelletier/go-toml v1.2.0 // indirect
+	github.com/russross/blackfriday v1.5.2 // indirect
 	github.com/sergi/go-diff v1.1.0 // indirect
 	github.com/shurcooL/github_flavored_markdown v0.0.0-20181002035957-2122de532470 // indirect
 	github.com/shurcooL/go v0.0.0-20200502201357-93f07166e636 // indirect
@@ -18,11 +45,19 @@ require (
 	github.com/shurcooL/highlight_diff v0.0.0-20181222201841-111da2e7d480 // indirect
 	github.com/shurcooL/highlight_go v0.0.0-20191220051317-782971ddf21b // indirect
 	github.com/shurcooL/octicon v0.0.0-20191102190552-cbb32d6a785c // indirect
+	github.com/shurcooL/sanitized_anchor_name v1.0.0 // indirect
 	github.com/sourcegraph/annotate v0.0.0-20160123013949-f4cad6c6324d // indirect
 	github.com/sourcegraph/syntaxhighlight v0.0.0-20170531221838-bd320f5d308e // indirect
-	github.com/spf13/cobra v1.0.0
-	github.com/spf13/viper v1.7.0
-	github.com/timshannon/badgerhold v0.0.0-20200316131017-7bcffb989f0d
-	github.com/zorkian/go-datadog-api v2.29.0+incompatible
-	go.uber.org/zap v1.15.0
+	github.com/spf13/afero v1.1.2 // indirect
+	github.com/spf13/cast v1.3.0 // indirect
+	github.com/spf13/jwalterweatherman v1.0.0 // indirect
+	github.com/spf13/pflag v1.0.3 // indirect
+	github.com/subosito/gotenv v1.2.0 // indirect
+	go.uber.org/atomic v1.6.0 // indirect
+	go.uber.org/multierr v1.5.0 // indirect
+	golang.org/x/net v0.0.0-20190620200207-3b0461eec859 // indirect
+	golang.org/x/sys v0.0.0-20200615200032-f1bc736245b1 // indirect
+	golang.org/x/text v0.3.2 // indirect
+	google.golang.org/protobuf v1.23.0 // indirect
+	gopkg.in/ini.v1 v1.51.0 // indirect
 )
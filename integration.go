@@ -0,0 +1,124 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// IntegrationAPIPrefix is the path of the official integration API, relative to the network application
+const IntegrationAPIPrefix = "/integration/v1"
+
+// APIKeyHeader is the header used to authenticate with an API key
+const APIKeyHeader = "X-API-KEY"
+
+// SetAPIKey switches the client to API key authentication. API keys are only supported by UniFi OS consoles,
+// no login or session cookie is required and the key is sent with every request.
+// key - the API key created in the UniFi Network application
+func (c *Client) SetAPIKey(key string) {
+	c.apiKey = key
+	c.SetUniFiOS(true)
+}
+
+// UsesAPIKey returns true if the client authenticates with an API key
+func (c *Client) UsesAPIKey() bool {
+	return c.apiKey != ""
+}
+
+// IntegrationPage contains the paging information of an integration API response
+type IntegrationPage struct {
+	Offset     int `json:"offset"`
+	Limit      int `json:"limit"`
+	Count      int `json:"count"`
+	TotalCount int `json:"totalCount"`
+}
+
+// IntegrationSite is a site as returned by the integration API
+type IntegrationSite struct {
+	ID                string `json:"id"`
+	InternalReference string `json:"internalReference"` // the site name used by the classic API, e.g. `default`
+	Name              string `json:"name"`
+}
+
+// IntegrationSitesResponse contains the integration API sites response
+type IntegrationSitesResponse struct {
+	IntegrationPage
+	Data []IntegrationSite `json:"data"`
+}
+
+// IntegrationDevice is a device as returned by the integration API
+type IntegrationDevice struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Model      string   `json:"model"`
+	MACAddress string   `json:"macAddress"`
+	IPAddress  string   `json:"ipAddress"`
+	State      string   `json:"state"`
+	Features   []string `json:"features"`
+	Interfaces []string `json:"interfaces"`
+}
+
+// IntegrationDevicesResponse contains the integration API devices response
+type IntegrationDevicesResponse struct {
+	IntegrationPage
+	Data []IntegrationDevice `json:"data"`
+}
+
+// IntegrationClient is a client as returned by the integration API
+type IntegrationClient struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Type           string `json:"type"` // WIRED, WIRELESS or VPN
+	MACAddress     string `json:"macAddress"`
+	IPAddress      string `json:"ipAddress"`
+	ConnectedAt    string `json:"connectedAt"`
+	UplinkDeviceID string `json:"uplinkDeviceId"`
+}
+
+// IntegrationClientsResponse contains the integration API clients response
+type IntegrationClientsResponse struct {
+	IntegrationPage
+	Data []IntegrationClient `json:"data"`
+}
+
+// ListIntegrationSites lists the sites available to the API key
+// offset - the number of entries to skip
+// limit - the maximum number of entries to return, 0 uses the controller default
+func (c *Client) ListIntegrationSites(ctx context.Context, offset int, limit int) (*IntegrationSitesResponse, error) {
+	var resp IntegrationSitesResponse
+	err := c.doIntegrationRequest(ctx, http.MethodGet, "/sites", offset, limit, &resp)
+	return &resp, err
+}
+
+// ListIntegrationDevices lists the adopted devices of the site
+// siteID - the integration site ID, see ListIntegrationSites
+// offset - the number of entries to skip
+// limit - the maximum number of entries to return, 0 uses the controller default
+func (c *Client) ListIntegrationDevices(ctx context.Context, siteID string, offset int, limit int) (*IntegrationDevicesResponse, error) {
+	var resp IntegrationDevicesResponse
+	err := c.doIntegrationRequest(ctx, http.MethodGet, fmt.Sprintf("/sites/%s/devices", siteID), offset, limit, &resp)
+	return &resp, err
+}
+
+// ListIntegrationClients lists the connected clients of the site
+// siteID - the integration site ID, see ListIntegrationSites
+// offset - the number of entries to skip
+// limit - the maximum number of entries to return, 0 uses the controller default
+func (c *Client) ListIntegrationClients(ctx context.Context, siteID string, offset int, limit int) (*IntegrationClientsResponse, error) {
+	var resp IntegrationClientsResponse
+	err := c.doIntegrationRequest(ctx, http.MethodGet, fmt.Sprintf("/sites/%s/clients", siteID), offset, limit, &resp)
+	return &resp, err
+}
+
+// doIntegrationRequest issues a paged request against the integration API
+func (c *Client) doIntegrationRequest(ctx context.Context, method string, extPath string, offset int, limit int, ret interface{}) error {
+	if err := c.requireFeature(ctx, FeatureIntegrationAPI); err != nil {
+		return err
+	}
+	params := []string{"offset", strconv.Itoa(offset)}
+	if limit > 0 {
+		params = append(params, "limit", strconv.Itoa(limit))
+	}
+	return c.doRequest(ctx, method, IntegrationAPIPrefix+extPath, nil, ret, params...)
+}
@@ -0,0 +1,82 @@
+package main
+
+// param is a single command payload parameter
+type param struct {
+	Key  string // the payload key
+	Name string // the go argument name
+	Type string // the go argument type
+}
+
+// command is a single known controller command
+type command struct {
+	Manager string // the manager constant, e.g. CommandManagerDevice
+	Path    string // the manager path, e.g. devmgr
+	Name    string // the command, e.g. restart
+	Doc     string
+	Params  []param
+	GoName  string
+}
+
+var (
+	mac     = param{Key: "mac", Name: "mac", Type: "string"}
+	macs    = param{Key: "macs", Name: "macs", Type: "[]string"}
+	id      = param{Key: "_id", Name: "id", Type: "string"}
+	siteArg = param{Key: "site", Name: "targetSite", Type: "string"}
+)
+
+// catalog is the list of known commands, keep it sorted by manager and command
+var catalog = []command{
+	{Path: "backup", Name: "delete-backup", Doc: "deletes a controller backup file", Params: []param{{Key: "filename", Name: "filename", Type: "string"}}},
+	{Path: "backup", Name: "list-backups", Doc: "lists the controller backup files"},
+
+	{Path: "devmgr", Name: "adopt", Doc: "adopts the device", Params: []param{mac}},
+	{Path: "devmgr", Name: "cancel-migrate", Doc: "cancels a pending device migration", Params: []param{mac}},
+	{Path: "devmgr", Name: "cancel-rolling-upgrade", Doc: "cancels the rolling upgrade of the site"},
+	{Path: "devmgr", Name: "force-provision", Doc: "force provisions the device", Params: []param{mac}},
+	{Path: "devmgr", Name: "migrate", Doc: "migrates the device to another controller", Params: []param{mac, {Key: "inform_url", Name: "informURL", Type: "string"}}},
+	{Path: "devmgr", Name: "power-cycle", Doc: "power cycles the PoE port of the switch", Params: []param{mac, {Key: "port_idx", Name: "portIdx", Type: "int"}}},
+	{Path: "devmgr", Name: "restart", Doc: "restarts the device", Params: []param{mac}},
+	{Path: "devmgr", Name: "set-locate", Doc: "starts flashing the device LED", Params: []param{mac}},
+	{Path: "devmgr", Name: "set-rollupgrade", Doc: "starts a rolling upgrade of the site access points"},
+	{Path: "devmgr", Name: "spectrum-scan", Doc: "starts a spectrum scan on the access point", Params: []param{mac}},
+	{Path: "devmgr", Name: "speedtest", Doc: "starts a speed test on the gateway"},
+	{Path: "devmgr", Name: "speedtest-status", Doc: "returns the status of the running speed test"},
+	{Path: "devmgr", Name: "unset-locate", Doc: "stops flashing the device LED", Params: []param{mac}},
+	{Path: "devmgr", Name: "upgrade", Doc: "upgrades the device to the latest firmware", Params: []param{mac}},
+	{Path: "devmgr", Name: "upgrade-external", Doc: "upgrades the device to the firmware at the url", Params: []param{mac, {Key: "url", Name: "url", Type: "string"}}},
+
+	{Path: "evtmgr", Name: "archive-alarm", Doc: "archives the alarm", Params: []param{id}},
+	{Path: "evtmgr", Name: "archive-all-alarms", Doc: "archives all alarms of the site"},
+
+	{Path: "hotspot", Name: "create-voucher", Doc: "creates hotspot vouchers", Params: []param{{Key: "n", Name: "count", Type: "int"}, {Key: "expire", Name: "expireMinutes", Type: "int"}, {Key: "quota", Name: "quota", Type: "int"}}},
+	{Path: "hotspot", Name: "delete-voucher", Doc: "deletes the hotspot voucher", Params: []param{id}},
+	{Path: "hotspot", Name: "extend", Doc: "extends the guest authorization", Params: []param{id}},
+
+	{Path: "sitemgr", Name: "delete-device", Doc: "forgets the device", Params: []param{mac}},
+	{Path: "sitemgr", Name: "delete-site", Doc: "deletes the site", Params: []param{siteArg}},
+	{Path: "sitemgr", Name: "get-admins", Doc: "lists the admins of the site"},
+	{Path: "sitemgr", Name: "move-device", Doc: "moves the device to another site", Params: []param{mac, siteArg}},
+
+	{Path: "stamgr", Name: "authorize-guest", Doc: "authorizes the guest", Params: []param{mac, {Key: "minutes", Name: "minutes", Type: "int"}}},
+	{Path: "stamgr", Name: "block-sta", Doc: "blocks the client", Params: []param{mac}},
+	{Path: "stamgr", Name: "forget-sta", Doc: "forgets the clients", Params: []param{macs}},
+	{Path: "stamgr", Name: "kick-sta", Doc: "disconnects the client", Params: []param{mac}},
+	{Path: "stamgr", Name: "unauthorize-guest", Doc: "unauthorizes the guest", Params: []param{mac}},
+	{Path: "stamgr", Name: "unblock-sta", Doc: "unblocks the client", Params: []param{mac}},
+
+	{Path: "stat", Name: "clear-dpi", Doc: "resets the DPI counters of the site"},
+
+	{Path: "system", Name: "backup", Doc: "creates a controller backup"},
+}
+
+// managers maps the manager path to its constant and the prefix of the generated methods
+var managers = map[string][2]string{
+	"backup":  {"CommandManagerBackup", "BackupMgr"},
+	"devmgr":  {"CommandManagerDevice", "DevMgr"},
+	"evtmgr":  {"CommandManagerEvent", "EvtMgr"},
+	"hotspot": {"CommandManagerHotspot", "Hotspot"},
+	"sitemgr": {"CommandManagerSite", "SiteMgr"},
+	"stamgr":  {"CommandManagerStation", "StaMgr"},
+	"stat":    {"CommandManagerStat", "Stat"},
+	"system":  {"CommandManagerSystem", "System"},
+}
@@ -0,0 +1,76 @@
+// Command commandgen generates the typed command wrappers of the unifi package from the command catalog.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"strings"
+	"text/template"
+)
+
+var tmpl = template.Must(template.New("commands").Parse(`// Code generated by internal/commandgen; DO NOT EDIT.
+
+package unifi
+
+import (
+	"context"
+)
+
+// CommandCatalog contains the known controller commands, see RunCommand
+var CommandCatalog = []Command{
+{{- range .}}
+	{Manager: {{.Manager}}, Name: "{{.Name}}", Params: []string{ {{- range $i, $p := .Params}}{{if $i}}, {{end}}"{{$p.Key}}"{{end -}} }},
+{{- end}}
+}
+{{range .}}
+// {{.GoName}} runs the {{.Path}} {{.Name}} command, it {{.Doc}}
+// site - the site to run the command on
+{{- range .Params}}
+// {{.Name}} - the {{.Key}} parameter
+{{- end}}
+func (c *Client) {{.GoName}}(ctx context.Context, site string{{range .Params}}, {{.Name}} {{.Type}}{{end}}) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, {{.Manager}}, "{{.Name}}", map[string]interface{}{
+{{- range .Params}}
+		"{{.Key}}": {{.Name}},
+{{- end}}
+	})
+}
+{{end}}`))
+
+// goName converts a dashed command name to camel case, e.g. kick-sta to KickSta
+func goName(name string) string {
+	parts := strings.Split(name, "-")
+	for i, p := range parts {
+		parts[i] = strings.ToUpper(p[:1]) + p[1:]
+	}
+	return strings.Join(parts, "")
+}
+
+func main() {
+	out := flag.String("o", "site_commands_gen.go", "the output file")
+	flag.Parse()
+
+	for i := range catalog {
+		m, ok := managers[catalog[i].Path]
+		if !ok {
+			log.Fatalf("unknown manager %s", catalog[i].Path)
+		}
+		catalog[i].Manager = m[0]
+		catalog[i].GoName = m[1] + goName(catalog[i].Name)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, catalog); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("%v\n%s", err, buf.String())
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
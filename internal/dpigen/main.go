@@ -0,0 +1,66 @@
+// Command dpigen generates the DPI application table of the unifi package from the controller's DPI application list.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"sort"
+	"text/template"
+)
+
+// application is an entry of the controller's DPI application list
+type application struct {
+	Category int    `json:"cat"`
+	App      int    `json:"id"`
+	Name     string `json:"name"`
+}
+
+var tmpl = template.Must(template.New("dpi").Parse(`// Code generated by internal/dpigen; DO NOT EDIT.
+
+package unifi
+
+// DPIApplicationNames maps DPI applications to their names as displayed by the controller,
+// see LoadDPIApplicationNames to add the applications of newer controllers
+var DPIApplicationNames = map[DPIApplication]string{
+{{- range .}}
+	{Category: {{.Category}}, App: {{.App}}}: {{printf "%q" .Name}},
+{{- end}}
+}
+`))
+
+func main() {
+	in := flag.String("in", "internal/dpigen/dpi_apps.json", "the DPI application list exported from the controller")
+	out := flag.String("o", "site_dpi_apps_gen.go", "the output file")
+	flag.Parse()
+
+	data, err := ioutil.ReadFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var apps []application
+	if err := json.Unmarshal(data, &apps); err != nil {
+		log.Fatalf("invalid application list %s: %v", *in, err)
+	}
+	sort.Slice(apps, func(i, j int) bool {
+		if apps[i].Category != apps[j].Category {
+			return apps[i].Category < apps[j].Category
+		}
+		return apps[i].App < apps[j].App
+	})
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, apps); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("%v\n%s", err, buf.String())
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
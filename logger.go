@@ -0,0 +1,32 @@
+package unifi
+
+// Logger is a structured logger, messages are followed by alternating keys and values.
+// A *zap.SugaredLogger satisfies this interface.
+type Logger interface {
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+}
+
+// noopLogger discards every message, it is used when no logger is configured
+type noopLogger struct{}
+
+func (noopLogger) Debugw(string, ...interface{}) {}
+func (noopLogger) Infow(string, ...interface{})  {}
+func (noopLogger) Warnw(string, ...interface{})  {}
+func (noopLogger) Errorw(string, ...interface{}) {}
+
+// SetLogger sets the logger used for logins, re-logins, request failures and event stream reconnects,
+// set to nil to disable logging.
+func (c *Client) SetLogger(logger Logger) {
+	c.logger = logger
+}
+
+// log returns the configured logger, never nil
+func (c *Client) log() Logger {
+	if c.logger == nil {
+		return noopLogger{}
+	}
+	return c.logger
+}
@@ -0,0 +1,223 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultManagerConcurrency is the number of concurrent calls of the Manager fan-out helpers when none is configured
+const DefaultManagerConcurrency = 8
+
+// Manager holds the clients of many controllers, e.g. for reporting jobs across the controllers of an MSP.
+// The clients must be logged in, the manager only fans out the calls.
+type Manager struct {
+	concurrency int
+
+	mu      sync.RWMutex
+	clients map[string]*Client
+}
+
+// NewManager returns an empty manager
+// concurrency - the maximum concurrent calls of the fan-out helpers, DefaultManagerConcurrency when 0
+func NewManager(concurrency int) *Manager {
+	if concurrency <= 0 {
+		concurrency = DefaultManagerConcurrency
+	}
+	return &Manager{concurrency: concurrency, clients: make(map[string]*Client)}
+}
+
+// Add adds the client of a controller, replacing any client with the same name
+// name - the name identifying the controller in callbacks and errors
+func (m *Manager) Add(name string, c *Client) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clients[name] = c
+}
+
+// Remove removes the client of a controller
+func (m *Manager) Remove(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.clients, name)
+}
+
+// Client returns the client of a controller
+func (m *Manager) Client(name string) (*Client, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c, ok := m.clients[name]
+	return c, ok
+}
+
+// Names returns the sorted controller names
+func (m *Manager) Names() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, 0, len(m.clients))
+	for name := range m.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ManagerError is the error of a single controller or site of a fan-out call
+type ManagerError struct {
+	Controller string
+	Site       string // empty for controller wide errors
+	Err        error
+}
+
+// Error implements error
+func (e *ManagerError) Error() string {
+	if e.Site != "" {
+		return fmt.Sprintf("controller %s site %s: %s", e.Controller, e.Site, e.Err)
+	}
+	return fmt.Sprintf("controller %s: %s", e.Controller, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *ManagerError) Unwrap() error {
+	return e.Err
+}
+
+// ManagerErrors aggregates the errors of a fan-out call, sorted by controller and site
+type ManagerErrors []*ManagerError
+
+// Error implements error
+func (e ManagerErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%d errors: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// sort sorts the errors by controller and site
+func (e ManagerErrors) sort() {
+	sort.Slice(e, func(i, j int) bool {
+		if e[i].Controller != e[j].Controller {
+			return e[i].Controller < e[j].Controller
+		}
+		return e[i].Site < e[j].Site
+	})
+}
+
+// managerTask is a single call of a fan-out
+type managerTask struct {
+	controller string
+	client     *Client
+	site       SitesResponseData
+}
+
+// run calls fn for every task with bounded parallelism and aggregates the errors, tasks not started
+// before the context is done fail with the context error
+func (m *Manager) run(ctx context.Context, tasks []managerTask, fn func(ctx context.Context, t managerTask) error) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs ManagerErrors
+		sem  = make(chan struct{}, m.concurrency)
+	)
+	fail := func(t managerTask, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, &ManagerError{Controller: t.controller, Site: t.site.Name, Err: err})
+	}
+
+	for _, t := range tasks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			fail(t, ctx.Err())
+			continue
+		}
+		wg.Add(1)
+		go func(t managerTask) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(ctx, t); err != nil {
+				fail(t, err)
+			}
+		}(t)
+	}
+	wg.Wait()
+
+	if len(errs) == 0 {
+		return nil
+	}
+	errs.sort()
+	return errs
+}
+
+// controllerTasks returns a task per controller
+func (m *Manager) controllerTasks() []managerTask {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	tasks := make([]managerTask, 0, len(m.clients))
+	for name, c := range m.clients {
+		tasks = append(tasks, managerTask{controller: name, client: c})
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].controller < tasks[j].controller
+	})
+	return tasks
+}
+
+// ForEachController calls fn concurrently for every controller. Every call runs to completion,
+// the errors are returned together as ManagerErrors.
+// fn - called with the controller name and client
+func (m *Manager) ForEachController(ctx context.Context, fn func(ctx context.Context, controller string, c *Client) error) error {
+	return m.run(ctx, m.controllerTasks(), func(ctx context.Context, t managerTask) error {
+		return fn(ctx, t.controller, t.client)
+	})
+}
+
+// ForEachSite calls fn concurrently for every site of every controller, as listed by ListSites.
+// Every call runs to completion, the errors of listing the sites and of the calls are returned together
+// as ManagerErrors.
+// fn - called with the controller name, its client and the site, use site.Name for the site requests
+func (m *Manager) ForEachSite(ctx context.Context, fn func(ctx context.Context, controller string, c *Client, site SitesResponseData) error) error {
+	var (
+		mu    sync.Mutex
+		tasks []managerTask
+	)
+	listErr := m.run(ctx, m.controllerTasks(), func(ctx context.Context, t managerTask) error {
+		sites, err := t.client.ListSites(ctx)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, site := range sites.Data {
+			tasks = append(tasks, managerTask{controller: t.controller, client: t.client, site: site})
+		}
+		return nil
+	})
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].controller != tasks[j].controller {
+			return tasks[i].controller < tasks[j].controller
+		}
+		return tasks[i].site.Name < tasks[j].site.Name
+	})
+
+	siteErr := m.run(ctx, tasks, func(ctx context.Context, t managerTask) error {
+		return fn(ctx, t.controller, t.client, t.site)
+	})
+
+	var errs ManagerErrors
+	if listErr != nil {
+		errs = append(errs, listErr.(ManagerErrors)...)
+	}
+	if siteErr != nil {
+		errs = append(errs, siteErr.(ManagerErrors)...)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	errs.sort()
+	return errs
+}
@@ -0,0 +1,64 @@
+package unifi
+
+import (
+	"net/http"
+)
+
+// RoundTripFunc sends a single request to the controller, the signature of http.RoundTripper.RoundTrip
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the sending of every request, it can mutate the request, inspect the response
+// or short-circuit the call. Middlewares must call next to continue the chain.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// RequestHook is called before every request is sent, returning an error aborts the request
+type RequestHook func(req *http.Request) error
+
+// ResponseHook is called after every request with the response or the error of the call
+type ResponseHook func(req *http.Request, resp *http.Response, err error)
+
+// Use appends middlewares to the client, the first middleware added is the outermost one.
+// Middlewares also apply to the login and relogin requests.
+func (c *Client) Use(middlewares ...Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// OnRequest adds a hook called before every request is sent, e.g. to inject tracing headers
+func (c *Client) OnRequest(hook RequestHook) {
+	c.Use(func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if err := hook(req); err != nil {
+				return nil, err
+			}
+			return next(req)
+		}
+	})
+}
+
+// OnResponse adds a hook called after every request, e.g. to record metrics
+func (c *Client) OnResponse(hook ResponseHook) {
+	c.Use(func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			hook(req, resp, err)
+			return resp, err
+		}
+	})
+}
+
+// roundTrip sends the request through the middleware chain
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	httpClient := c.HTTPClient
+	if requestOptionsFrom(req.Context()).noRedirect {
+		noRedirect := *httpClient
+		noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+		httpClient = &noRedirect
+	}
+	send := RoundTripFunc(httpClient.Do)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		send = c.middlewares[i](send)
+	}
+	return send(req)
+}
@@ -0,0 +1,187 @@
+package unifi
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// DefaultTimeout is the request timeout used when none is configured
+const DefaultTimeout = 30 * time.Second
+
+// DefaultMaxIdleConnsPerHost is the number of idle connections kept to the controller. Every request goes to
+// the same host, the net/http default of 2 would close most connections under concurrent use.
+const DefaultMaxIdleConnsPerHost = 16
+
+// DefaultSite is the site used by site requests issued with an empty site name, unless configured otherwise
+const DefaultSite = "default"
+
+// clientOptions collects the options applied by NewClient
+type clientOptions struct {
+	httpClient  *http.Client
+	certConfig  *CertificationConfig
+	tlsConfig   *tls.Config
+	insecure    bool
+	timeout     time.Duration
+	userAgent   string
+	jar         http.CookieJar
+	defaultSite string
+	middlewares []Middleware
+
+	credentialsProvider CredentialsProvider
+	sessionStore        SessionStore
+	tls                 tlsOptions
+	proxyURL            string
+	noProxy             bool
+	dialer              DialContextFunc
+
+	maxIdleConnsPerHost int
+}
+
+// Option configures the client, see NewClient
+type Option func(o *clientOptions)
+
+// WithHTTPClient uses the http client to talk to the controller. The client is copied, the TLS, proxy and dialer options
+// are ignored and the timeout and cookie jar options override the corresponding fields of the copy.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithCertificationConfig applies the certificate checks of the configuration, see CertificationConfig
+func WithCertificationConfig(certConfig *CertificationConfig) Option {
+	return func(o *clientOptions) {
+		o.certConfig = certConfig
+	}
+}
+
+// WithTLSConfig uses the TLS configuration for the connections, it takes precedence over WithCertificationConfig
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(o *clientOptions) {
+		o.tlsConfig = tlsConfig
+	}
+}
+
+// WithInsecureSkipVerify disables all certificate checks, e.g. for the self-signed certificate of a default install
+func WithInsecureSkipVerify() Option {
+	return func(o *clientOptions) {
+		o.insecure = true
+	}
+}
+
+// WithTimeout sets the request timeout, defaults to DefaultTimeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithUserAgent overrides the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithCookieJar stores the cookies set by the controller in the jar, in addition to the session cookies
+// the client keeps on its own
+func WithCookieJar(jar http.CookieJar) Option {
+	return func(o *clientOptions) {
+		o.jar = jar
+	}
+}
+
+// WithDefaultSite sets the site used by site requests issued with an empty site name, defaults to DefaultSite
+func WithDefaultSite(site string) Option {
+	return func(o *clientOptions) {
+		o.defaultSite = site
+	}
+}
+
+// WithMaxIdleConnsPerHost sets the number of idle connections kept to the controller, defaults to DefaultMaxIdleConnsPerHost
+func WithMaxIdleConnsPerHost(n int) Option {
+	return func(o *clientOptions) {
+		o.maxIdleConnsPerHost = n
+	}
+}
+
+// buildHTTPClient builds the http client for the options, never modifying shared clients or transports
+func (o *clientOptions) buildHTTPClient() (*http.Client, error) {
+	var httpClient http.Client
+	if o.httpClient != nil {
+		httpClient = *o.httpClient
+	} else {
+		tlsConfig := o.tlsConfig
+		if tlsConfig == nil && o.certConfig != nil {
+			var err error
+			tlsConfig, err = o.certConfig.tlsConfig()
+			if err != nil {
+				return nil, err
+			}
+		}
+		if o.insecure {
+			if tlsConfig == nil {
+				tlsConfig = &tls.Config{}
+			} else {
+				tlsConfig = tlsConfig.Clone()
+			}
+			tlsConfig.InsecureSkipVerify = true
+		}
+		if o.tls.isSet() {
+			var err error
+			tlsConfig, err = o.tls.apply(tlsConfig)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		tr := http.DefaultTransport.(*http.Transport).Clone()
+		tr.TLSClientConfig = tlsConfig
+		if err := o.applyProxy(tr); err != nil {
+			return nil, err
+		}
+		tr.MaxIdleConnsPerHost = o.maxIdleConnsPerHost
+		if tr.MaxIdleConns > 0 && tr.MaxIdleConns < o.maxIdleConnsPerHost {
+			tr.MaxIdleConns = o.maxIdleConnsPerHost
+		}
+		httpClient.Transport = tr
+	}
+	httpClient.Timeout = o.timeout
+	if o.jar != nil {
+		httpClient.Jar = o.jar
+	}
+	return &httpClient, nil
+}
+
+// tlsConfig returns the TLS configuration for the certificate checks, nil for the default checks
+func (cc *CertificationConfig) tlsConfig() (*tls.Config, error) {
+	if cc.DisableCertCheck {
+		return &tls.Config{
+			InsecureSkipVerify: true,
+		}, nil
+	} else if cc.PEMCert != "" {
+		cert, err := ioutil.ReadFile(cc.PEMCert)
+		if err != nil {
+			return nil, err
+		}
+		certPool := x509.NewCertPool()
+		certPool.AppendCertsFromPEM(cert)
+		return &tls.Config{
+			RootCAs: certPool,
+		}, nil
+	} else if len(cc.Certificates) > 0 {
+		certPool := x509.NewCertPool()
+		for _, cert := range cc.Certificates {
+			if cert != nil {
+				certPool.AddCert(cert)
+			}
+		}
+		return &tls.Config{
+			RootCAs: certPool,
+		}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,173 @@
+package unifi
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultPageSize is the page size used by the pagers when none is specified
+const DefaultPageSize = 100
+
+// PageFunc fetches a single page of at most limit items starting at offset
+type PageFunc[T any] func(ctx context.Context, offset int, limit int) ([]T, error)
+
+// Pager iterates over a paginated endpoint, fetching the next page on demand.
+// A page shorter than the page size is treated as the last page.
+//
+//	pager := client.EventsPager("default", unifi.EventFilter{})
+//	for pager.Next(ctx) {
+//		evt := pager.Item()
+//	}
+//	if err := pager.Err(); err != nil {
+//	}
+type Pager[T any] struct {
+	fetch    PageFunc[T]
+	pageSize int
+	offset   int
+	page     []T
+	index    int
+	done     bool
+	err      error
+}
+
+// NewPager returns a pager fetching pages of pageSize items with fetch
+// pageSize - the page size, DefaultPageSize when 0
+// start - the offset of the first item
+// fetch - fetches a single page
+func NewPager[T any](pageSize int, start int, fetch PageFunc[T]) *Pager[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return &Pager[T]{fetch: fetch, pageSize: pageSize, offset: start, index: -1}
+}
+
+// Next advances to the next item, fetching the next page when required.
+// It returns false once there are no more items or an error occurred, see Err.
+func (p *Pager[T]) Next(ctx context.Context) bool {
+	if p.err != nil {
+		return false
+	}
+	p.index++
+	if p.index < len(p.page) {
+		return true
+	}
+	if p.done {
+		return false
+	}
+
+	page, err := p.fetch(ctx, p.offset, p.pageSize)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	p.page = page
+	p.index = 0
+	p.offset += len(page)
+	if len(page) < p.pageSize {
+		p.done = true
+	}
+	return len(page) > 0
+}
+
+// Item returns the current item, only valid after Next returned true
+func (p *Pager[T]) Item() T {
+	return p.page[p.index]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// All fetches every remaining item
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var items []T
+	for p.Next(ctx) {
+		items = append(items, p.Item())
+	}
+	return items, p.Err()
+}
+
+// EventsPager returns a pager over the typed events of the site, see ListTypedEvents
+// site - site to query
+// filter - the event filters, Start is the offset of the first event and Limit the page size
+func (c *Client) EventsPager(site string, filter EventFilter) *Pager[Event] {
+	if filter.Limit > 3000 {
+		// larger pages are truncated by the controller
+		filter.Limit = 3000
+	}
+	return NewPager(filter.Limit, filter.Start, func(ctx context.Context, offset int, limit int) ([]Event, error) {
+		f := filter
+		f.Start, f.Limit = offset, limit
+		return c.ListTypedEvents(ctx, site, f)
+	})
+}
+
+// AlarmsPager returns a pager over the alarms of the site, see ListAlarms
+// site - site to query
+// filter - the alarm filters, Start is the offset of the first alarm and Limit the page size
+func (c *Client) AlarmsPager(site string, filter AlarmFilter) *Pager[SiteAlarmsAlarm] {
+	if filter.Limit > 3000 {
+		// larger pages are truncated by the controller
+		filter.Limit = 3000
+	}
+	return NewPager(filter.Limit, filter.Start, func(ctx context.Context, offset int, limit int) ([]SiteAlarmsAlarm, error) {
+		f := filter
+		f.Start, f.Limit = offset, limit
+		resp, err := c.ListAlarms(ctx, site, f)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Data, nil
+	})
+}
+
+// IPSEventsPager returns a pager over the IPS/IDS alerts of the site, see ListIPSEvents
+// site - site to query
+// filter - the alert filters, Offset is the offset of the first alert and Limit the page size
+func (c *Client) IPSEventsPager(site string, filter IPSEventFilter) *Pager[EventIPSAlert] {
+	if filter.Limit > 10000 {
+		// larger pages are truncated by the controller
+		filter.Limit = 10000
+	}
+	// fix the time range so it does not move between pages
+	if filter.End.IsZero() {
+		filter.End = time.Now().UTC()
+	}
+	if filter.Start.IsZero() {
+		filter.Start = filter.End.Add(-24 * time.Hour)
+	}
+	return NewPager(filter.Limit, filter.Offset, func(ctx context.Context, offset int, limit int) ([]EventIPSAlert, error) {
+		f := filter
+		f.Offset, f.Limit = offset, limit
+		return c.ListIPSEvents(ctx, site, f)
+	})
+}
+
+// UsersPager returns a pager over the known user/client devices of the site, see ListUsers.
+// The controller does not page the users, the list is fetched once with the first page and paged locally.
+// site - site to query
+// filter - the user filters, Start is the offset of the first user and Limit the page size
+func (c *Client) UsersPager(site string, filter UserFilter) *Pager[User] {
+	var users []User
+	fetched := false
+	return NewPager(filter.Limit, filter.Start, func(ctx context.Context, offset int, limit int) ([]User, error) {
+		if !fetched {
+			f := filter
+			f.Start, f.Limit = 0, 0
+			resp, err := c.ListUsers(ctx, site, f)
+			if err != nil {
+				return nil, err
+			}
+			users, fetched = resp.Data, true
+		}
+		if offset >= len(users) {
+			return nil, nil
+		}
+		end := offset + limit
+		if end > len(users) {
+			end = len(users)
+		}
+		return users[offset:end], nil
+	})
+}
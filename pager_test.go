@@ -0,0 +1,106 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPager(t *testing.T) {
+	items := make([]int, 25)
+	for i := range items {
+		items[i] = i
+	}
+	fetch := func(ctx context.Context, offset int, limit int) ([]int, error) {
+		if offset >= len(items) {
+			return nil, nil
+		}
+		end := offset + limit
+		if end > len(items) {
+			end = len(items)
+		}
+		return items[offset:end], nil
+	}
+
+	tests := []struct {
+		name     string
+		pageSize int
+		start    int
+		want     int
+		first    int
+	}{
+		{"default page size", 0, 0, 25, 0},
+		{"partial last page", 10, 0, 25, 0},
+		{"exact pages", 5, 0, 25, 0},
+		{"start offset", 10, 7, 18, 7},
+		{"start past the end", 10, 30, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPager(tt.pageSize, tt.start, fetch).All(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != tt.want {
+				t.Fatalf("got %d items, want %d", len(got), tt.want)
+			}
+			if len(got) > 0 && got[0] != tt.first {
+				t.Errorf("first item is %d, want %d", got[0], tt.first)
+			}
+		})
+	}
+}
+
+func TestPagerStopsOnError(t *testing.T) {
+	errPage := errors.New("page failed")
+	calls := 0
+	pager := NewPager(2, 0, func(ctx context.Context, offset int, limit int) ([]int, error) {
+		calls++
+		if offset > 0 {
+			return nil, errPage
+		}
+		return []int{1, 2}, nil
+	})
+	got, err := pager.All(context.Background())
+	if !errors.Is(err, errPage) {
+		t.Fatalf("expected the page error, got %v", err)
+	}
+	if len(got) != 2 || pager.Next(context.Background()) || calls != 2 {
+		t.Errorf("expected the pager to stop after the failed page, got %v after %d calls", got, calls)
+	}
+}
+
+func TestUsersPagerFetchesOnce(t *testing.T) {
+	var requests int32
+	users := make([]string, 0, 7)
+	for i := 0; i < 7; i++ {
+		users = append(users, fmt.Sprintf(`{"_id":"%d","mac":"00:00:00:00:00:0%d"}`, i, i))
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[` + strings.Join(users, ",") + `]}`))
+	}))
+	defer srv.Close()
+	c, err := NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetUniFiOS(false)
+
+	got, err := c.UsersPager("default", UserFilter{Start: 1, Limit: 2}).All(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 6 || got[0].ID != "1" || got[5].ID != "6" {
+		t.Errorf("unexpected users %+v", got)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+}
@@ -0,0 +1,26 @@
+module github.com/platinummonkey/unifi/promexporter
+
+go 1.20
+
+require (
+	github.com/platinummonkey/unifi v0.0.0-20261015081524-23943a0d633c
+	github.com/prometheus/client_golang v1.7.1
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.1.1 // indirect
+	github.com/golang/protobuf v1.4.2 // indirect
+	github.com/gorilla/websocket v1.4.2 // indirect
+	github.com/matttproud/golang_protobuf_extensions v1.0.1 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/prometheus/client_model v0.2.0 // indirect
+	github.com/prometheus/common v0.10.0 // indirect
+	github.com/prometheus/procfs v0.1.3 // indirect
+	golang.org/x/sys v0.0.0-20200615200032-f1bc736245b1 // indirect
+	google.golang.org/protobuf v1.23.0 // indirect
+	gopkg.in/yaml.v2 v2.2.5 // indirect
+)
+
+// local development against the client in the same repository, drop it to build against the required version
+replace github.com/platinummonkey/unifi => ../
@@ -0,0 +1,287 @@
+// Package promexporter exposes the controller state as prometheus metrics.
+//
+// The collector queries the controller on every scrape, there is no background polling:
+//
+//	c, _ := unifi.NewClient(baseURL, unifi.WithTimeout(30*time.Second))
+//	_ = c.Login(ctx, username, password, true)
+//	http.Handle("/metrics", promexporter.Handler(c, promexporter.Options{}))
+package promexporter
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/platinummonkey/unifi"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// DefaultNamespace is the metric namespace used when none is configured
+const DefaultNamespace = "unifi"
+
+// Options configures the collector
+type Options struct {
+	Namespace string        // the metric namespace, defaults to DefaultNamespace
+	Sites     []string      // the site names to collect, all available sites when empty
+	Timeout   time.Duration // the timeout of a single scrape, defaults to 30 seconds
+	Logger    unifi.Logger  // logs the sites failing to scrape, optional
+}
+
+// Collector is a prometheus.Collector for the sites of a controller
+type Collector struct {
+	client  *unifi.Client
+	sites   []string
+	timeout time.Duration
+	logger  unifi.Logger
+
+	up             *prometheus.Desc
+	scrapeDuration *prometheus.Desc
+	siteUp         *prometheus.Desc
+
+	siteHealth  *prometheus.Desc
+	siteClients *prometheus.Desc
+	siteDevices *prometheus.Desc
+	wanRXRate   *prometheus.Desc
+	wanTXRate   *prometheus.Desc
+	wanLatency  *prometheus.Desc
+
+	deviceInfo        *prometheus.Desc
+	deviceState       *prometheus.Desc
+	deviceUptime      *prometheus.Desc
+	deviceCPU         *prometheus.Desc
+	deviceMemory      *prometheus.Desc
+	deviceTemperature *prometheus.Desc
+	deviceClients     *prometheus.Desc
+	deviceRXBytes     *prometheus.Desc
+	deviceTXBytes     *prometheus.Desc
+
+	radioClients     *prometheus.Desc
+	radioUtilization *prometheus.Desc
+
+	portUp      *prometheus.Desc
+	portSpeed   *prometheus.Desc
+	portRXBytes *prometheus.Desc
+	portTXBytes *prometheus.Desc
+	portPoE     *prometheus.Desc
+}
+
+// New returns a new collector for the client, the client must be logged in
+// client - the controller client
+// opts - the collector options
+func New(client *unifi.Client, opts Options) *Collector {
+	if opts.Namespace == "" {
+		opts.Namespace = DefaultNamespace
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 30 * time.Second
+	}
+	ns := opts.Namespace
+	siteLabels := []string{"site"}
+	deviceLabels := []string{"site", "mac", "name", "type"}
+	radioLabels := []string{"site", "mac", "name", "radio", "radio_name"}
+	portLabels := []string{"site", "mac", "name", "port", "port_name"}
+
+	return &Collector{
+		client:  client,
+		sites:   opts.Sites,
+		timeout: opts.Timeout,
+		logger:  opts.Logger,
+
+		up:             prometheus.NewDesc(prometheus.BuildFQName(ns, "", "up"), "Whether the last scrape of the controller succeeded.", nil, nil),
+		scrapeDuration: prometheus.NewDesc(prometheus.BuildFQName(ns, "scrape", "duration_seconds"), "Duration of the last scrape of the controller.", nil, nil),
+		siteUp:         prometheus.NewDesc(prometheus.BuildFQName(ns, "site", "up"), "Whether the last scrape of the site succeeded.", siteLabels, nil),
+
+		siteHealth:  prometheus.NewDesc(prometheus.BuildFQName(ns, "site", "health_ok"), "Whether the site subsystem reports an ok status.", []string{"site", "subsystem"}, nil),
+		siteClients: prometheus.NewDesc(prometheus.BuildFQName(ns, "site", "clients"), "Number of connected clients by subsystem and type.", []string{"site", "subsystem", "type"}, nil),
+		siteDevices: prometheus.NewDesc(prometheus.BuildFQName(ns, "site", "devices"), "Number of devices by subsystem and state.", []string{"site", "subsystem", "state"}, nil),
+		wanRXRate:   prometheus.NewDesc(prometheus.BuildFQName(ns, "wan", "rx_bytes_rate"), "WAN receive throughput in bytes per second.", siteLabels, nil),
+		wanTXRate:   prometheus.NewDesc(prometheus.BuildFQName(ns, "wan", "tx_bytes_rate"), "WAN transmit throughput in bytes per second.", siteLabels, nil),
+		wanLatency:  prometheus.NewDesc(prometheus.BuildFQName(ns, "wan", "latency_milliseconds"), "WAN latency as measured by the gateway.", siteLabels, nil),
+
+		deviceInfo:        prometheus.NewDesc(prometheus.BuildFQName(ns, "device", "info"), "Device information.", []string{"site", "mac", "name", "type", "model", "version"}, nil),
+		deviceState:       prometheus.NewDesc(prometheus.BuildFQName(ns, "device", "state"), "Device state as reported by the controller, 1 is connected.", deviceLabels, nil),
+		deviceUptime:      prometheus.NewDesc(prometheus.BuildFQName(ns, "device", "uptime_seconds"), "Device uptime.", deviceLabels, nil),
+		deviceCPU:         prometheus.NewDesc(prometheus.BuildFQName(ns, "device", "cpu_percent"), "Device CPU usage.", deviceLabels, nil),
+		deviceMemory:      prometheus.NewDesc(prometheus.BuildFQName(ns, "device", "memory_percent"), "Device memory usage.", deviceLabels, nil),
+		deviceTemperature: prometheus.NewDesc(prometheus.BuildFQName(ns, "device", "temperature_celsius"), "Highest device temperature.", deviceLabels, nil),
+		deviceClients:     prometheus.NewDesc(prometheus.BuildFQName(ns, "device", "clients"), "Number of clients connected to the device.", deviceLabels, nil),
+		deviceRXBytes:     prometheus.NewDesc(prometheus.BuildFQName(ns, "device", "rx_bytes_total"), "Bytes received by the device.", deviceLabels, nil),
+		deviceTXBytes:     prometheus.NewDesc(prometheus.BuildFQName(ns, "device", "tx_bytes_total"), "Bytes transmitted by the device.", deviceLabels, nil),
+
+		radioClients:     prometheus.NewDesc(prometheus.BuildFQName(ns, "radio", "clients"), "Number of clients connected to the radio.", radioLabels, nil),
+		radioUtilization: prometheus.NewDesc(prometheus.BuildFQName(ns, "radio", "channel_utilization_percent"), "Radio channel utilization.", radioLabels, nil),
+
+		portUp:      prometheus.NewDesc(prometheus.BuildFQName(ns, "port", "up"), "Whether the port link is up.", portLabels, nil),
+		portSpeed:   prometheus.NewDesc(prometheus.BuildFQName(ns, "port", "speed_mbps"), "Port link speed.", portLabels, nil),
+		portRXBytes: prometheus.NewDesc(prometheus.BuildFQName(ns, "port", "rx_bytes_total"), "Bytes received on the port.", portLabels, nil),
+		portTXBytes: prometheus.NewDesc(prometheus.BuildFQName(ns, "port", "tx_bytes_total"), "Bytes transmitted on the port.", portLabels, nil),
+		portPoE:     prometheus.NewDesc(prometheus.BuildFQName(ns, "port", "poe_power_watts"), "PoE power drawn on the port.", portLabels, nil),
+	}
+}
+
+// Handler returns a http handler serving the metrics of a new collector on a dedicated registry
+// client - the controller client
+// opts - the collector options
+func Handler(client *unifi.Client, opts Options) http.Handler {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(New(client, opts))
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		c.up, c.scrapeDuration, c.siteUp,
+		c.siteHealth, c.siteClients, c.siteDevices, c.wanRXRate, c.wanTXRate, c.wanLatency,
+		c.deviceInfo, c.deviceState, c.deviceUptime, c.deviceCPU, c.deviceMemory, c.deviceTemperature,
+		c.deviceClients, c.deviceRXBytes, c.deviceTXBytes,
+		c.radioClients, c.radioUtilization,
+		c.portUp, c.portSpeed, c.portRXBytes, c.portTXBytes, c.portPoE,
+	} {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	up := 1.0
+	if err := c.collect(ctx, ch); err != nil {
+		up = 0
+	}
+	ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, up)
+	ch <- prometheus.MustNewConstMetric(c.scrapeDuration, prometheus.GaugeValue, time.Since(start).Seconds())
+}
+
+// collect gathers the metrics of every configured site, a failing site is reported by the site up metric and
+// does not abort the scrape of the other sites
+func (c *Collector) collect(ctx context.Context, ch chan<- prometheus.Metric) error {
+	sites := c.sites
+	if len(sites) == 0 {
+		resp, err := c.client.ListSites(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range resp.Data {
+			sites = append(sites, s.Name)
+		}
+	}
+
+	for _, site := range sites {
+		siteUp := 1.0
+		if err := c.collectSite(ctx, ch, site); err != nil {
+			siteUp = 0
+			if c.logger != nil {
+				c.logger.Warnw("failed to scrape site", "site", site, "error", err)
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(c.siteUp, prometheus.GaugeValue, siteUp, site)
+	}
+	return nil
+}
+
+// collectSite gathers the metrics of a single site
+func (c *Collector) collectSite(ctx context.Context, ch chan<- prometheus.Metric, site string) error {
+	if err := c.collectHealth(ctx, ch, site); err != nil {
+		return err
+	}
+	return c.collectDevices(ctx, ch, site)
+}
+
+// collectHealth gathers the site health, client count and WAN throughput metrics
+func (c *Collector) collectHealth(ctx context.Context, ch chan<- prometheus.Metric, site string) error {
+	health, err := c.client.SiteHealth(ctx, site)
+	if err != nil {
+		return err
+	}
+	for _, h := range health.Data {
+		ok := 0.0
+		if h.IsOK() {
+			ok = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.siteHealth, prometheus.GaugeValue, ok, site, h.SubSystem)
+
+		switch unifi.HealthSubsystem(h.SubSystem) {
+		case unifi.HealthSubsystemLAN, unifi.HealthSubsystemWLAN:
+			ch <- prometheus.MustNewConstMetric(c.siteClients, prometheus.GaugeValue, float64(h.NumberUser), site, h.SubSystem, "user")
+			ch <- prometheus.MustNewConstMetric(c.siteClients, prometheus.GaugeValue, float64(h.NumberGuest), site, h.SubSystem, "guest")
+			ch <- prometheus.MustNewConstMetric(c.siteDevices, prometheus.GaugeValue, float64(h.NumberAdopted), site, h.SubSystem, "adopted")
+			ch <- prometheus.MustNewConstMetric(c.siteDevices, prometheus.GaugeValue, float64(h.NumberDisconnected), site, h.SubSystem, "disconnected")
+			ch <- prometheus.MustNewConstMetric(c.siteDevices, prometheus.GaugeValue, float64(h.NumberPending), site, h.SubSystem, "pending")
+		case unifi.HealthSubsystemWAN:
+			ch <- prometheus.MustNewConstMetric(c.wanRXRate, prometheus.GaugeValue, float64(h.RXBytesR), site)
+			ch <- prometheus.MustNewConstMetric(c.wanTXRate, prometheus.GaugeValue, float64(h.TXBytesR), site)
+		case unifi.HealthSubsystemWWW:
+			ch <- prometheus.MustNewConstMetric(c.wanLatency, prometheus.GaugeValue, float64(h.Latency), site)
+		}
+	}
+	return nil
+}
+
+// collectDevices gathers the device, radio and port metrics
+func (c *Collector) collectDevices(ctx context.Context, ch chan<- prometheus.Metric, site string) error {
+	devices, err := c.client.ListDeviceStats(ctx, site)
+	if err != nil {
+		return err
+	}
+	for _, d := range devices {
+		name := d.Name
+		if name == "" {
+			name = d.MAC
+		}
+		labels := []string{site, d.MAC, name, string(d.Type)}
+
+		ch <- prometheus.MustNewConstMetric(c.deviceInfo, prometheus.GaugeValue, 1, site, d.MAC, name, string(d.Type), d.Model, d.Version)
+		ch <- prometheus.MustNewConstMetric(c.deviceState, prometheus.GaugeValue, float64(d.State), labels...)
+		ch <- prometheus.MustNewConstMetric(c.deviceUptime, prometheus.GaugeValue, float64(d.Uptime), labels...)
+		ch <- prometheus.MustNewConstMetric(c.deviceCPU, prometheus.GaugeValue, d.CPU(), labels...)
+		ch <- prometheus.MustNewConstMetric(c.deviceMemory, prometheus.GaugeValue, d.Memory(), labels...)
+		if d.HasTemperature {
+			ch <- prometheus.MustNewConstMetric(c.deviceTemperature, prometheus.GaugeValue, d.Temperature(), labels...)
+		}
+		ch <- prometheus.MustNewConstMetric(c.deviceClients, prometheus.GaugeValue, float64(d.NumberSTA), labels...)
+		ch <- prometheus.MustNewConstMetric(c.deviceRXBytes, prometheus.CounterValue, float64(d.RXBytes), labels...)
+		ch <- prometheus.MustNewConstMetric(c.deviceTXBytes, prometheus.CounterValue, float64(d.TXBytes), labels...)
+
+		for _, r := range d.RadioTableStats {
+			ch <- prometheus.MustNewConstMetric(c.radioClients, prometheus.GaugeValue, float64(r.NumberSTA), site, d.MAC, name, r.Radio, r.Name)
+			ch <- prometheus.MustNewConstMetric(c.radioUtilization, prometheus.GaugeValue, float64(r.CUTotal), site, d.MAC, name, r.Radio, r.Name)
+		}
+
+		for _, p := range d.PortTable {
+			portLabels := []string{site, d.MAC, name, strconv.Itoa(p.PortIdx), p.Name}
+			portUp := 0.0
+			if p.Up {
+				portUp = 1
+			}
+			ch <- prometheus.MustNewConstMetric(c.portUp, prometheus.GaugeValue, portUp, portLabels...)
+			ch <- prometheus.MustNewConstMetric(c.portSpeed, prometheus.GaugeValue, float64(p.Speed), portLabels...)
+			ch <- prometheus.MustNewConstMetric(c.portRXBytes, prometheus.CounterValue, float64(p.RXBytes), portLabels...)
+			ch <- prometheus.MustNewConstMetric(c.portTXBytes, prometheus.CounterValue, float64(p.TXBytes), portLabels...)
+			if p.PortPoE {
+				ch <- prometheus.MustNewConstMetric(c.portPoE, prometheus.GaugeValue, toFloat(p.PoEPower), portLabels...)
+			}
+		}
+	}
+	return nil
+}
+
+// toFloat converts the string or number values reported by the controller
+func toFloat(v interface{}) float64 {
+	switch t := v.(type) {
+	case float64:
+		return t
+	case string:
+		f, err := strconv.ParseFloat(t, 64)
+		if err != nil {
+			return 0
+		}
+		return f
+	}
+	return 0
+}
@@ -0,0 +1,63 @@
+package promexporter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/platinummonkey/unifi"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func newTestCollector(t *testing.T, sites ...string) *Collector {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/s/good/stat/health":
+			_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+		case "/api/s/good/stat/device":
+			// a tri-radio access point with two 5GHz radios
+			_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"mac":"aa:bb:cc:dd:ee:ff","name":"ap","type":"uap","radio_table_stats":[
+				{"name":"wifi0","radio":"ng","num_sta":1,"cu_total":10},
+				{"name":"wifi1","radio":"na","num_sta":2,"cu_total":20},
+				{"name":"wifi2","radio":"na","num_sta":3,"cu_total":30}]}]}`))
+		default:
+			http.Error(w, "unavailable", http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	c, err := unifi.NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetUniFiOS(false)
+	return New(c, Options{Sites: sites})
+}
+
+func TestCollectorReportsFailingSites(t *testing.T) {
+	col := newTestCollector(t, "bad", "good")
+	expected := `# HELP unifi_site_up Whether the last scrape of the site succeeded.
+# TYPE unifi_site_up gauge
+unifi_site_up{site="bad"} 0
+unifi_site_up{site="good"} 1
+`
+	if err := testutil.CollectAndCompare(col, strings.NewReader(expected), "unifi_site_up"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCollectorRadiosOnTheSameBand(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(newTestCollector(t, "good"))
+	expected := `# HELP unifi_radio_clients Number of clients connected to the radio.
+# TYPE unifi_radio_clients gauge
+unifi_radio_clients{mac="aa:bb:cc:dd:ee:ff",name="ap",radio="na",radio_name="wifi1",site="good"} 2
+unifi_radio_clients{mac="aa:bb:cc:dd:ee:ff",name="ap",radio="na",radio_name="wifi2",site="good"} 3
+unifi_radio_clients{mac="aa:bb:cc:dd:ee:ff",name="ap",radio="ng",radio_name="wifi0",site="good"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(expected), "unifi_radio_clients"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,73 @@
+package unifi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// RawResponse is a controller response without any assumptions about its shape
+type RawResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// JSON decodes the body into v
+func (r *RawResponse) JSON(v interface{}) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// Raw issues a request and returns the response as is, for debugging and for endpoints that do not use
+// the meta/data shape. Authentication, relogin, retries and middlewares apply as for every other request,
+// but error status codes are returned in the response rather than as an error.
+// method - the HTTP method
+// path - the controller path including any query string, e.g. `/api/s/default/stat/device`,
+// the UniFi OS network prefix is added when required
+// body - the request body, nil for none
+// requestOpts - per-request options, e.g. WithRequestTimeout, applied on top of those carried by ctx
+func (c *Client) Raw(ctx context.Context, method string, path string, body io.Reader, requestOpts ...RequestOption) (*RawResponse, error) {
+	ctx = withRequestOptions(ctx, requestOpts)
+	opts := requestOptionsFrom(ctx)
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
+	rawQuery := ""
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path, rawQuery = path[:i], path[i+1:]
+	}
+	u := c.WithPathAndQueryParams(c.apiPath(path))
+	u.RawQuery = rawQuery
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	c.SetHeaders(req)
+	req.Header.Set("Accept", "*/*")
+	_, _, generation := c.sessionState()
+
+	resp, err := c.doWithRetry(req, opts.retry)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && c.canRelogin() {
+		resp.Body.Close()
+		resp, err = c.relogin(req, generation)
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &RawResponse{StatusCode: resp.StatusCode, Header: resp.Header, Body: data}, nil
+}
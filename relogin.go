@@ -0,0 +1,75 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// ReloginHook is called every time the client re-authenticates after the controller invalidated the session.
+// err is nil if the re-login succeeded.
+type ReloginHook func(ctx context.Context, err error)
+
+// SetAutoRelogin enables or disables the transparent re-login when the session expires, enabled by default.
+// When enabled a request failing with HTTP 401 triggers a login with the last used credentials,
+// or with fresh credentials of the provider when one is set, and the request is retried once.
+func (c *Client) SetAutoRelogin(enabled bool) {
+	c.disableRelogin = !enabled
+}
+
+// SetReloginHook sets the hook called on every automatic re-login, set to nil to remove it.
+func (c *Client) SetReloginHook(hook ReloginHook) {
+	c.reloginHook = hook
+}
+
+// canRelogin returns true if the client is able to re-login on its own
+func (c *Client) canRelogin() bool {
+	username, password := c.credentials()
+	if password == "" && c.provider() == nil {
+		// e.g. a restored session, see WithSessionStore
+		return false
+	}
+	return !c.disableRelogin && c.apiKey == "" && username != ""
+}
+
+// relogin logs in again with the stored or provided credentials and retries the request once.
+// Concurrent requests failing with the same expired session share a single re-login.
+// generation - the session generation the failed request was sent with
+func (c *Client) relogin(req *http.Request, generation uint64) (*http.Response, error) {
+	ctx := req.Context()
+
+	c.reloginMu.Lock()
+	if _, _, current := c.sessionState(); current == generation {
+		username, password, err := c.reloginCredentials(ctx)
+		c.clearSession()
+
+		if err == nil {
+			c.log().Infow("session expired, logging in again", "username", username, "path", req.URL.Path)
+			err = c.login(ctx, username, password, c.isLongRunningSession())
+		}
+		if c.reloginHook != nil {
+			c.reloginHook(ctx, err)
+		}
+		if err != nil {
+			c.reloginMu.Unlock()
+			c.log().Errorw("re-login failed", "username", username, "error", err)
+			return nil, err
+		}
+		c.persistSession(ctx)
+	}
+	c.reloginMu.Unlock()
+
+	var err error
+	retry := req.Clone(ctx)
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	} else if req.Body != nil && req.Body != http.NoBody {
+		return nil, fmt.Errorf("unable to retry %s %s, request body can not be replayed", req.Method, req.URL.Path)
+	}
+	retry.Header.Del("Cookie")
+	c.SetHeaders(retry)
+	return c.do(retry)
+}
@@ -0,0 +1,154 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy defines how failed requests are retried
+type RetryPolicy struct {
+	MaxAttempts int           // the total number of attempts, including the first one
+	Backoff     time.Duration // the wait before the first retry, doubled on every further retry, defaults to 500ms
+	MaxBackoff  time.Duration // the upper bound of the wait between retries, defaults to 10s
+	// RetryOn decides if the attempt is retried, resp is nil when err is set.
+	// Defaults to DefaultRetryOn.
+	RetryOn func(resp *http.Response, err error) bool
+}
+
+// DefaultRetryOn retries transport errors, rate limited and temporarily unavailable responses.
+// Errors of the request context are never retried.
+func DefaultRetryOn(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// requestOptions are the per-request options carried by the context
+type requestOptions struct {
+	timeout     time.Duration
+	retry       *RetryPolicy
+	contentType string
+	noRedirect  bool
+}
+
+// RequestOption configures a single request. Do, Get, Post, DoV2 and Raw accept them as a parameter, the typed
+// methods pick them up from the context, see WithRequestOptions and the package documentation.
+type RequestOption func(o *requestOptions)
+
+// WithRequestTimeout bounds each request, including its retries, by the timeout
+func WithRequestTimeout(timeout time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithRetryPolicy retries failed requests according to the policy
+func WithRetryPolicy(policy RetryPolicy) RequestOption {
+	return func(o *requestOptions) {
+		o.retry = &policy
+	}
+}
+
+// withContentType overrides the JSON content type, e.g. for file uploads
+func withContentType(contentType string) RequestOption {
+	return func(o *requestOptions) {
+		o.contentType = contentType
+	}
+}
+
+// withoutRedirects returns the redirect responses instead of following them
+func withoutRedirects() RequestOption {
+	return func(o *requestOptions) {
+		o.noRedirect = true
+	}
+}
+
+type requestOptionsKey struct{}
+
+// WithRequestOptions returns a context applying the options to every request issued with it, e.g.
+//
+//	resp, err := c.SiteHealth(unifi.WithRequestOptions(ctx, unifi.WithRequestTimeout(2*time.Second)), site)
+//
+// The site methods do not take a variadic options parameter, many of them already end in a variadic
+// parameter, e.g. the macs of ListDevices, and adding one to each would break every caller. The context is
+// the one parameter they all share, so it carries the options instead; Do, DoV2 and Raw take them directly.
+// Options already carried by ctx are kept unless overridden.
+func WithRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	o := requestOptionsFrom(ctx)
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return context.WithValue(ctx, requestOptionsKey{}, o)
+}
+
+// withRequestOptions returns the context with the options, the context itself when there are none
+func withRequestOptions(ctx context.Context, opts []RequestOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+	return WithRequestOptions(ctx, opts...)
+}
+
+// requestOptionsFrom returns the request options carried by the context
+func requestOptionsFrom(ctx context.Context) requestOptions {
+	o, _ := ctx.Value(requestOptionsKey{}).(requestOptions)
+	return o
+}
+
+// doWithRetry sends the request, retrying according to the policy. A nil policy sends the request once.
+func (c *Client) doWithRetry(req *http.Request, policy *RetryPolicy) (*http.Response, error) {
+	if policy == nil || policy.MaxAttempts <= 1 {
+		return c.do(req)
+	}
+	retryOn := policy.RetryOn
+	if retryOn == nil {
+		retryOn = DefaultRetryOn
+	}
+	backoff := policy.Backoff
+	if backoff <= 0 {
+		backoff = 500 * time.Millisecond
+	}
+	maxBackoff := policy.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 10 * time.Second
+	}
+
+	ctx := req.Context()
+	attempt := req
+	for i := 1; ; i++ {
+		resp, err := c.do(attempt)
+		if ctx.Err() != nil || i >= policy.MaxAttempts || !retryOn(resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		attempt = req.Clone(ctx)
+		if req.GetBody != nil {
+			attempt.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		} else if req.Body != nil && req.Body != http.NoBody {
+			return nil, fmt.Errorf("unable to retry %s %s, request body can not be replayed", req.Method, req.URL.Path)
+		}
+
+		c.log().Warnw("retrying request", "method", req.Method, "path", req.URL.Path, "attempt", i+1, "backoff", backoff)
+		if err := sleepContext(ctx, backoff); err != nil {
+			return nil, err
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
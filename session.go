@@ -0,0 +1,67 @@
+package unifi
+
+import (
+	"net/http"
+)
+
+// session state accessors, the session is shared by every request and guarded by the client mutex
+
+// setSession stores the session of a successful login
+func (c *Client) setSession(cookies []*http.Cookie, csrfToken string, remember bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.authCookies = cookies
+	if csrfToken != "" {
+		c.csrfToken = csrfToken
+	}
+	c.longRunningSession = remember
+	c.sessionGeneration++
+	// probes failing before the login say nothing about the controller
+	c.capabilitiesErr = nil
+}
+
+// setCSRFToken updates the CSRF token, UniFi OS rotates it with responses
+func (c *Client) setCSRFToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.csrfToken = token
+}
+
+// clearSession drops the session cookies and CSRF token
+func (c *Client) clearSession() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.authCookies = nil
+	c.csrfToken = ""
+}
+
+// sessionState returns a copy of the session cookies, the CSRF token and the session generation
+func (c *Client) sessionState() ([]*http.Cookie, string, uint64) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	cookies := make([]*http.Cookie, len(c.authCookies))
+	copy(cookies, c.authCookies)
+	return cookies, c.csrfToken, c.sessionGeneration
+}
+
+// isLongRunningSession returns true if the session was created with remember set
+func (c *Client) isLongRunningSession() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.longRunningSession
+}
+
+// setCredentials stores the credentials used to re-login, empty values disable the re-login
+func (c *Client) setCredentials(username string, password string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.username = username
+	c.password = password
+}
+
+// credentials returns the stored credentials
+func (c *Client) credentials() (string, string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.username, c.password
+}
@@ -0,0 +1,157 @@
+package unifi
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SessionData is the persisted login session, see SessionStore
+type SessionData struct {
+	Cookies   []*http.Cookie `json:"cookies"`
+	CSRFToken string         `json:"csrf_token,omitempty"`
+	UniFiOS   bool           `json:"unifi_os"`
+	Remember  bool           `json:"remember"`
+	Username  string         `json:"username,omitempty"` // the logged in user, the password is never persisted
+	SavedAt   time.Time      `json:"saved_at"`
+}
+
+// expired returns true if every cookie with an expiry has expired
+func (d *SessionData) expired(now time.Time) bool {
+	if len(d.Cookies) == 0 {
+		return true
+	}
+	for _, cookie := range d.Cookies {
+		if cookie.Expires.IsZero() || cookie.Expires.After(now) {
+			return false
+		}
+	}
+	return true
+}
+
+// SessionStore persists the login session across process restarts, so short-lived processes
+// do not login on every run. Implementations must be safe for concurrent use.
+type SessionStore interface {
+	// Load returns the stored session, nil if there is none
+	Load(ctx context.Context) (*SessionData, error)
+	// Save stores the session, replacing any stored session
+	Save(ctx context.Context, session *SessionData) error
+	// Clear removes the stored session
+	Clear(ctx context.Context) error
+}
+
+// FileSessionStore stores the session as JSON in a file only readable by the current user
+type FileSessionStore struct {
+	Path string
+}
+
+// Load implements SessionStore
+func (s *FileSessionStore) Load(ctx context.Context) (*SessionData, error) {
+	data, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var session SessionData
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Save implements SessionStore, the file is replaced atomically
+func (s *FileSessionStore) Save(ctx context.Context, session *SessionData) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// Clear implements SessionStore
+func (s *FileSessionStore) Clear(ctx context.Context) error {
+	err := os.Remove(s.Path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// WithSessionStore persists the session in the store after every login and restores it in NewClient.
+// A restored session is used until the controller rejects it, re-logins then require a credentials provider,
+// see WithCredentialsProvider, as the password is never persisted.
+func WithSessionStore(store SessionStore) Option {
+	return func(o *clientOptions) {
+		o.sessionStore = store
+	}
+}
+
+// restoreSession loads the stored session, failures are logged and the client starts without a session
+func (c *Client) restoreSession(ctx context.Context) {
+	if c.sessionStore == nil {
+		return
+	}
+	session, err := c.sessionStore.Load(ctx)
+	if err != nil {
+		c.log().Warnw("unable to load the stored session", "error", err)
+		return
+	}
+	if session == nil || session.expired(time.Now()) {
+		return
+	}
+	c.SetUniFiOS(session.UniFiOS)
+	c.setSession(session.Cookies, session.CSRFToken, session.Remember)
+	c.setCredentials(session.Username, "")
+	c.log().Debugw("restored stored session", "username", session.Username, "saved_at", session.SavedAt)
+}
+
+// persistSession saves the current session in the store, failures are logged
+func (c *Client) persistSession(ctx context.Context) {
+	if c.sessionStore == nil {
+		return
+	}
+	cookies, csrfToken, _ := c.sessionState()
+	username, _ := c.credentials()
+	session := &SessionData{
+		Cookies:   cookies,
+		CSRFToken: csrfToken,
+		UniFiOS:   c.IsUniFiOS(),
+		Remember:  c.isLongRunningSession(),
+		Username:  username,
+		SavedAt:   time.Now().UTC(),
+	}
+	if err := c.sessionStore.Save(ctx, session); err != nil {
+		c.log().Warnw("unable to store the session", "error", err)
+	}
+}
+
+// forgetSession removes the stored session, failures are logged
+func (c *Client) forgetSession(ctx context.Context) {
+	if c.sessionStore == nil {
+		return
+	}
+	if err := c.sessionStore.Clear(ctx); err != nil {
+		c.log().Warnw("unable to clear the stored session", "error", err)
+	}
+}
@@ -0,0 +1,125 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// AdminRole defines the role of an admin on a site
+type AdminRole string
+
+// The supported admin roles
+const (
+	AdminRoleAdmin    AdminRole = "admin"
+	AdminRoleReadOnly AdminRole = "readonly"
+)
+
+// IsValid returns true if it's a valid admin role.
+// there are only a few valid types
+func (r AdminRole) IsValid() bool {
+	switch r {
+	case AdminRoleAdmin, AdminRoleReadOnly:
+		return true
+	default:
+		return false
+	}
+}
+
+// Known admin permissions
+const (
+	AdminPermissionDeviceAdopt   = "API_DEVICE_ADOPT"
+	AdminPermissionDeviceRestart = "API_DEVICE_RESTART"
+)
+
+// Admin defines a controller administrator
+type Admin struct {
+	ID                   string    `json:"_id"`
+	Name                 string    `json:"name"`
+	Email                string    `json:"email"`
+	Role                 AdminRole `json:"role"`
+	Permissions          []string  `json:"permissions"`
+	IsSuper              bool      `json:"is_super"`
+	IsOwner              bool      `json:"is_owner"`
+	IsLocal              bool      `json:"is_local"`
+	ForSSO               bool      `json:"for_sso"`
+	EmailAlertEnabled    bool      `json:"email_alert_enabled"`
+	SuperSitePermissions []string  `json:"super_site_permissions,omitempty"`
+	LastSiteName         string    `json:"last_site_name,omitempty"`
+	RequiresNewPassword  bool      `json:"requires_new_password"`
+	TimeCreated          int64     `json:"time_created"`
+	Pending              bool      `json:"is_pending"` // the invite was not accepted yet
+}
+
+// HasPermission returns true if the admin was granted the permission
+func (a Admin) HasPermission(permission string) bool {
+	for _, p := range a.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// AdminsResponse contains the admins response
+type AdminsResponse struct {
+	Meta CommonMeta `json:"meta"`
+	Data []Admin    `json:"data"`
+}
+
+// ListAdmins will list the admins with access to the site
+// site - the site to query
+func (c *Client) ListAdmins(ctx context.Context, site string) (*AdminsResponse, error) {
+	data := []byte(`{"cmd": "get-admins"}`)
+
+	var resp AdminsResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/sitemgr", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// ListAllAdmins will list the admins of all sites, this requires super admin access
+func (c *Client) ListAllAdmins(ctx context.Context) (*AdminsResponse, error) {
+	var resp AdminsResponse
+	err := c.doRequest(ctx, http.MethodGet, "/api/stat/admin", nil, &resp)
+	return &resp, err
+}
+
+// SetAdminRole will update the role and permissions of an admin on the site
+// site - the site to modify
+// adminID - 24-char string _id of the admin - from ListAdmins
+// role - the new role
+// permissions - the permissions to grant, replacing the existing permissions
+func (c *Client) SetAdminRole(ctx context.Context, site string, adminID string, role AdminRole, permissions ...string) (*GenericResponse, error) {
+	if !role.IsValid() {
+		return nil, fmt.Errorf("invalid admin role specified: %s", role)
+	}
+	if permissions == nil {
+		permissions = make([]string, 0)
+	}
+	payload := map[string]interface{}{
+		"cmd":         "update-admin",
+		"admin":       strings.TrimSpace(adminID),
+		"role":        role,
+		"permissions": permissions,
+	}
+	data, _ := json.Marshal(payload)
+
+	var resp GenericResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/sitemgr", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// IsSuperAdmin returns true if the logged in user is a super admin
+func (c *Client) IsSuperAdmin(ctx context.Context) (bool, error) {
+	resp, err := c.Self(ctx)
+	if err != nil {
+		return false, err
+	}
+	if len(resp.Data) == 0 {
+		return false, fmt.Errorf("controller did not return the logged in user")
+	}
+	return resp.Data[0].IsSuper, nil
+}
@@ -2,9 +2,12 @@ package unifi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 // SiteAlarmsAlarm is an alarm event
@@ -55,6 +58,26 @@ type SiteAlarmsAlarm struct {
 	USGIPASN              string      `json:"usgipASN"`
 	USGIPCountry          interface{} `json:"usgipCountry"`
 	USGIPGeo              GeoCodeData `json:"usgipGeo"`
+	HandledAdminID        string      `json:"handled_admin_id"`
+	HandledTime           string      `json:"handled_time"`
+}
+
+// Alarm is an alarm event, see ListAlarms
+type Alarm = SiteAlarmsAlarm
+
+// Created returns the time the alarm was raised
+func (a SiteAlarmsAlarm) Created() time.Time {
+	return time.Unix(0, a.Time*int64(time.Millisecond)).UTC()
+}
+
+// Severity returns the IPS alert severity, 1 is the most severe, 0 for alarms without a severity
+func (a SiteAlarmsAlarm) Severity() int {
+	return a.InnerAlertSeverity
+}
+
+// Handled returns true if the alarm was archived or handled by an admin
+func (a SiteAlarmsAlarm) Handled() bool {
+	return a.Archived || a.HandledAdminID != ""
 }
 
 // SiteAlarmsResponse contains the stat/alarms alarm events response
@@ -70,7 +93,7 @@ type SiteAlarmsResponse struct {
 // limit - limit the max amount of events returned, defaults to 3000 if zero-value
 // order - defined the sort order of the alarm events
 // archived - query archived (when true) or unarchived (default) alarm events
-func (c *Client) SiteAlarms(site string, historyHours int, offset int, limit int, order EventSortOrder, archived bool) (*SiteAlarmsResponse, error) {
+func (c *Client) SiteAlarms(ctx context.Context, site string, historyHours int, offset int, limit int, order EventSortOrder, archived bool) (*SiteAlarmsResponse, error) {
 	if historyHours <= 0 {
 		historyHours = 24
 	}
@@ -95,7 +118,7 @@ func (c *Client) SiteAlarms(site string, historyHours int, offset int, limit int
 	data, _ := json.Marshal(&payload)
 
 	var resp SiteAlarmsResponse
-	err := c.doSiteRequest(http.MethodGet, site, fmt.Sprintf("stat/alarm?archived=%t", archived), bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "stat/alarm", bytes.NewReader(data), &resp, "archived", strconv.FormatBool(archived))
 	return &resp, err
 }
 
@@ -109,7 +132,7 @@ type SiteAlarmsCountResponse struct {
 // site - site to query
 // historyHours - number of hours of history to return, defaults to 24 hours
 // archived - query archived (when true) or unarchived (default) alarm events
-func (c *Client) SiteAlarmsCount(site string, historyHours int, archived bool) (*SiteAlarmsCountResponse, error) {
+func (c *Client) SiteAlarmsCount(ctx context.Context, site string, historyHours int, archived bool) (*SiteAlarmsCountResponse, error) {
 	if historyHours <= 0 {
 		historyHours = 720
 	}
@@ -121,6 +144,52 @@ func (c *Client) SiteAlarmsCount(site string, historyHours int, archived bool) (
 	data, _ := json.Marshal(&payload)
 
 	var resp SiteAlarmsCountResponse
-	err := c.doSiteRequest(http.MethodGet, site, fmt.Sprintf("stat/alarm/cnt/?archived=%t", archived), bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "stat/alarm/cnt", bytes.NewReader(data), &resp, "archived", strconv.FormatBool(archived))
+	return &resp, err
+}
+
+// AlarmFilter defines the filters applied when listing alarms, the zero-value lists all alarms
+type AlarmFilter struct {
+	Archived    *bool          // only list archived (true) or unarchived (false) alarms, nil for both
+	WithinHours int            // only list alarms of the last hours, 0 for no limit
+	Start       int            // pagination offset
+	Limit       int            // pagination limit, 0 for no limit, at most 3000
+	Order       EventSortOrder // defaults to newest first
+}
+
+// ListAlarms lists the alarms for the site
+// site - site to query
+// filter - the alarm filters
+func (c *Client) ListAlarms(ctx context.Context, site string, filter AlarmFilter) (*SiteAlarmsResponse, error) {
+	if filter.Order == "" {
+		filter.Order = EventSortOrderTimeDescending
+	}
+	if !filter.Order.IsValid() {
+		return nil, fmt.Errorf("invalid sort order: %s", filter.Order)
+	}
+
+	payload := map[string]interface{}{
+		"_sort": string(filter.Order),
+	}
+	if filter.Archived != nil {
+		payload["archived"] = *filter.Archived
+	}
+	if filter.WithinHours > 0 {
+		payload["within"] = filter.WithinHours
+	}
+	if filter.Start > 0 {
+		payload["_start"] = filter.Start
+	}
+	if filter.Limit > 0 {
+		if filter.Limit > 3000 {
+			// there is a default max
+			filter.Limit = 3000
+		}
+		payload["_limit"] = filter.Limit
+	}
+	data, _ := json.Marshal(&payload)
+
+	var resp SiteAlarmsResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "list/alarm", bytes.NewReader(data), &resp)
 	return &resp, err
 }
@@ -0,0 +1,179 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// APLoadPoint is a single client count data point
+type APLoadPoint struct {
+	Time      time.Time
+	NumberSTA float64
+}
+
+// APRadioPoint is a single data point of an access point radio from the ap report
+type APRadioPoint struct {
+	Time               time.Time
+	NumberSTA          float64
+	ChannelUtilization float64 // percent of airtime in use
+	ChannelSelfRX      float64 // percent of airtime used receiving from own clients
+	ChannelSelfTX      float64 // percent of airtime used sending to own clients
+	TXPackets          float64
+	TXRetries          float64
+}
+
+// RetryRate returns the percentage of transmitted packets that were retried
+func (p APRadioPoint) RetryRate() float64 {
+	if p.TXPackets == 0 {
+		return 0
+	}
+	return p.TXRetries / p.TXPackets * 100
+}
+
+// APRadioLoad is the load of a single access point radio
+type APRadioLoad struct {
+	Radio   string         // `ng` for 2.4GHz, `na` for 5GHz, `6e` for 6GHz
+	Channel interface{}    // the current channel, sometimes string or int
+	Points  []APRadioPoint // historical client count, airtime and retries from the ap report
+}
+
+// APLoad is the client and airtime load of an access point
+type APLoad struct {
+	MAC       string
+	Interval  ReportInterval
+	NumberSTA []APLoadPoint // historical client count over all radios
+	Radios    []APRadioLoad
+}
+
+// apRadioAttributes are the ap report attributes of a radio
+type apRadioAttributes struct {
+	numberSTA, cuTotal, cuSelfRX, cuSelfTX, txPackets, txRetries ReportAttribute
+}
+
+// apLoadRadioAttributes maps the radio to its report attributes
+var apLoadRadioAttributes = map[string]apRadioAttributes{
+	"ng": {
+		numberSTA: ReportAttributeNGNumberSTA,
+		cuTotal:   ReportAttributeNGCUTotal,
+		cuSelfRX:  ReportAttributeNGCUSelfRX,
+		cuSelfTX:  ReportAttributeNGCUSelfTX,
+		txPackets: ReportAttributeNGTXPackets,
+		txRetries: ReportAttributeNGTXRetries,
+	},
+	"na": {
+		numberSTA: ReportAttributeNANumberSTA,
+		cuTotal:   ReportAttributeNACUTotal,
+		cuSelfRX:  ReportAttributeNACUSelfRX,
+		cuSelfTX:  ReportAttributeNACUSelfTX,
+		txPackets: ReportAttributeNATXPackets,
+		txRetries: ReportAttributeNATXRetries,
+	},
+	"6e": {
+		numberSTA: ReportAttribute6ENumberSTA,
+		cuTotal:   ReportAttribute6ECUTotal,
+		cuSelfRX:  ReportAttribute6ECUSelfRX,
+		cuSelfTX:  ReportAttribute6ECUSelfTX,
+		txPackets: ReportAttribute6ETXPackets,
+		txRetries: ReportAttribute6ETXRetries,
+	},
+}
+
+// list returns the attributes to request
+func (a apRadioAttributes) list() []ReportAttribute {
+	return []ReportAttribute{a.numberSTA, a.cuTotal, a.cuSelfRX, a.cuSelfTX, a.txPackets, a.txRetries}
+}
+
+// point returns the radio data point of the report point, false when the point has no data for the radio
+func (a apRadioAttributes) point(p SiteReport) (APRadioPoint, bool) {
+	ret := APRadioPoint{Time: p.Time()}
+	found := false
+	for attr, v := range map[ReportAttribute]*float64{
+		a.numberSTA: &ret.NumberSTA,
+		a.cuTotal:   &ret.ChannelUtilization,
+		a.cuSelfRX:  &ret.ChannelSelfRX,
+		a.cuSelfTX:  &ret.ChannelSelfTX,
+		a.txPackets: &ret.TXPackets,
+		a.txRetries: &ret.TXRetries,
+	} {
+		if f, ok := p.Float(attr); ok {
+			*v = f
+			found = true
+		}
+	}
+	return ret, found
+}
+
+// apLoadInterval returns the finest report interval the controller keeps for the window
+func apLoadInterval(window time.Duration) ReportInterval {
+	switch {
+	case window <= 12*time.Hour:
+		return ReportInterval5Min
+	case window <= 7*24*time.Hour:
+		return ReportIntervalHourly
+	default:
+		return ReportIntervalDaily
+	}
+}
+
+// APLoad returns the client count, channel utilization and retry history per radio over the window
+// site - the site to query
+// apMAC - the access point mac
+// window - how far back the history goes
+func (c *Client) APLoad(ctx context.Context, site string, apMAC string, window time.Duration) (*APLoad, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("invalid window specified: %s", window)
+	}
+	apMAC = strings.ToLower(apMAC)
+
+	device, err := c.DeviceStats(ctx, site, apMAC)
+	if err != nil {
+		return nil, err
+	}
+	// not only uap devices have radios, e.g. the udm
+	if len(device.RadioTableStats) == 0 {
+		return nil, fmt.Errorf("device %s has no radios: %s", apMAC, device.Type)
+	}
+
+	attributes := []ReportAttribute{ReportAttributeNumberSTA, ReportAttributeTime}
+	for _, radio := range device.RadioTableStats {
+		if attrs, ok := apLoadRadioAttributes[radio.Radio]; ok {
+			attributes = append(attributes, attrs.list()...)
+		}
+	}
+
+	until := time.Now().UTC()
+	interval := apLoadInterval(window)
+	query := NewReportQuery(site).
+		Type(ReportTypeAP).
+		Interval(interval).
+		Attributes(attributes...).
+		MACs(apMAC).
+		Between(until.Add(-window), until).
+		Chunked(0)
+	resp, err := c.Report(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	points := resp.Points()
+
+	load := &APLoad{MAC: apMAC, Interval: interval}
+	for _, point := range points {
+		if v, ok := point.Float(ReportAttributeNumberSTA); ok {
+			load.NumberSTA = append(load.NumberSTA, APLoadPoint{Time: point.Time(), NumberSTA: v})
+		}
+	}
+	for _, radio := range device.RadioTableStats {
+		r := APRadioLoad{Radio: radio.Radio, Channel: radio.Channel}
+		if attrs, ok := apLoadRadioAttributes[radio.Radio]; ok {
+			for _, point := range points {
+				if p, ok := attrs.point(point); ok {
+					r.Points = append(r.Points, p)
+				}
+			}
+		}
+		load.Radios = append(load.Radios, r)
+	}
+	return load, nil
+}
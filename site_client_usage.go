@@ -0,0 +1,119 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ClientUsage is the historical traffic of a single client
+type ClientUsage struct {
+	MAC      string
+	Interval ReportInterval
+	Points   []UserStat // one point per interval bucket in time order, buckets without data are zero
+}
+
+// RXBytes returns the total bytes received by the client
+func (u *ClientUsage) RXBytes() float64 {
+	var total float64
+	for _, p := range u.Points {
+		total += p.RXBytes
+	}
+	return total
+}
+
+// TXBytes returns the total bytes sent by the client
+func (u *ClientUsage) TXBytes() float64 {
+	var total float64
+	for _, p := range u.Points {
+		total += p.TXBytes
+	}
+	return total
+}
+
+// Bytes returns the total bytes sent and received by the client
+func (u *ClientUsage) Bytes() float64 {
+	var total float64
+	for _, p := range u.Points {
+		total += p.Bytes
+	}
+	return total
+}
+
+// ClientUsage returns the traffic of the client for every interval bucket in [since, until)
+// site - the site to query
+// mac - the client mac
+// since - the start of the usage period
+// until - the end of the usage period
+// interval - the bucket size, one of 5minutes, hourly or daily
+// loc - the location the buckets are aligned in, e.g. the site timezone for daily buckets, nil aligns in UTC
+func (c *Client) ClientUsage(ctx context.Context, site string, mac string, since time.Time, until time.Time, interval ReportInterval, loc *time.Location) (*ClientUsage, error) {
+	bucket := interval.Duration()
+	if bucket == 0 {
+		return nil, fmt.Errorf("invalid interval specified: %s", interval)
+	}
+	if !since.Before(until) {
+		return nil, fmt.Errorf("invalid end time, must occur after start time")
+	}
+	mac = strings.ToLower(mac)
+
+	query := NewReportQuery(site).
+		Type(ReportTypeUser).
+		Interval(interval).
+		Attributes(ReportAttributeBytes, ReportAttributeRXBytes, ReportAttributeTXBytes, ReportAttributeTime).
+		MACs(mac).
+		Between(since, until).
+		In(loc).
+		Chunked(0)
+	resp, err := c.Report(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := resp.UserStats()
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &ClientUsage{MAC: mac, Interval: interval}
+	var starts []time.Time
+	for t := usageBucket(since, interval, loc); t.Before(until); t = nextUsageBucket(t, interval) {
+		starts = append(starts, t)
+		usage.Points = append(usage.Points, UserStat{
+			ReportStatBase: ReportStatBase{OID: mac, Origin: string(ReportTypeUser), Time: t.UnixNano() / int64(time.Millisecond)},
+			User:           mac,
+		})
+	}
+	// the controller may align buckets differently, e.g. daily buckets to its own timezone,
+	// so every point replaces the bucket it falls into rather than matching on the exact time
+	for _, stat := range stats {
+		ts := stat.Timestamp()
+		i := sort.Search(len(starts), func(i int) bool { return starts[i].After(ts) }) - 1
+		if i >= 0 {
+			usage.Points[i] = stat
+		}
+	}
+	return usage, nil
+}
+
+// usageBucket returns the start of the bucket containing t, daily buckets start at midnight in loc,
+// which is not a multiple of 24 hours across daylight saving time changes
+func usageBucket(t time.Time, interval ReportInterval, loc *time.Location) time.Time {
+	if interval != ReportIntervalDaily {
+		return alignTime(t, interval.Duration(), loc)
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// nextUsageBucket returns the start of the bucket following the bucket starting at t
+func nextUsageBucket(t time.Time, interval ReportInterval) time.Time {
+	if interval == ReportIntervalDaily {
+		return t.AddDate(0, 0, 1)
+	}
+	return t.Add(interval.Duration())
+}
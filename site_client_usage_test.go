@@ -0,0 +1,105 @@
+package unifi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newUsageController serves the user report with a point per time
+func newUsageController(t *testing.T, times ...time.Time) *Client {
+	points := make([]string, 0, len(times))
+	for i, ts := range times {
+		points = append(points, fmt.Sprintf(`{"time":%d,"user":"aa:bb:cc:dd:ee:ff","bytes":%d,"rx_bytes":%d,"tx_bytes":0}`,
+			ts.UnixNano()/int64(time.Millisecond), i+1, i+1))
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[` + strings.Join(points, ",") + `]}`))
+	}))
+	t.Cleanup(srv.Close)
+	c, err := NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetUniFiOS(false)
+	return c
+}
+
+func TestClientUsage(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, berlin) }
+	hour := func(h int) time.Time { return time.Date(2024, 1, 1, h, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name      string
+		interval  ReportInterval
+		loc       *time.Location
+		since     time.Time
+		until     time.Time
+		points    []time.Time
+		wantTimes []time.Time
+		wantBytes []float64
+	}{
+		{
+			name:      "hourly gaps are zero filled",
+			interval:  ReportIntervalHourly,
+			since:     hour(0).Add(30 * time.Minute),
+			until:     hour(4),
+			points:    []time.Time{hour(1), hour(3)},
+			wantTimes: []time.Time{hour(0), hour(1), hour(2), hour(3)},
+			wantBytes: []float64{0, 1, 0, 2},
+		},
+		{
+			// Berlin switches to summer time on 2024-03-31, the day has 23 hours
+			name:      "daily buckets across a daylight saving time change",
+			interval:  ReportIntervalDaily,
+			loc:       berlin,
+			since:     day(30).Add(5 * time.Hour),
+			until:     day(33),
+			points:    []time.Time{day(30), day(31), day(32)},
+			wantTimes: []time.Time{day(30), day(31), day(32)},
+			wantBytes: []float64{1, 2, 3},
+		},
+		{
+			name:      "daily points off midnight replace their day",
+			interval:  ReportIntervalDaily,
+			loc:       berlin,
+			since:     day(30),
+			until:     day(33),
+			points:    []time.Time{day(31).Add(time.Hour)},
+			wantTimes: []time.Time{day(30), day(31), day(32)},
+			wantBytes: []float64{0, 1, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newUsageController(t, tt.points...)
+			usage, err := c.ClientUsage(context.Background(), "default", "AA:BB:CC:DD:EE:FF", tt.since, tt.until, tt.interval, tt.loc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(usage.Points) != len(tt.wantTimes) {
+				t.Fatalf("got %d points, want %d", len(usage.Points), len(tt.wantTimes))
+			}
+			for i, p := range usage.Points {
+				if p.Bytes != tt.wantBytes[i] {
+					t.Errorf("point %d has %v bytes, want %v", i, p.Bytes, tt.wantBytes[i])
+				}
+				if p.Bytes == 0 && !p.Timestamp().Equal(tt.wantTimes[i]) {
+					t.Errorf("point %d is at %s, want %s", i, p.Timestamp(), tt.wantTimes[i])
+				}
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package unifi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -65,6 +66,8 @@ type SiteActiveClient struct {
 	Uptime                int64  `json:"uptime"`
 	UserID                string `json:"user_id"`
 	VLAN                  int    `json:"vlan"`
+
+	ClientFingerprint
 }
 
 // SiteActiveClientsResponse contains the active clients response
@@ -76,27 +79,42 @@ type SiteActiveClientsResponse struct {
 // SiteActiveClients will list active clients
 // site - the site to query
 // filterMac - filter to a specific mac, if zero-value, then no filter is applied
-func (c *Client) SiteActiveClients(site string, filterMac string) (*SiteActiveClientsResponse, error) {
+func (c *Client) SiteActiveClients(ctx context.Context, site string, filterMac string) (*SiteActiveClientsResponse, error) {
 	extPath := "stat/sta"
 	if filterMac != "" {
 		extPath = extPath + "/" + strings.ToLower(filterMac)
 	}
 
 	var resp SiteActiveClientsResponse
-	err := c.doSiteRequest(http.MethodGet, site, extPath, nil, &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, extPath, nil, &resp)
 	return &resp, err
 }
 
 // ClientDetails gets the details for a single client
 // site - the site to query
 // mac - the client mac to query
-func (c *Client) ClientDetails(site string, mac string) (*GenericResponse, error) {
+func (c *Client) ClientDetails(ctx context.Context, site string, mac string) (*GenericResponse, error) {
 	if mac == "" {
 		return nil, fmt.Errorf("must specify a client MAC")
 	}
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodGet, site, fmt.Sprintf("stat/user/%s", strings.ToLower(mac)), nil, &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, fmt.Sprintf("stat/user/%s", strings.ToLower(mac)), nil, &resp)
+	return &resp, err
+}
+
+// updateClient will apply a partial update to the user/client device
+func (c *Client) updateClient(ctx context.Context, site string, clientID string, payload map[string]interface{}) (*GenericResponse, error) {
+	if strings.TrimSpace(clientID) == "" {
+		return nil, fmt.Errorf("must specify the client ID")
+	}
+	payload["_id"] = strings.TrimSpace(strings.ToLower(clientID))
+	data, _ := json.Marshal(payload)
+
+	extPath := fmt.Sprintf("rest/user/%s", strings.TrimSpace(strings.ToLower(clientID)))
+
+	var resp GenericResponse
+	err := c.doSiteRequest(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
 	return &resp, err
 }
 
@@ -106,9 +124,8 @@ func (c *Client) ClientDetails(site string, mac string) (*GenericResponse, error
 // useFixedIP - true to set a fixedIP, false to unset
 // networkID - if useFixedIP set this to the specified value
 // fixedIP - if userFixedIP set this to the fixed IP specified
-func (c *Client) UpdateClientFixedIP(site string, clientID string, useFixedIP bool, networkID *string, fixedIP *string) (*GenericResponse, error) {
+func (c *Client) UpdateClientFixedIP(ctx context.Context, site string, clientID string, useFixedIP bool, networkID *string, fixedIP *string) (*GenericResponse, error) {
 	payload := map[string]interface{}{
-		"_id":         strings.TrimSpace(strings.ToLower(clientID)),
 		"use_fixedip": useFixedIP,
 	}
 	if useFixedIP {
@@ -119,12 +136,61 @@ func (c *Client) UpdateClientFixedIP(site string, clientID string, useFixedIP bo
 			payload["fixed_ip"] = *fixedIP
 		}
 	}
+	return c.updateClient(ctx, site, clientID, payload)
+}
 
-	data, _ := json.Marshal(payload)
+// SetClientFixedIP will assign a fixed IP (DHCP reservation) to a user/client device
+// site - the site to modify
+// clientID - the ID of the user/client device to be modified
+// networkID - the ID of the network the fixed IP belongs to
+// fixedIP - the fixed IP to assign
+func (c *Client) SetClientFixedIP(ctx context.Context, site string, clientID string, networkID string, fixedIP string) (*GenericResponse, error) {
+	if networkID == "" || fixedIP == "" {
+		return nil, fmt.Errorf("must specify the network ID and fixed IP")
+	}
+	return c.UpdateClientFixedIP(ctx, site, clientID, true, &networkID, &fixedIP)
+}
 
-	extPath := fmt.Sprintf("rest/user/%s", strings.TrimSpace(strings.ToLower(clientID)))
+// ClearClientFixedIP will remove the fixed IP of a user/client device
+// site - the site to modify
+// clientID - the ID of the user/client device to be modified
+func (c *Client) ClearClientFixedIP(ctx context.Context, site string, clientID string) (*GenericResponse, error) {
+	return c.UpdateClientFixedIP(ctx, site, clientID, false, nil, nil)
+}
 
-	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
-	return &resp, err
+// SetClientName will update the name of a user/client device
+// site - the site to modify
+// clientID - the ID of the user/client device to be modified
+// name - the name to set, when empty the existing name will be removed
+func (c *Client) SetClientName(ctx context.Context, site string, clientID string, name string) (*GenericResponse, error) {
+	payload := map[string]interface{}{
+		"name": name,
+	}
+	return c.updateClient(ctx, site, clientID, payload)
+}
+
+// SetClientNote will update the note of a user/client device
+// site - the site to modify
+// clientID - the ID of the user/client device to be modified
+// note - the note to set, when empty the existing note will be removed
+func (c *Client) SetClientNote(ctx context.Context, site string, clientID string, note string) (*GenericResponse, error) {
+	payload := map[string]interface{}{
+		"noted": note != "",
+		"note":  note,
+	}
+	return c.updateClient(ctx, site, clientID, payload)
+}
+
+// SetClientUserGroup will assign a user/client device to a user group
+// site - the site to modify
+// clientID - the ID of the user/client device to be modified
+// groupID - the ID of the user group
+func (c *Client) SetClientUserGroup(ctx context.Context, site string, clientID string, groupID string) (*GenericResponse, error) {
+	if groupID == "" {
+		return nil, fmt.Errorf("must specify the user group ID")
+	}
+	payload := map[string]interface{}{
+		"usergroup_id": groupID,
+	}
+	return c.updateClient(ctx, site, clientID, payload)
 }
@@ -0,0 +1,37 @@
+package unifi
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// CloneSite copies the networks, WLANs, firewall groups and rules, port profiles and user groups of a site to
+// another site, possibly on another controller. Objects are matched by name, existing objects are updated and
+// the references between the objects are remapped to the IDs of the destination site, see Apply.
+// Settings, WAN networks and VPN networks are not copied. AP groups, WLAN groups and RADIUS profiles are not copied either, cloned WLANs use
+// the defaults of the destination site.
+// src - the client of the source controller
+// srcSite - the site to copy
+// dst - the client of the destination controller, may be the same client as src
+// dstSite - the site to modify, it must exist, see CreateSite
+// opts - use DryRun to only compute the plan, Prune deletes destination objects missing from the source site
+func CloneSite(ctx context.Context, src *Client, srcSite string, dst *Client, dstSite string, opts ApplyOptions) (*ConfigPlan, error) {
+	cfg, err := src.Export(ctx, srcSite)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to export site %s", srcSite)
+	}
+	cfg.Site = dstSite
+	cfg.Settings = nil
+	for i := range cfg.WLANs {
+		cfg.WLANs[i].WLANGroupID = ""
+		cfg.WLANs[i].APGroupIDs = nil
+		cfg.WLANs[i].RADIUSProfileID = ""
+	}
+
+	plan, err := dst.Apply(ctx, dstSite, cfg, opts)
+	if err != nil {
+		return plan, errors.Wrapf(err, "unable to clone site %s to %s", srcSite, dstSite)
+	}
+	return plan, nil
+}
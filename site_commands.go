@@ -0,0 +1,92 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+//go:generate go run ./internal/commandgen -o site_commands_gen.go
+
+// CommandManager defines the controller manager a command is sent to, the cmd/<manager> endpoint
+type CommandManager string
+
+// The known command managers
+const (
+	CommandManagerBackup  CommandManager = "backup"
+	CommandManagerDevice  CommandManager = "devmgr"
+	CommandManagerEvent   CommandManager = "evtmgr"
+	CommandManagerHotspot CommandManager = "hotspot"
+	CommandManagerSite    CommandManager = "sitemgr"
+	CommandManagerStation CommandManager = "stamgr"
+	CommandManagerStat    CommandManager = "stat"
+	CommandManagerSystem  CommandManager = "system"
+)
+
+// IsValid returns true if it's one of the known command managers.
+// RunCommand also accepts other managers, e.g. `firmware` or `productinfo`
+func (m CommandManager) IsValid() bool {
+	switch m {
+	case CommandManagerBackup, CommandManagerDevice, CommandManagerEvent, CommandManagerHotspot:
+		fallthrough
+	case CommandManagerSite, CommandManagerStation, CommandManagerStat, CommandManagerSystem:
+		return true
+	default:
+		return false
+	}
+}
+
+// Command is a known controller command, see CommandCatalog
+type Command struct {
+	Manager CommandManager
+	Name    string
+	Params  []string // the required payload keys
+}
+
+// LookupCommand returns the catalog entry of the command
+// manager - the command manager
+// cmd - the command name, e.g. `kick-sta`
+func LookupCommand(manager CommandManager, cmd string) (Command, bool) {
+	for _, command := range CommandCatalog {
+		if command.Manager == manager && command.Name == cmd {
+			return command, true
+		}
+	}
+	return Command{}, false
+}
+
+// RunCommand sends a command to a controller manager, managers and commands missing from CommandCatalog are
+// sent as is so new or undocumented commands remain reachable.
+// site - the site to run the command on
+// manager - the command manager
+// cmd - the command name, e.g. `kick-sta`
+// payload - the command parameters, the `cmd` key is set from cmd
+func (c *Client) RunCommand(ctx context.Context, site string, manager CommandManager, cmd string, payload map[string]interface{}) (*GenericResponse, error) {
+	if manager == "" || strings.Contains(string(manager), "/") {
+		return nil, fmt.Errorf("invalid command manager specified: %s", manager)
+	}
+	if cmd == "" {
+		return nil, fmt.Errorf("must specify the command")
+	}
+	if command, ok := LookupCommand(manager, cmd); ok {
+		for _, key := range command.Params {
+			if _, ok := payload[key]; !ok {
+				return nil, fmt.Errorf("command %s/%s requires parameters: %s", manager, cmd, strings.Join(command.Params, ", "))
+			}
+		}
+	}
+
+	body := make(map[string]interface{}, len(payload)+1)
+	for k, v := range payload {
+		body[k] = v
+	}
+	body["cmd"] = cmd
+	data, _ := json.Marshal(body)
+
+	var resp GenericResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, fmt.Sprintf("cmd/%s", manager), bytes.NewReader(data), &resp)
+	return &resp, err
+}
@@ -0,0 +1,338 @@
+// Code generated by internal/commandgen; DO NOT EDIT.
+
+package unifi
+
+import (
+	"context"
+)
+
+// CommandCatalog contains the known controller commands, see RunCommand
+var CommandCatalog = []Command{
+	{Manager: CommandManagerBackup, Name: "delete-backup", Params: []string{"filename"}},
+	{Manager: CommandManagerBackup, Name: "list-backups", Params: []string{}},
+	{Manager: CommandManagerDevice, Name: "adopt", Params: []string{"mac"}},
+	{Manager: CommandManagerDevice, Name: "cancel-migrate", Params: []string{"mac"}},
+	{Manager: CommandManagerDevice, Name: "cancel-rolling-upgrade", Params: []string{}},
+	{Manager: CommandManagerDevice, Name: "force-provision", Params: []string{"mac"}},
+	{Manager: CommandManagerDevice, Name: "migrate", Params: []string{"mac", "inform_url"}},
+	{Manager: CommandManagerDevice, Name: "power-cycle", Params: []string{"mac", "port_idx"}},
+	{Manager: CommandManagerDevice, Name: "restart", Params: []string{"mac"}},
+	{Manager: CommandManagerDevice, Name: "set-locate", Params: []string{"mac"}},
+	{Manager: CommandManagerDevice, Name: "set-rollupgrade", Params: []string{}},
+	{Manager: CommandManagerDevice, Name: "spectrum-scan", Params: []string{"mac"}},
+	{Manager: CommandManagerDevice, Name: "speedtest", Params: []string{}},
+	{Manager: CommandManagerDevice, Name: "speedtest-status", Params: []string{}},
+	{Manager: CommandManagerDevice, Name: "unset-locate", Params: []string{"mac"}},
+	{Manager: CommandManagerDevice, Name: "upgrade", Params: []string{"mac"}},
+	{Manager: CommandManagerDevice, Name: "upgrade-external", Params: []string{"mac", "url"}},
+	{Manager: CommandManagerEvent, Name: "archive-alarm", Params: []string{"_id"}},
+	{Manager: CommandManagerEvent, Name: "archive-all-alarms", Params: []string{}},
+	{Manager: CommandManagerHotspot, Name: "create-voucher", Params: []string{"n", "expire", "quota"}},
+	{Manager: CommandManagerHotspot, Name: "delete-voucher", Params: []string{"_id"}},
+	{Manager: CommandManagerHotspot, Name: "extend", Params: []string{"_id"}},
+	{Manager: CommandManagerSite, Name: "delete-device", Params: []string{"mac"}},
+	{Manager: CommandManagerSite, Name: "delete-site", Params: []string{"site"}},
+	{Manager: CommandManagerSite, Name: "get-admins", Params: []string{}},
+	{Manager: CommandManagerSite, Name: "move-device", Params: []string{"mac", "site"}},
+	{Manager: CommandManagerStation, Name: "authorize-guest", Params: []string{"mac", "minutes"}},
+	{Manager: CommandManagerStation, Name: "block-sta", Params: []string{"mac"}},
+	{Manager: CommandManagerStation, Name: "forget-sta", Params: []string{"macs"}},
+	{Manager: CommandManagerStation, Name: "kick-sta", Params: []string{"mac"}},
+	{Manager: CommandManagerStation, Name: "unauthorize-guest", Params: []string{"mac"}},
+	{Manager: CommandManagerStation, Name: "unblock-sta", Params: []string{"mac"}},
+	{Manager: CommandManagerStat, Name: "clear-dpi", Params: []string{}},
+	{Manager: CommandManagerSystem, Name: "backup", Params: []string{}},
+}
+
+// BackupMgrDeleteBackup runs the backup delete-backup command, it deletes a controller backup file
+// site - the site to run the command on
+// filename - the filename parameter
+func (c *Client) BackupMgrDeleteBackup(ctx context.Context, site string, filename string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerBackup, "delete-backup", map[string]interface{}{
+		"filename": filename,
+	})
+}
+
+// BackupMgrListBackups runs the backup list-backups command, it lists the controller backup files
+// site - the site to run the command on
+func (c *Client) BackupMgrListBackups(ctx context.Context, site string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerBackup, "list-backups", map[string]interface{}{})
+}
+
+// DevMgrAdopt runs the devmgr adopt command, it adopts the device
+// site - the site to run the command on
+// mac - the mac parameter
+func (c *Client) DevMgrAdopt(ctx context.Context, site string, mac string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerDevice, "adopt", map[string]interface{}{
+		"mac": mac,
+	})
+}
+
+// DevMgrCancelMigrate runs the devmgr cancel-migrate command, it cancels a pending device migration
+// site - the site to run the command on
+// mac - the mac parameter
+func (c *Client) DevMgrCancelMigrate(ctx context.Context, site string, mac string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerDevice, "cancel-migrate", map[string]interface{}{
+		"mac": mac,
+	})
+}
+
+// DevMgrCancelRollingUpgrade runs the devmgr cancel-rolling-upgrade command, it cancels the rolling upgrade of the site
+// site - the site to run the command on
+func (c *Client) DevMgrCancelRollingUpgrade(ctx context.Context, site string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerDevice, "cancel-rolling-upgrade", map[string]interface{}{})
+}
+
+// DevMgrForceProvision runs the devmgr force-provision command, it force provisions the device
+// site - the site to run the command on
+// mac - the mac parameter
+func (c *Client) DevMgrForceProvision(ctx context.Context, site string, mac string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerDevice, "force-provision", map[string]interface{}{
+		"mac": mac,
+	})
+}
+
+// DevMgrMigrate runs the devmgr migrate command, it migrates the device to another controller
+// site - the site to run the command on
+// mac - the mac parameter
+// informURL - the inform_url parameter
+func (c *Client) DevMgrMigrate(ctx context.Context, site string, mac string, informURL string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerDevice, "migrate", map[string]interface{}{
+		"mac":        mac,
+		"inform_url": informURL,
+	})
+}
+
+// DevMgrPowerCycle runs the devmgr power-cycle command, it power cycles the PoE port of the switch
+// site - the site to run the command on
+// mac - the mac parameter
+// portIdx - the port_idx parameter
+func (c *Client) DevMgrPowerCycle(ctx context.Context, site string, mac string, portIdx int) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerDevice, "power-cycle", map[string]interface{}{
+		"mac":      mac,
+		"port_idx": portIdx,
+	})
+}
+
+// DevMgrRestart runs the devmgr restart command, it restarts the device
+// site - the site to run the command on
+// mac - the mac parameter
+func (c *Client) DevMgrRestart(ctx context.Context, site string, mac string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerDevice, "restart", map[string]interface{}{
+		"mac": mac,
+	})
+}
+
+// DevMgrSetLocate runs the devmgr set-locate command, it starts flashing the device LED
+// site - the site to run the command on
+// mac - the mac parameter
+func (c *Client) DevMgrSetLocate(ctx context.Context, site string, mac string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerDevice, "set-locate", map[string]interface{}{
+		"mac": mac,
+	})
+}
+
+// DevMgrSetRollupgrade runs the devmgr set-rollupgrade command, it starts a rolling upgrade of the site access points
+// site - the site to run the command on
+func (c *Client) DevMgrSetRollupgrade(ctx context.Context, site string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerDevice, "set-rollupgrade", map[string]interface{}{})
+}
+
+// DevMgrSpectrumScan runs the devmgr spectrum-scan command, it starts a spectrum scan on the access point
+// site - the site to run the command on
+// mac - the mac parameter
+func (c *Client) DevMgrSpectrumScan(ctx context.Context, site string, mac string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerDevice, "spectrum-scan", map[string]interface{}{
+		"mac": mac,
+	})
+}
+
+// DevMgrSpeedtest runs the devmgr speedtest command, it starts a speed test on the gateway
+// site - the site to run the command on
+func (c *Client) DevMgrSpeedtest(ctx context.Context, site string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerDevice, "speedtest", map[string]interface{}{})
+}
+
+// DevMgrSpeedtestStatus runs the devmgr speedtest-status command, it returns the status of the running speed test
+// site - the site to run the command on
+func (c *Client) DevMgrSpeedtestStatus(ctx context.Context, site string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerDevice, "speedtest-status", map[string]interface{}{})
+}
+
+// DevMgrUnsetLocate runs the devmgr unset-locate command, it stops flashing the device LED
+// site - the site to run the command on
+// mac - the mac parameter
+func (c *Client) DevMgrUnsetLocate(ctx context.Context, site string, mac string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerDevice, "unset-locate", map[string]interface{}{
+		"mac": mac,
+	})
+}
+
+// DevMgrUpgrade runs the devmgr upgrade command, it upgrades the device to the latest firmware
+// site - the site to run the command on
+// mac - the mac parameter
+func (c *Client) DevMgrUpgrade(ctx context.Context, site string, mac string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerDevice, "upgrade", map[string]interface{}{
+		"mac": mac,
+	})
+}
+
+// DevMgrUpgradeExternal runs the devmgr upgrade-external command, it upgrades the device to the firmware at the url
+// site - the site to run the command on
+// mac - the mac parameter
+// url - the url parameter
+func (c *Client) DevMgrUpgradeExternal(ctx context.Context, site string, mac string, url string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerDevice, "upgrade-external", map[string]interface{}{
+		"mac": mac,
+		"url": url,
+	})
+}
+
+// EvtMgrArchiveAlarm runs the evtmgr archive-alarm command, it archives the alarm
+// site - the site to run the command on
+// id - the _id parameter
+func (c *Client) EvtMgrArchiveAlarm(ctx context.Context, site string, id string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerEvent, "archive-alarm", map[string]interface{}{
+		"_id": id,
+	})
+}
+
+// EvtMgrArchiveAllAlarms runs the evtmgr archive-all-alarms command, it archives all alarms of the site
+// site - the site to run the command on
+func (c *Client) EvtMgrArchiveAllAlarms(ctx context.Context, site string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerEvent, "archive-all-alarms", map[string]interface{}{})
+}
+
+// HotspotCreateVoucher runs the hotspot create-voucher command, it creates hotspot vouchers
+// site - the site to run the command on
+// count - the n parameter
+// expireMinutes - the expire parameter
+// quota - the quota parameter
+func (c *Client) HotspotCreateVoucher(ctx context.Context, site string, count int, expireMinutes int, quota int) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerHotspot, "create-voucher", map[string]interface{}{
+		"n":      count,
+		"expire": expireMinutes,
+		"quota":  quota,
+	})
+}
+
+// HotspotDeleteVoucher runs the hotspot delete-voucher command, it deletes the hotspot voucher
+// site - the site to run the command on
+// id - the _id parameter
+func (c *Client) HotspotDeleteVoucher(ctx context.Context, site string, id string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerHotspot, "delete-voucher", map[string]interface{}{
+		"_id": id,
+	})
+}
+
+// HotspotExtend runs the hotspot extend command, it extends the guest authorization
+// site - the site to run the command on
+// id - the _id parameter
+func (c *Client) HotspotExtend(ctx context.Context, site string, id string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerHotspot, "extend", map[string]interface{}{
+		"_id": id,
+	})
+}
+
+// SiteMgrDeleteDevice runs the sitemgr delete-device command, it forgets the device
+// site - the site to run the command on
+// mac - the mac parameter
+func (c *Client) SiteMgrDeleteDevice(ctx context.Context, site string, mac string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerSite, "delete-device", map[string]interface{}{
+		"mac": mac,
+	})
+}
+
+// SiteMgrDeleteSite runs the sitemgr delete-site command, it deletes the site
+// site - the site to run the command on
+// targetSite - the site parameter
+func (c *Client) SiteMgrDeleteSite(ctx context.Context, site string, targetSite string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerSite, "delete-site", map[string]interface{}{
+		"site": targetSite,
+	})
+}
+
+// SiteMgrGetAdmins runs the sitemgr get-admins command, it lists the admins of the site
+// site - the site to run the command on
+func (c *Client) SiteMgrGetAdmins(ctx context.Context, site string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerSite, "get-admins", map[string]interface{}{})
+}
+
+// SiteMgrMoveDevice runs the sitemgr move-device command, it moves the device to another site
+// site - the site to run the command on
+// mac - the mac parameter
+// targetSite - the site parameter
+func (c *Client) SiteMgrMoveDevice(ctx context.Context, site string, mac string, targetSite string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerSite, "move-device", map[string]interface{}{
+		"mac":  mac,
+		"site": targetSite,
+	})
+}
+
+// StaMgrAuthorizeGuest runs the stamgr authorize-guest command, it authorizes the guest
+// site - the site to run the command on
+// mac - the mac parameter
+// minutes - the minutes parameter
+func (c *Client) StaMgrAuthorizeGuest(ctx context.Context, site string, mac string, minutes int) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerStation, "authorize-guest", map[string]interface{}{
+		"mac":     mac,
+		"minutes": minutes,
+	})
+}
+
+// StaMgrBlockSta runs the stamgr block-sta command, it blocks the client
+// site - the site to run the command on
+// mac - the mac parameter
+func (c *Client) StaMgrBlockSta(ctx context.Context, site string, mac string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerStation, "block-sta", map[string]interface{}{
+		"mac": mac,
+	})
+}
+
+// StaMgrForgetSta runs the stamgr forget-sta command, it forgets the clients
+// site - the site to run the command on
+// macs - the macs parameter
+func (c *Client) StaMgrForgetSta(ctx context.Context, site string, macs []string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerStation, "forget-sta", map[string]interface{}{
+		"macs": macs,
+	})
+}
+
+// StaMgrKickSta runs the stamgr kick-sta command, it disconnects the client
+// site - the site to run the command on
+// mac - the mac parameter
+func (c *Client) StaMgrKickSta(ctx context.Context, site string, mac string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerStation, "kick-sta", map[string]interface{}{
+		"mac": mac,
+	})
+}
+
+// StaMgrUnauthorizeGuest runs the stamgr unauthorize-guest command, it unauthorizes the guest
+// site - the site to run the command on
+// mac - the mac parameter
+func (c *Client) StaMgrUnauthorizeGuest(ctx context.Context, site string, mac string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerStation, "unauthorize-guest", map[string]interface{}{
+		"mac": mac,
+	})
+}
+
+// StaMgrUnblockSta runs the stamgr unblock-sta command, it unblocks the client
+// site - the site to run the command on
+// mac - the mac parameter
+func (c *Client) StaMgrUnblockSta(ctx context.Context, site string, mac string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerStation, "unblock-sta", map[string]interface{}{
+		"mac": mac,
+	})
+}
+
+// StatClearDpi runs the stat clear-dpi command, it resets the DPI counters of the site
+// site - the site to run the command on
+func (c *Client) StatClearDpi(ctx context.Context, site string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerStat, "clear-dpi", map[string]interface{}{})
+}
+
+// SystemBackup runs the system backup command, it creates a controller backup
+// site - the site to run the command on
+func (c *Client) SystemBackup(ctx context.Context, site string) (*GenericResponse, error) {
+	return c.RunCommand(ctx, site, CommandManagerSystem, "backup", map[string]interface{}{})
+}
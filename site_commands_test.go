@@ -0,0 +1,58 @@
+package unifi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRunCommand(t *testing.T) {
+	var gotPath, gotCmd string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		gotPath = r.URL.Path
+		gotCmd, _ = body["cmd"].(string)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer srv.Close()
+	c, err := NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetUniFiOS(false)
+
+	tests := []struct {
+		name     string
+		manager  CommandManager
+		cmd      string
+		payload  map[string]interface{}
+		wantErr  bool
+		wantPath string
+	}{
+		{"known command", CommandManagerStation, "kick-sta", map[string]interface{}{"mac": "aa:bb:cc:dd:ee:ff"}, false, "/api/s/default/cmd/stamgr"},
+		{"missing parameter", CommandManagerStation, "kick-sta", nil, true, ""},
+		{"unknown manager", CommandManager("productinfo"), "check-firmware-update", nil, false, "/api/s/default/cmd/productinfo"},
+		{"empty manager", CommandManager(""), "x", nil, true, ""},
+		{"manager with a slash", CommandManager("../stat"), "x", nil, true, ""},
+		{"empty command", CommandManagerSystem, "", nil, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPath, gotCmd = "", ""
+			_, err := c.RunCommand(context.Background(), "default", tt.manager, tt.cmd, tt.payload)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RunCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotPath != tt.wantPath || gotCmd != tt.cmd {
+				t.Errorf("sent %s to %s, want %s to %s", gotCmd, gotPath, tt.cmd, tt.wantPath)
+			}
+		})
+	}
+}
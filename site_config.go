@@ -0,0 +1,188 @@
+package unifi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// SiteConfigVersion is the version of the SiteConfig document format
+const SiteConfigVersion = 1
+
+// SiteConfig is a declarative document of the configuration of a site, see Export.
+// It marshals to JSON and YAML with the controller field names. Secrets like WLAN passphrases are included
+// as the controller returns them, store the document accordingly.
+type SiteConfig struct {
+	Version        int                             `json:"version"`
+	Site           string                          `json:"site"`
+	ExportedAt     time.Time                       `json:"exported_at"`
+	Networks       []Network                       `json:"networks"` // the LAN networks, see Export
+	WLANs          []WLANConf                      `json:"wlans"`
+	FirewallGroups []FirewallGroup                 `json:"firewall_groups"`
+	FirewallRules  []FirewallRule                  `json:"firewall_rules"`
+	PortProfiles   []PortProfile                   `json:"port_profiles"`
+	UserGroups     []UserGroup                     `json:"user_groups"`
+	Settings       map[string]SiteDetailedSettings `json:"settings"` // keyed by the setting section key, e.g. `mgmt`
+}
+
+// Export reads the networks, WLANs, firewall groups and rules, port profiles, user groups and settings of the site
+// into a single document, the entries are sorted by name and the firewall rules by ruleset and rule index so
+// exports of an unchanged site are identical.
+// Only the corporate, guest and vlan-only networks are exported, WAN and VPN networks carry wan_*, ipsec and
+// x_* settings that Network does not model, so they could not be applied or cloned without losing them.
+// site - the site to export
+func (c *Client) Export(ctx context.Context, site string) (*SiteConfig, error) {
+	cfg := &SiteConfig{
+		Version:    SiteConfigVersion,
+		Site:       site,
+		ExportedAt: time.Now().UTC(),
+		Settings:   make(map[string]SiteDetailedSettings),
+	}
+
+	networks, err := c.ListNetworks(ctx, site)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to export networks")
+	}
+	for _, n := range networks.Data {
+		if isLANNetwork(n.Purpose) {
+			cfg.Networks = append(cfg.Networks, n)
+		}
+	}
+	sort.SliceStable(cfg.Networks, func(i, j int) bool {
+		return cfg.Networks[i].Name < cfg.Networks[j].Name
+	})
+
+	wlans, err := c.ListWLANs(ctx, site)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to export wlans")
+	}
+	cfg.WLANs = wlans.Data
+	sort.SliceStable(cfg.WLANs, func(i, j int) bool {
+		return cfg.WLANs[i].Name < cfg.WLANs[j].Name
+	})
+
+	groups, err := c.ListFirewallGroups(ctx, site)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to export firewall groups")
+	}
+	cfg.FirewallGroups = groups.Data
+	sort.SliceStable(cfg.FirewallGroups, func(i, j int) bool {
+		return cfg.FirewallGroups[i].Name < cfg.FirewallGroups[j].Name
+	})
+
+	rules, err := c.ListFirewallRules(ctx, site)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to export firewall rules")
+	}
+	cfg.FirewallRules = rules.Data
+	sort.SliceStable(cfg.FirewallRules, func(i, j int) bool {
+		if cfg.FirewallRules[i].Ruleset != cfg.FirewallRules[j].Ruleset {
+			return cfg.FirewallRules[i].Ruleset < cfg.FirewallRules[j].Ruleset
+		}
+		return cfg.FirewallRules[i].RuleIndex < cfg.FirewallRules[j].RuleIndex
+	})
+
+	profiles, err := c.ListPortProfiles(ctx, site)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to export port profiles")
+	}
+	cfg.PortProfiles = profiles.Data
+	sort.SliceStable(cfg.PortProfiles, func(i, j int) bool {
+		return cfg.PortProfiles[i].Name < cfg.PortProfiles[j].Name
+	})
+
+	userGroups, err := c.ListUserGroups(ctx, site)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to export user groups")
+	}
+	cfg.UserGroups = userGroups.Data
+	sort.SliceStable(cfg.UserGroups, func(i, j int) bool {
+		return cfg.UserGroups[i].Name < cfg.UserGroups[j].Name
+	})
+
+	settings, err := c.SiteDetailedSettings(ctx, site)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to export settings")
+	}
+	for _, s := range settings.Data {
+		if key := s.SettingKey(); key != "" {
+			cfg.Settings[key] = s
+		}
+	}
+	return cfg, nil
+}
+
+// MarshalYAML marshals the document with the same field names and order as the JSON encoding
+func (s SiteConfig) MarshalYAML() (interface{}, error) {
+	type plain SiteConfig
+	data, err := json.Marshal(plain(s))
+	if err != nil {
+		return nil, err
+	}
+	// JSON is valid YAML, decoding into a MapSlice keeps the field order
+	var doc yaml.MapSlice
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// UnmarshalYAML unmarshals the document using the JSON field names
+func (s *SiteConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var doc interface{}
+	if err := unmarshal(&doc); err != nil {
+		return err
+	}
+	data, err := json.Marshal(yamlToJSON(doc))
+	if err != nil {
+		return err
+	}
+	type plain SiteConfig
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// ParseSiteConfig parses a document written as JSON or YAML, see Export
+func ParseSiteConfig(data []byte) (*SiteConfig, error) {
+	var cfg SiteConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, errors.Wrap(err, "unable to parse site config")
+	}
+	if cfg.Version > SiteConfigVersion {
+		return nil, fmt.Errorf("unsupported site config version: %d", cfg.Version)
+	}
+	return &cfg, nil
+}
+
+// yamlToJSON converts the map[interface{}]interface{} maps decoded by yaml into maps encoding/json supports
+func yamlToJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			m[fmt.Sprint(k)] = yamlToJSON(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range t {
+			t[i] = yamlToJSON(val)
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+// isLANNetwork returns true for the network purposes fully described by Network
+func isLANNetwork(purpose NetworkPurpose) bool {
+	switch purpose {
+	case NetworkPurposeCorporate, NetworkPurposeGuest, NetworkPurposeVLANOnly:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,410 @@
+package unifi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ConfigAction defines the action of a configuration change
+type ConfigAction string
+
+// The configuration change actions
+const (
+	ConfigActionCreate ConfigAction = "create"
+	ConfigActionUpdate ConfigAction = "update"
+	ConfigActionDelete ConfigAction = "delete"
+)
+
+// ConfigChange is a single change required to reach the desired configuration
+type ConfigChange struct {
+	Action ConfigAction `json:"action"`
+	Kind   string       `json:"kind"` // network, wlan, firewall_group, firewall_rule, port_profile, user_group or setting
+	Name   string       `json:"name"`
+	ID     string       `json:"id,omitempty"`     // the ID of the live object, empty for creates
+	Fields []string     `json:"fields,omitempty"` // the changed fields of updates
+}
+
+// String returns a readable form of the change, e.g. `update network LAN (dhcpd_start, vlan)`
+func (ch ConfigChange) String() string {
+	s := fmt.Sprintf("%s %s %s", ch.Action, ch.Kind, ch.Name)
+	if len(ch.Fields) > 0 {
+		s += " (" + strings.Join(ch.Fields, ", ") + ")"
+	}
+	return s
+}
+
+// ConfigPlan is the list of changes required to reach the desired configuration, in the order they are applied
+type ConfigPlan struct {
+	Site    string         `json:"site"`
+	Changes []ConfigChange `json:"changes"`
+}
+
+// Empty returns true if the site already matches the desired configuration
+func (p *ConfigPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String returns the changes one per line
+func (p *ConfigPlan) String() string {
+	lines := make([]string, 0, len(p.Changes))
+	for _, ch := range p.Changes {
+		lines = append(lines, ch.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ApplyOptions configures Apply
+type ApplyOptions struct {
+	DryRun bool // only compute the plan, do not change the site
+	Prune  bool // delete live objects missing from the desired configuration, settings are never deleted
+}
+
+// configKind describes how to sync the objects of a single rest endpoint
+type configKind[T any] struct {
+	kind   string
+	path   string
+	id     func(*T) *string
+	siteID func(*T) *string
+	name   func(*T) string
+	remap  func(*T, func(string) string) // rewrites the IDs of referenced objects
+	keep   func(*T) bool                 // built-in objects that are never pruned
+}
+
+// pendingDelete is a prune deferred until all creates and updates are applied
+type pendingDelete struct {
+	change ConfigChange
+	path   string
+}
+
+// configSync carries the state of a single Apply
+type configSync struct {
+	c       *Client
+	site    string
+	opts    ApplyOptions
+	plan    *ConfigPlan
+	ids     map[string]string // desired object IDs to live object IDs
+	deletes [][]pendingDelete // per kind, in apply order
+}
+
+// lookup returns the live ID of the referenced desired object ID
+func (s *configSync) lookup(id string) string {
+	if live, ok := s.ids[id]; ok {
+		return live
+	}
+	return id
+}
+
+// lookupConfigIDs returns the live IDs of the referenced desired object IDs
+func lookupConfigIDs(ids []string, lookup func(string) string) []string {
+	if ids == nil {
+		return nil
+	}
+	ret := make([]string, len(ids))
+	for i, id := range ids {
+		ret[i] = lookup(id)
+	}
+	return ret
+}
+
+// Apply changes the site to match the desired configuration, see Export, and returns the applied changes.
+// Objects are matched by ID and then by name, references between the objects of the document are remapped
+// to the live IDs so a document exported from another site can be applied. Creates and updates are applied
+// in dependency order before any deletes. On error the returned plan contains the changes applied so far.
+// site - the site to modify
+// desired - the desired configuration, sections left empty are not changed unless Prune is set, WAN and VPN
+// networks are not supported
+// opts - use DryRun to only compute the plan
+func (c *Client) Apply(ctx context.Context, site string, desired *SiteConfig, opts ApplyOptions) (*ConfigPlan, error) {
+	for _, n := range desired.Networks {
+		if !isLANNetwork(n.Purpose) {
+			return nil, fmt.Errorf("invalid network purpose specified: %s (%s)", n.Purpose, n.Name)
+		}
+	}
+	live, err := c.Export(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	s := &configSync{c: c, site: site, opts: opts, plan: &ConfigPlan{Site: site}, ids: make(map[string]string)}
+
+	err = syncConfig(ctx, s, configKind[UserGroup]{
+		kind: "user_group", path: "rest/usergroup",
+		id:     func(o *UserGroup) *string { return &o.ID },
+		siteID: func(o *UserGroup) *string { return &o.SiteID },
+		name:   func(o *UserGroup) string { return o.Name },
+		keep:   func(o *UserGroup) bool { return isBuiltInConfig(o.AttrNoDelete, o.AttrHiddenID) },
+	}, desired.UserGroups, live.UserGroups)
+	if err != nil {
+		return s.plan, err
+	}
+	err = syncConfig(ctx, s, configKind[FirewallGroup]{
+		kind: "firewall_group", path: "rest/firewallgroup",
+		id:     func(o *FirewallGroup) *string { return &o.ID },
+		siteID: func(o *FirewallGroup) *string { return &o.SiteID },
+		name:   func(o *FirewallGroup) string { return o.Name },
+	}, desired.FirewallGroups, live.FirewallGroups)
+	if err != nil {
+		return s.plan, err
+	}
+	err = syncConfig(ctx, s, configKind[Network]{
+		kind: "network", path: "rest/networkconf",
+		id:     func(o *Network) *string { return &o.ID },
+		siteID: func(o *Network) *string { return &o.SiteID },
+		name:   func(o *Network) string { return o.Name },
+		keep: func(o *Network) bool {
+			return isBuiltInConfig(o.AttrNoDelete, o.AttrHiddenID) || o.Purpose == NetworkPurposeWAN
+		},
+	}, desired.Networks, live.Networks)
+	if err != nil {
+		return s.plan, err
+	}
+	err = syncConfig(ctx, s, configKind[PortProfile]{
+		kind: "port_profile", path: "rest/portconf",
+		id:     func(o *PortProfile) *string { return &o.ID },
+		siteID: func(o *PortProfile) *string { return &o.SiteID },
+		name:   func(o *PortProfile) string { return o.Name },
+		keep:   func(o *PortProfile) bool { return isBuiltInConfig(o.AttrNoDelete, o.AttrHiddenID) },
+		remap: func(o *PortProfile, lookup func(string) string) {
+			o.NativeNetworkConfID = lookup(o.NativeNetworkConfID)
+			o.VoiceNetworkConfID = lookup(o.VoiceNetworkConfID)
+			o.TaggedNetworkConfIDs = lookupConfigIDs(o.TaggedNetworkConfIDs, lookup)
+		},
+	}, desired.PortProfiles, live.PortProfiles)
+	if err != nil {
+		return s.plan, err
+	}
+	err = syncConfig(ctx, s, configKind[WLANConf]{
+		kind: "wlan", path: "rest/wlanconf",
+		id:     func(o *WLANConf) *string { return &o.ID },
+		siteID: func(o *WLANConf) *string { return &o.SiteID },
+		name:   func(o *WLANConf) string { return o.Name },
+		keep:   func(o *WLANConf) bool { return isBuiltInConfig(o.AttrNoDelete, o.AttrHiddenID) },
+		remap: func(o *WLANConf, lookup func(string) string) {
+			o.NetworkConfID = lookup(o.NetworkConfID)
+			o.UserGroupID = lookup(o.UserGroupID)
+		},
+	}, desired.WLANs, live.WLANs)
+	if err != nil {
+		return s.plan, err
+	}
+	err = syncConfig(ctx, s, configKind[FirewallRule]{
+		kind: "firewall_rule", path: "rest/firewallrule",
+		id:     func(o *FirewallRule) *string { return &o.ID },
+		siteID: func(o *FirewallRule) *string { return &o.SiteID },
+		name:   func(o *FirewallRule) string { return o.Name },
+		keep:   func(o *FirewallRule) bool { return isBuiltInConfig(o.AttrNoDelete, o.AttrHiddenID) },
+		remap: func(o *FirewallRule, lookup func(string) string) {
+			o.SourceFirewallGroupIDs = lookupConfigIDs(o.SourceFirewallGroupIDs, lookup)
+			o.DestinationFirewallGroupIDs = lookupConfigIDs(o.DestinationFirewallGroupIDs, lookup)
+			o.SourceNetworkConfID = lookup(o.SourceNetworkConfID)
+			o.DestinationNetworkConfID = lookup(o.DestinationNetworkConfID)
+		},
+	}, desired.FirewallRules, live.FirewallRules)
+	if err != nil {
+		return s.plan, err
+	}
+	if err := s.syncSettings(ctx, desired.Settings, live.Settings); err != nil {
+		return s.plan, err
+	}
+
+	// delete in reverse dependency order, e.g. firewall rules before the groups they reference
+	for i := len(s.deletes) - 1; i >= 0; i-- {
+		for _, d := range s.deletes[i] {
+			if !opts.DryRun {
+				err := c.doSiteRequest(ctx, http.MethodDelete, site, path.Join(d.path, d.change.ID), nil, nil)
+				if err != nil {
+					return s.plan, errors.Wrapf(err, "unable to %s", d.change)
+				}
+			}
+			s.plan.Changes = append(s.plan.Changes, d.change)
+		}
+	}
+	return s.plan, nil
+}
+
+// syncConfig creates and updates the objects of a single kind and queues the prunes
+func syncConfig[T any](ctx context.Context, s *configSync, k configKind[T], desired []T, live []T) error {
+	byID := make(map[string]*T, len(live))
+	byName := make(map[string]*T, len(live))
+	for i := range live {
+		l := &live[i]
+		byID[*k.id(l)] = l
+		if _, ok := byName[k.name(l)]; !ok {
+			byName[k.name(l)] = l
+		}
+	}
+	matched := make(map[string]bool, len(live))
+
+	for _, d := range desired {
+		desiredID := *k.id(&d)
+		l := byID[desiredID]
+		if l == nil || desiredID == "" {
+			l = byName[k.name(&d)]
+		}
+		if l != nil && matched[*k.id(l)] {
+			l = nil
+		}
+		if k.remap != nil {
+			k.remap(&d, s.lookup)
+		}
+
+		if l != nil {
+			liveID := *k.id(l)
+			matched[liveID] = true
+			if desiredID != "" {
+				s.ids[desiredID] = liveID
+			}
+			*k.id(&d), *k.siteID(&d) = liveID, *k.siteID(l)
+			fields, err := diffConfigFields(d, *l)
+			if err != nil {
+				return err
+			}
+			if len(fields) == 0 {
+				continue
+			}
+			change := ConfigChange{Action: ConfigActionUpdate, Kind: k.kind, Name: k.name(&d), ID: liveID, Fields: fields}
+			if !s.opts.DryRun {
+				if _, err := Do[T](ctx, s.c, http.MethodPut, s.site, path.Join(k.path, liveID), d); err != nil {
+					return errors.Wrapf(err, "unable to %s", change)
+				}
+			}
+			s.plan.Changes = append(s.plan.Changes, change)
+			continue
+		}
+
+		*k.id(&d), *k.siteID(&d) = "", ""
+		change := ConfigChange{Action: ConfigActionCreate, Kind: k.kind, Name: k.name(&d)}
+		if !s.opts.DryRun {
+			resp, err := Do[T](ctx, s.c, http.MethodPost, s.site, k.path, d)
+			if err != nil {
+				return errors.Wrapf(err, "unable to %s", change)
+			}
+			if created, ok := resp.First(); ok && desiredID != "" {
+				s.ids[desiredID] = *k.id(&created)
+			}
+		}
+		s.plan.Changes = append(s.plan.Changes, change)
+	}
+
+	var deletes []pendingDelete
+	if s.opts.Prune {
+		for i := range live {
+			l := &live[i]
+			if matched[*k.id(l)] || (k.keep != nil && k.keep(l)) {
+				continue
+			}
+			deletes = append(deletes, pendingDelete{
+				change: ConfigChange{Action: ConfigActionDelete, Kind: k.kind, Name: k.name(l), ID: *k.id(l)},
+				path:   k.path,
+			})
+		}
+	}
+	s.deletes = append(s.deletes, deletes)
+	return nil
+}
+
+// syncSettings updates the setting sections, only the fields present in the desired sections are compared
+func (s *configSync) syncSettings(ctx context.Context, desired map[string]SiteDetailedSettings, live map[string]SiteDetailedSettings) error {
+	keys := make([]string, 0, len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		l, ok := live[key]
+		if !ok {
+			return fmt.Errorf("unknown setting section: %s", key)
+		}
+		id, _ := l["_id"].(string)
+
+		update := make(map[string]interface{})
+		var fields []string
+		for field, value := range desired[key] {
+			if isConfigIDField(field) || field == "key" {
+				continue
+			}
+			update[field] = value
+			if !configValuesEqual(value, l[field]) {
+				fields = append(fields, field)
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		sort.Strings(fields)
+
+		change := ConfigChange{Action: ConfigActionUpdate, Kind: "setting", Name: key, ID: id, Fields: fields}
+		if !s.opts.DryRun {
+			if err := s.c.UpdateSetting(ctx, s.site, key, id, update, nil); err != nil {
+				return errors.Wrapf(err, "unable to %s", change)
+			}
+		}
+		s.plan.Changes = append(s.plan.Changes, change)
+	}
+	return nil
+}
+
+// isConfigIDField returns true for the fields identifying the object rather than configuring it, the attr_
+// fields are managed by the controller
+func isConfigIDField(field string) bool {
+	return field == "_id" || field == "site_id" || strings.HasPrefix(field, "attr_")
+}
+
+// isBuiltInConfig returns true for the built-in objects of the controller, these are never pruned
+func isBuiltInConfig(noDelete bool, hiddenID string) bool {
+	return noDelete || hiddenID != ""
+}
+
+// diffConfigFields returns the sorted JSON field names that differ between the objects
+func diffConfigFields(desired interface{}, live interface{}) ([]string, error) {
+	d, err := configFields(desired)
+	if err != nil {
+		return nil, err
+	}
+	l, err := configFields(live)
+	if err != nil {
+		return nil, err
+	}
+	var fields []string
+	for field, value := range d {
+		if !isConfigIDField(field) && !configValuesEqual(value, l[field]) {
+			fields = append(fields, field)
+		}
+	}
+	for field := range l {
+		if _, ok := d[field]; !ok && !isConfigIDField(field) {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+// configFields returns the JSON fields of the object
+func configFields(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	err = json.Unmarshal(data, &fields)
+	return fields, err
+}
+
+// configValuesEqual compares the values by their JSON encoding, so e.g. numbers decoded from YAML and JSON compare equal
+func configValuesEqual(a interface{}, b interface{}) bool {
+	aData, aErr := json.Marshal(a)
+	bData, bErr := json.Marshal(b)
+	if aErr != nil || bErr != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return string(aData) == string(bData)
+}
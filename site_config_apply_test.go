@@ -0,0 +1,292 @@
+package unifi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// configController serves the rest endpoints of the default site from memory and records every change
+type configController struct {
+	mu      sync.Mutex
+	objects map[string][]map[string]interface{} // keyed by the rest endpoint, e.g. `networkconf`
+	ops     []string                            // `<method> <endpoint>[/<id>]`
+	bodies  map[string]map[string]interface{}   // the last request body per op
+	created int
+}
+
+// newConfigController serves the objects, keyed by the rest endpoint, as JSON
+func newConfigController(t *testing.T, objects map[string]string) (*configController, *Client) {
+	cc := &configController{objects: make(map[string][]map[string]interface{}), bodies: make(map[string]map[string]interface{})}
+	for _, endpoint := range []string{"networkconf", "wlanconf", "firewallgroup", "firewallrule", "portconf", "usergroup", "setting"} {
+		list := []map[string]interface{}{}
+		if data, ok := objects[endpoint]; ok {
+			if err := json.Unmarshal([]byte(data), &list); err != nil {
+				t.Fatal(err)
+			}
+		}
+		cc.objects[endpoint] = list
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(cc.serveHTTP))
+	t.Cleanup(srv.Close)
+	c, err := NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetUniFiOS(false)
+	return cc, c
+}
+
+func (cc *configController) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	rest := strings.TrimPrefix(r.URL.Path, "/api/s/default/rest/")
+	endpoint, id := rest, ""
+	if i := strings.Index(rest, "/"); i >= 0 {
+		endpoint, id = rest[:i], rest[i+1:]
+	}
+	objects, ok := cc.objects[endpoint]
+	if !ok || rest == r.URL.Path {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method == http.MethodGet {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"meta": map[string]string{"rc": "ok"}, "data": objects})
+		return
+	}
+
+	op := r.Method + " " + path.Join(endpoint, id)
+	id = path.Base(id) // settings are updated at setting/<key>/<id>
+	cc.ops = append(cc.ops, op)
+	var body map[string]interface{}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	cc.bodies[op] = body
+
+	var data []map[string]interface{}
+	switch r.Method {
+	case http.MethodPost:
+		cc.created++
+		body["_id"] = fmt.Sprintf("new-%d", cc.created)
+		cc.objects[endpoint] = append(objects, body)
+		data = append(data, body)
+	case http.MethodPut:
+		for i := range objects {
+			if objects[i]["_id"] == id {
+				objects[i] = body
+			}
+		}
+	case http.MethodDelete:
+		for i := range objects {
+			if objects[i]["_id"] == id {
+				cc.objects[endpoint] = append(objects[:i], objects[i+1:]...)
+				break
+			}
+		}
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"meta": map[string]string{"rc": "ok"}, "data": data})
+}
+
+func TestApply(t *testing.T) {
+	live := map[string]string{
+		"networkconf": `[
+			{"_id":"live-lan","site_id":"s1","name":"LAN","purpose":"corporate","enabled":true,"ip_subnet":"192.168.1.1/24","attr_hidden_id":"LAN","attr_no_delete":true},
+			{"_id":"live-iot","site_id":"s1","name":"IoT","purpose":"corporate","enabled":true,"ip_subnet":"10.0.20.1/24","vlan_enabled":true,"vlan":20}
+		]`,
+		"firewallgroup": `[
+			{"_id":"live-old","site_id":"s1","name":"Old","group_type":"address-group","group_members":["10.0.0.1"]}
+		]`,
+		"firewallrule": `[
+			{"_id":"live-old-rule","site_id":"s1","name":"Old rule","enabled":true,"action":"drop","ruleset":"LAN_IN","rule_index":2000,"src_firewallgroup_ids":["live-old"]},
+			{"_id":"live-builtin-rule","site_id":"s1","name":"Built-in","enabled":true,"action":"accept","ruleset":"LAN_IN","rule_index":3000,"attr_no_delete":true}
+		]`,
+		"setting": `[
+			{"_id":"live-mgmt","key":"mgmt","led_enabled":true}
+		]`,
+	}
+	desired := func() *SiteConfig {
+		return &SiteConfig{
+			Networks: []Network{
+				// matched by name, the exporting site used other IDs
+				{ID: "src-lan", SiteID: "s0", Name: "LAN", Purpose: NetworkPurposeCorporate, Enabled: true, IPSubnet: "192.168.1.1/24", AttrHiddenID: "LAN", AttrNoDelete: true},
+				{ID: "src-iot", SiteID: "s0", Name: "IoT", Purpose: NetworkPurposeCorporate, Enabled: true, IPSubnet: "10.0.30.1/24", VLANEnabled: true, VLAN: 20},
+			},
+			FirewallGroups: []FirewallGroup{
+				{ID: "src-servers", Name: "Servers", GroupType: FirewallGroupTypeAddressGroup, GroupMembers: []string{"10.0.0.10"}},
+			},
+			FirewallRules: []FirewallRule{
+				{
+					ID: "src-rule", Name: "Block IoT to servers", Enabled: true, Action: FirewallRuleActionDrop,
+					Ruleset: FirewallRulesetLANIn, RuleIndex: 2001,
+					SourceNetworkConfID: "src-iot", DestinationFirewallGroupIDs: []string{"src-servers"},
+				},
+			},
+			Settings: map[string]SiteDetailedSettings{
+				"mgmt": {"key": "mgmt", "led_enabled": false},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		opts       ApplyOptions
+		wantPlan   []string
+		wantOps    []string
+		wantBodies map[string]map[string]interface{} // the expected fields of the request bodies
+	}{
+		{
+			name: "dry run",
+			opts: ApplyOptions{DryRun: true, Prune: true},
+			wantPlan: []string{
+				"create firewall_group Servers",
+				"update network IoT (ip_subnet)",
+				"create firewall_rule Block IoT to servers",
+				"update setting mgmt (led_enabled)",
+				"delete firewall_rule Old rule",
+				"delete firewall_group Old",
+			},
+		},
+		{
+			name: "apply",
+			opts: ApplyOptions{},
+			wantPlan: []string{
+				"create firewall_group Servers",
+				"update network IoT (ip_subnet)",
+				"create firewall_rule Block IoT to servers",
+				"update setting mgmt (led_enabled)",
+			},
+			wantOps: []string{
+				"POST firewallgroup",
+				"PUT networkconf/live-iot",
+				"POST firewallrule",
+				"PUT setting/mgmt/live-mgmt",
+			},
+			wantBodies: map[string]map[string]interface{}{
+				"PUT networkconf/live-iot": {"_id": "live-iot", "site_id": "s1", "ip_subnet": "10.0.30.1/24"},
+				"POST firewallrule": {
+					"src_networkconf_id":    "live-iot",
+					"dst_firewallgroup_ids": []interface{}{"new-1"},
+				},
+			},
+		},
+		{
+			name: "apply and prune",
+			opts: ApplyOptions{Prune: true},
+			wantPlan: []string{
+				"create firewall_group Servers",
+				"update network IoT (ip_subnet)",
+				"create firewall_rule Block IoT to servers",
+				"update setting mgmt (led_enabled)",
+				"delete firewall_rule Old rule",
+				"delete firewall_group Old",
+			},
+			wantOps: []string{
+				"POST firewallgroup",
+				"PUT networkconf/live-iot",
+				"POST firewallrule",
+				"PUT setting/mgmt/live-mgmt",
+				"DELETE firewallrule/live-old-rule",
+				"DELETE firewallgroup/live-old",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc, c := newConfigController(t, live)
+			plan, err := c.Apply(context.Background(), "default", desired(), tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var gotPlan []string
+			for _, ch := range plan.Changes {
+				gotPlan = append(gotPlan, ch.String())
+			}
+			if !reflect.DeepEqual(gotPlan, tt.wantPlan) {
+				t.Errorf("plan = %q, want %q", gotPlan, tt.wantPlan)
+			}
+			if !reflect.DeepEqual(cc.ops, tt.wantOps) {
+				t.Errorf("requests = %q, want %q", cc.ops, tt.wantOps)
+			}
+			for op, want := range tt.wantBodies {
+				for field, value := range want {
+					if got := cc.bodies[op][field]; !reflect.DeepEqual(got, value) {
+						t.Errorf("%s %s = %v, want %v", op, field, got, value)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestApplyUnchanged(t *testing.T) {
+	_, c := newConfigController(t, map[string]string{
+		"networkconf": `[{"_id":"live-lan","site_id":"s1","name":"LAN","purpose":"corporate","enabled":true,"attr_hidden_id":"LAN"}]`,
+	})
+	cfg, err := c.Export(context.Background(), "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := c.Apply(context.Background(), "default", cfg, ApplyOptions{Prune: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Errorf("plan = %q, want no changes", plan)
+	}
+
+	// the built-in network is kept when pruning with an empty document
+	plan, err = c.Apply(context.Background(), "default", &SiteConfig{}, ApplyOptions{Prune: true, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Errorf("plan = %q, want no changes", plan)
+	}
+}
+
+func TestDiffConfigFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired interface{}
+		live    interface{}
+		want    []string
+	}{
+		{
+			name:    "equal",
+			desired: map[string]interface{}{"name": "LAN", "vlan": 20},
+			live:    map[string]interface{}{"name": "LAN", "vlan": float64(20)},
+		},
+		{
+			name:    "changed and removed fields",
+			desired: map[string]interface{}{"name": "LAN", "vlan": 30},
+			live:    map[string]interface{}{"name": "LAN", "vlan": 20, "domain_name": "lan"},
+			want:    []string{"domain_name", "vlan"},
+		},
+		{
+			name:    "ID and controller fields are ignored",
+			desired: map[string]interface{}{"_id": "a", "site_id": "s0", "name": "LAN"},
+			live:    map[string]interface{}{"_id": "b", "site_id": "s1", "name": "LAN", "attr_hidden_id": "LAN"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := diffConfigFields(tt.desired, tt.live)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffConfigFields() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
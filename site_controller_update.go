@@ -0,0 +1,133 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// ControllerUpdate describes the update state of the controller software
+type ControllerUpdate struct {
+	Version          string // the running network application version
+	UpdateAvailable  bool   // a newer network application version is available
+	UpdateDownloaded bool   // the update is downloaded and ready to install
+	PackageVersion   string // the version of the installed package, classic controllers only
+	PackageUpdate    bool   // a newer package is available, classic controllers only
+	CloudKeyVersion  string // the Cloud Key firmware version, Cloud Key gen1 only
+	CloudKeyUpdate   bool   // a newer Cloud Key firmware is available, Cloud Key gen1 only
+}
+
+// CheckControllerUpdate will ask the controller to check for software and device firmware updates and returns
+// the update state of the controller
+func (c *Client) CheckControllerUpdate(ctx context.Context) (*ControllerUpdate, error) {
+	data := []byte(`{"cmd": "check-firmware-update"}`)
+
+	var resp GenericResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, "", "cmd/productinfo", bytes.NewReader(data), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := c.SysInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ControllerUpdate{
+		Version:          info.Version,
+		UpdateAvailable:  info.UpdateAvailable,
+		UpdateDownloaded: info.UpdateDownloaded,
+		PackageVersion:   info.PackageVersion,
+		PackageUpdate:    info.PackageUpdateAvailable,
+		CloudKeyVersion:  info.CloudKeyVersion,
+		CloudKeyUpdate:   info.CloudKeyUpdateAvailable,
+	}, nil
+}
+
+// UpdateController will install the available controller software update, the controller restarts afterwards.
+// Only classic controllers update themselves, UniFi OS consoles update the network application through the
+// console and return an error wrapping ErrUnsupported.
+func (c *Client) UpdateController(ctx context.Context) error {
+	if detected, _ := c.unifiOSState(); !detected {
+		if _, err := c.DetectUniFiOS(ctx); err != nil {
+			return err
+		}
+	}
+	if c.IsUniFiOS() {
+		return errors.Wrap(ErrUnsupported, "the network application is updated by the UniFi OS console")
+	}
+
+	data := []byte(`{"cmd": "upgrade"}`)
+
+	var resp GenericResponse
+	return c.doSiteRequest(ctx, http.MethodPost, "", "cmd/system", bytes.NewReader(data), &resp)
+}
+
+// CachedFirmware defines a device firmware known to the controller
+type CachedFirmware struct {
+	Device   string `json:"device"` // the model the firmware is for, e.g. `U7PG2`
+	Version  string `json:"version"`
+	MD5      string `json:"md5"`
+	Size     int64  `json:"size"`
+	Path     string `json:"path,omitempty"`
+	Channel  string `json:"channel,omitempty"` // the release channel, e.g. `release`
+	Cached   bool   `json:"cached,omitempty"`
+	Download string `json:"url,omitempty"` // the download location, available firmware only
+}
+
+// CachedFirmwareResponse contains the cmd/firmware list response
+type CachedFirmwareResponse struct {
+	Meta CommonMeta       `json:"meta"`
+	Data []CachedFirmware `json:"data"`
+}
+
+// ListCachedFirmware will list the device firmware downloaded to the controller
+// site - the site to query
+func (c *Client) ListCachedFirmware(ctx context.Context, site string) (*CachedFirmwareResponse, error) {
+	return c.firmwareCommand(ctx, site, map[string]interface{}{
+		"cmd": "list-cached",
+	})
+}
+
+// ListAvailableFirmware will list the device firmware available for download to the controller
+// site - the site to query
+func (c *Client) ListAvailableFirmware(ctx context.Context, site string) (*CachedFirmwareResponse, error) {
+	return c.firmwareCommand(ctx, site, map[string]interface{}{
+		"cmd": "list-available",
+	})
+}
+
+// DownloadFirmware will download the device firmware to the controller cache
+// site - the site to modify
+// device - the model the firmware is for, see ListAvailableFirmware
+// version - the firmware version
+func (c *Client) DownloadFirmware(ctx context.Context, site string, device string, version string) (*CachedFirmwareResponse, error) {
+	return c.firmwareCommand(ctx, site, map[string]interface{}{
+		"cmd":     "download",
+		"device":  device,
+		"version": version,
+	})
+}
+
+// RemoveCachedFirmware will remove the device firmware from the controller cache
+// site - the site to modify
+// device - the model the firmware is for, see ListCachedFirmware
+// version - the firmware version
+func (c *Client) RemoveCachedFirmware(ctx context.Context, site string, device string, version string) (*CachedFirmwareResponse, error) {
+	return c.firmwareCommand(ctx, site, map[string]interface{}{
+		"cmd":     "remove",
+		"device":  device,
+		"version": version,
+	})
+}
+
+// firmwareCommand runs a cmd/firmware command
+func (c *Client) firmwareCommand(ctx context.Context, site string, payload map[string]interface{}) (*CachedFirmwareResponse, error) {
+	data, _ := json.Marshal(payload)
+
+	var resp CachedFirmwareResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/firmware", bytes.NewReader(data), &resp)
+	return &resp, err
+}
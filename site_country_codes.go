@@ -1,6 +1,7 @@
 package unifi
 
 import (
+	"context"
 	"net/http"
 )
 
@@ -20,8 +21,8 @@ type SiteCountryCodesResponse struct {
 
 // SiteCountryCodes lists the site's country codes
 // site - the site to query
-func (c *Client) SiteCountryCodes(site string) (*SiteCountryCodesResponse, error) {
+func (c *Client) SiteCountryCodes(ctx context.Context, site string) (*SiteCountryCodesResponse, error) {
 	var resp SiteCountryCodesResponse
-	err := c.doSiteRequest(http.MethodGet, site, "stat/ccode", nil, &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "stat/ccode", nil, &resp)
 	return &resp, err
 }
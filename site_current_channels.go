@@ -1,6 +1,7 @@
 package unifi
 
 import (
+	"context"
 	"net/http"
 )
 
@@ -32,8 +33,8 @@ type SiteCurrentChannelsResponse struct {
 
 // SiteCurrentChannels lists the current channels
 // site - the site to query
-func (c *Client) SiteCurrentChannels(site string) (*SiteCurrentChannelsResponse, error) {
+func (c *Client) SiteCurrentChannels(ctx context.Context, site string) (*SiteCurrentChannelsResponse, error) {
 	var resp SiteCurrentChannelsResponse
-	err := c.doSiteRequest(http.MethodGet, site, "stat/current-channel", nil, &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "stat/current-channel", nil, &resp)
 	return &resp, err
 }
@@ -1,20 +1,86 @@
 package unifi
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 )
 
+// DashboardInterval defines the dashboard metric interval
+type DashboardInterval string
+
+// The supported dashboard intervals
+const (
+	DashboardInterval5Minutes DashboardInterval = "5minutes"
+	DashboardIntervalHourly   DashboardInterval = "hourly"
+)
+
+// IsValid returns true if it's a valid dashboard interval.
+// there are only a few valid types
+func (i DashboardInterval) IsValid() bool {
+	switch i {
+	case DashboardInterval5Minutes, DashboardIntervalHourly:
+		return true
+	default:
+		return false
+	}
+}
+
+// DashboardData is a single dashboard data point
+type DashboardData struct {
+	ReportStatBase
+
+	LatencyAvg  float64 `json:"latency_avg"`
+	LatencyMin  float64 `json:"latency_min"`
+	LatencyMax  float64 `json:"latency_max"`
+	WANRXBytes  float64 `json:"wan-rx_bytes"`
+	WANTXBytes  float64 `json:"wan-tx_bytes"`
+	WAN2RXBytes float64 `json:"wan2-rx_bytes"`
+	WAN2TXBytes float64 `json:"wan2-tx_bytes"`
+	LANRXBytes  float64 `json:"lan-rx_bytes"`
+	LANTXBytes  float64 `json:"lan-tx_bytes"`
+	MaxRXBytesR float64 `json:"max_rx_bytes-r"`
+	MaxTXBytesR float64 `json:"max_tx_bytes-r"`
+	RXBytes     float64 `json:"rx_bytes"`
+	TXBytes     float64 `json:"tx_bytes"`
+}
+
+// DashboardResponse contains the stat/dashboard response data
+type DashboardResponse struct {
+	Meta CommonMeta      `json:"meta"`
+	Data []DashboardData `json:"data"`
+}
+
+// Dashboard returns the dashboard metrics for the site
+// site - the site to query
+// interval - the metric interval, 5 minutes is only supported on controllers >= 5.5.x
+func (c *Client) Dashboard(ctx context.Context, site string, interval DashboardInterval) (*DashboardResponse, error) {
+	if !interval.IsValid() {
+		return nil, fmt.Errorf("invalid dashboard interval specified: %s", interval)
+	}
+	var queryParams []string
+	if interval == DashboardInterval5Minutes {
+		queryParams = []string{"scale", string(interval)}
+	}
+
+	var resp DashboardResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "stat/dashboard", nil, &resp, queryParams...)
+	return &resp, err
+}
+
 // ListDashboardMetrics will list dashboard metric objects
 // site - the site to query
 // scale5Min - if true will return stats based on 5 minute intervals, otherwise defaults to hourly stats.
 // note this only works on controllers >= 5.5.x
-func (c *Client) ListDashboardMetrics(site string, scale5Min bool) (*GenericResponse, error) {
+//
+// Deprecated: use Dashboard for typed data points.
+func (c *Client) ListDashboardMetrics(ctx context.Context, site string, scale5Min bool) (*GenericResponse, error) {
 	var queryParams []string
 	if scale5Min {
 		queryParams = []string{"scale", "5minutes"}
 	}
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodGet, site, "stat/dashboard", nil, &resp, queryParams...)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "stat/dashboard", nil, &resp, queryParams...)
 	return &resp, err
 }
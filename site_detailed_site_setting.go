@@ -1,6 +1,7 @@
 package unifi
 
 import (
+	"context"
 	"net/http"
 )
 
@@ -15,8 +16,8 @@ type SiteDetailedSettingsResponse struct {
 
 // SiteDetailedSettings queries the site for the detailed settings
 // site - the site to query
-func (c *Client) SiteDetailedSettings(site string) (*SiteDetailedSettingsResponse, error) {
+func (c *Client) SiteDetailedSettings(ctx context.Context, site string) (*SiteDetailedSettingsResponse, error) {
 	var resp SiteDetailedSettingsResponse
-	err := c.doSiteRequest(http.MethodGet, site, "rest/setting", nil, &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/setting", nil, &resp)
 	return &resp, err
 }
@@ -2,6 +2,7 @@ package unifi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -81,7 +82,7 @@ type SiteDeviceDetailedResponse struct {
 // SiteDevicesDetailed queries for the detailed device data
 // site - the site to query
 // filterMACs - optional list of macs to get specific device data for
-func (c *Client) SiteDevicesDetailed(site string, filterMACs ...string) (*SiteDeviceDetailedResponse, error) {
+func (c *Client) SiteDevicesDetailed(ctx context.Context, site string, filterMACs ...string) (*SiteDeviceDetailedResponse, error) {
 	var resp SiteDeviceDetailedResponse
 	var sendBody io.Reader
 	method := http.MethodGet
@@ -94,6 +95,6 @@ func (c *Client) SiteDevicesDetailed(site string, filterMACs ...string) (*SiteDe
 		data, _ := json.Marshal(payload)
 		sendBody = bytes.NewReader(data)
 	}
-	err := c.doSiteRequest(method, site, "stat/device", sendBody, &resp)
+	err := c.doSiteRequest(ctx, method, site, "stat/device", sendBody, &resp)
 	return &resp, err
 }
@@ -0,0 +1,164 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// LEDOverride defines the LED mode of a device
+type LEDOverride string
+
+// The supported LED modes
+const (
+	LEDOverrideDefault LEDOverride = "default" // follow the site LED setting
+	LEDOverrideOn      LEDOverride = "on"
+	LEDOverrideOff     LEDOverride = "off"
+)
+
+// IsValid returns true if it's a valid LED mode.
+// there are only a few valid types
+func (o LEDOverride) IsValid() bool {
+	switch o {
+	case LEDOverrideDefault, LEDOverrideOn, LEDOverrideOff:
+		return true
+	default:
+		return false
+	}
+}
+
+// SetLocate will start or stop blinking the device LED to locate it.
+// site - site this device currently registered to
+// mac - the device mac
+// on - true to start blinking, false to return the LED to its normal state
+func (c *Client) SetLocate(ctx context.Context, site string, mac string, on bool) (*GenericResponse, error) {
+	cmd := "unset-locate"
+	if on {
+		cmd = "set-locate"
+	}
+	payload := map[string]interface{}{
+		"cmd": cmd,
+		"mac": strings.ToLower(mac),
+	}
+	data, _ := json.Marshal(payload)
+
+	var resp GenericResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// SetLEDOverride will set the LED mode of a device, overriding the site LED setting
+// site - site this device currently registered to
+// deviceID - the 24 char _id of the device, see GetDevice
+// mode - the LED mode
+func (c *Client) SetLEDOverride(ctx context.Context, site string, deviceID string, mode LEDOverride) (*DevicesResponse, error) {
+	if !mode.IsValid() {
+		return nil, fmt.Errorf("invalid led override specified: %s", mode)
+	}
+	payload := map[string]interface{}{
+		"led_override": mode,
+	}
+	return c.updateDevice(ctx, site, deviceID, payload)
+}
+
+// DeviceLEDSettings defines the LED settings of a device, only the set fields are changed
+type DeviceLEDSettings struct {
+	Mode       *LEDOverride `json:"led_override,omitempty"`
+	Color      *string      `json:"led_override_color,omitempty"`            // hex color, e.g. `#0000ff`, devices with RGB LEDs only
+	Brightness *int         `json:"led_override_color_brightness,omitempty"` // 0-100
+}
+
+// SetLEDSettings will set the LED mode, color and brightness of a device, overriding the site LED setting
+// site - site this device currently registered to
+// deviceID - the 24 char _id of the device, see GetDevice
+// settings - the LED settings to change
+func (c *Client) SetLEDSettings(ctx context.Context, site string, deviceID string, settings DeviceLEDSettings) (*DevicesResponse, error) {
+	if settings.Mode != nil && !settings.Mode.IsValid() {
+		return nil, fmt.Errorf("invalid led override specified: %s", *settings.Mode)
+	}
+	if settings.Color != nil && !isHexColor(*settings.Color) {
+		return nil, fmt.Errorf("invalid led color specified: %s", *settings.Color)
+	}
+	if settings.Brightness != nil && (*settings.Brightness < 0 || *settings.Brightness > 100) {
+		return nil, fmt.Errorf("invalid led brightness specified: %d", *settings.Brightness)
+	}
+	return c.updateDevice(ctx, site, deviceID, settings)
+}
+
+// DeviceDisplaySettings defines the touch screen settings of consoles and switches with a display, e.g. the UDM Pro,
+// only the set fields are changed
+type DeviceDisplaySettings struct {
+	BrightnessOverride  *bool   `json:"lcm_brightness_override,omitempty"` // use Brightness instead of the site setting
+	Brightness          *int    `json:"lcm_brightness,omitempty"`          // 1-100
+	IdleTimeoutOverride *bool   `json:"lcm_idle_timeout_override,omitempty"`
+	IdleTimeout         *int    `json:"lcm_idle_timeout,omitempty"` // seconds until the display turns off
+	NightModeEnabled    *bool   `json:"lcm_night_mode_enabled,omitempty"`
+	NightModeBegins     *string `json:"lcm_night_mode_begins,omitempty"` // e.g. `22:00`
+	NightModeEnds       *string `json:"lcm_night_mode_ends,omitempty"`   // e.g. `08:00`
+}
+
+// SetDisplaySettings will set the display settings of a device
+// site - site this device currently registered to
+// deviceID - the 24 char _id of the device, see GetDevice
+// settings - the display settings to change
+func (c *Client) SetDisplaySettings(ctx context.Context, site string, deviceID string, settings DeviceDisplaySettings) (*DevicesResponse, error) {
+	if settings.Brightness != nil && (*settings.Brightness < 1 || *settings.Brightness > 100) {
+		return nil, fmt.Errorf("invalid display brightness specified: %d", *settings.Brightness)
+	}
+	if settings.IdleTimeout != nil && *settings.IdleTimeout < 0 {
+		return nil, fmt.Errorf("invalid display idle timeout specified: %d", *settings.IdleTimeout)
+	}
+	return c.updateDevice(ctx, site, deviceID, settings)
+}
+
+// SetDarkMode will turn the LEDs off and the display to its lowest brightness with the shortest idle timeout,
+// or return both to the site settings, e.g. to enforce a datacenter dark mode policy
+// site - site this device currently registered to
+// deviceID - the 24 char _id of the device, see GetDevice
+// dark - true to turn the LEDs and display down, false to follow the site settings again
+func (c *Client) SetDarkMode(ctx context.Context, site string, deviceID string, dark bool) (*DevicesResponse, error) {
+	mode := LEDOverrideDefault
+	if dark {
+		mode = LEDOverrideOff
+	}
+	settings := struct {
+		DeviceLEDSettings
+		DeviceDisplaySettings
+	}{
+		DeviceLEDSettings:     DeviceLEDSettings{Mode: &mode},
+		DeviceDisplaySettings: DeviceDisplaySettings{BrightnessOverride: &dark, IdleTimeoutOverride: &dark},
+	}
+	if dark {
+		brightness, timeout := 1, 10
+		settings.DeviceDisplaySettings.Brightness = &brightness
+		settings.DeviceDisplaySettings.IdleTimeout = &timeout
+	}
+	return c.updateDevice(ctx, site, deviceID, settings)
+}
+
+// updateDevice updates the device with the fields of the payload
+func (c *Client) updateDevice(ctx context.Context, site string, deviceID string, payload interface{}) (*DevicesResponse, error) {
+	data, _ := json.Marshal(payload)
+
+	extPath := fmt.Sprintf("rest/device/%s", strings.TrimSpace(deviceID))
+
+	var resp DevicesResponse
+	err := c.doSiteRequest(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// isHexColor returns true if the color is formatted like `#0000ff`
+func isHexColor(color string) bool {
+	if len(color) != 7 || color[0] != '#' {
+		return false
+	}
+	for _, r := range color[1:] {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
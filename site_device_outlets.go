@@ -0,0 +1,174 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// DeviceOutlet defines the state of a power outlet of a USP-PDU-Pro or smart plug
+type DeviceOutlet struct {
+	Index        int         `json:"index"`
+	Name         string      `json:"name"`
+	RelayState   bool        `json:"relay_state"`   // the outlet is powered
+	CycleEnabled bool        `json:"cycle_enabled"` // the outlet is power cycled when the connected device goes offline
+	Caps         int         `json:"outlet_caps"`
+	Voltage      interface{} `json:"outlet_voltage"`      // these come back as strings >.<
+	Current      interface{} `json:"outlet_current"`      // these come back as strings >.<
+	Power        interface{} `json:"outlet_power"`        // these come back as strings >.<
+	PowerFactor  interface{} `json:"outlet_power_factor"` // these come back as strings >.<
+}
+
+// GetVoltage returns the outlet voltage in volts
+func (o DeviceOutlet) GetVoltage() float64 {
+	return outletMetric(o.Voltage)
+}
+
+// GetCurrent returns the outlet current in amperes
+func (o DeviceOutlet) GetCurrent() float64 {
+	return outletMetric(o.Current)
+}
+
+// GetPower returns the power draw of the outlet in watts
+func (o DeviceOutlet) GetPower() float64 {
+	return outletMetric(o.Power)
+}
+
+// GetPowerFactor returns the power factor of the outlet load
+func (o DeviceOutlet) GetPowerFactor() float64 {
+	return outletMetric(o.PowerFactor)
+}
+
+// outletMetric returns the float value of a metric reported as string or number
+func outletMetric(v interface{}) float64 {
+	switch m := v.(type) {
+	case string:
+		f, err := strconv.ParseFloat(m, 64)
+		if err != nil {
+			return 0
+		}
+		return f
+	case float64:
+		return m
+	}
+	return 0
+}
+
+// OutletOverride defines the per-outlet settings of a PDU or smart plug
+type OutletOverride struct {
+	Index        int    `json:"index"`
+	Name         string `json:"name,omitempty"`
+	RelayState   bool   `json:"relay_state"`
+	CycleEnabled bool   `json:"cycle_enabled"`
+}
+
+// OutletPower returns the power draw of every outlet of the device in watts, keyed by the outlet index
+func (d *Device) OutletPower() map[int]float64 {
+	power := make(map[int]float64, len(d.OutletTable))
+	for _, o := range d.OutletTable {
+		power[o.Index] = o.GetPower()
+	}
+	return power
+}
+
+// TotalOutletPower returns the summed power draw of all outlets of the device in watts
+func (d *Device) TotalOutletPower() float64 {
+	var total float64
+	for _, o := range d.OutletTable {
+		total += o.GetPower()
+	}
+	return total
+}
+
+// ListOutlets returns the outlets of a PDU or smart plug, including the current power draw
+// site - the site to query
+// mac - the device mac
+func (c *Client) ListOutlets(ctx context.Context, site string, mac string) ([]DeviceOutlet, error) {
+	device, err := c.GetDevice(ctx, site, mac)
+	if err != nil {
+		return nil, err
+	}
+	return device.OutletTable, nil
+}
+
+// SetDeviceOutletOverrides will replace all outlet overrides of a PDU or smart plug
+// site - the site to modify
+// deviceID - the 24 char _id of the device, see GetDevice
+// overrides - the complete set of outlet overrides
+func (c *Client) SetDeviceOutletOverrides(ctx context.Context, site string, deviceID string, overrides []OutletOverride) (*DevicesResponse, error) {
+	if overrides == nil {
+		overrides = make([]OutletOverride, 0)
+	}
+	payload := map[string]interface{}{
+		"outlet_overrides": overrides,
+	}
+	return c.updateDevice(ctx, site, deviceID, payload)
+}
+
+// SetOutletOverrides will set the overrides of multiple outlets, keeping the overrides of the other outlets
+// site - the site to modify
+// mac - the device mac
+// overrides - the outlet overrides, each replaces any existing override of the same outlet
+func (c *Client) SetOutletOverrides(ctx context.Context, site string, mac string, overrides ...OutletOverride) (*DevicesResponse, error) {
+	device, err := c.GetDevice(ctx, site, mac)
+	if err != nil {
+		return nil, err
+	}
+	return c.setOutletOverrides(ctx, site, device, overrides)
+}
+
+// SetOutlet will power an outlet on or off, keeping its name and cycle setting
+// site - the site to modify
+// mac - the device mac
+// index - the outlet index, see DeviceOutlet
+// on - true to power the outlet, false to cut its power
+func (c *Client) SetOutlet(ctx context.Context, site string, mac string, index int, on bool) (*DevicesResponse, error) {
+	device, err := c.GetDevice(ctx, site, mac)
+	if err != nil {
+		return nil, err
+	}
+
+	override, ok := device.outletOverride(index)
+	if !ok {
+		return nil, fmt.Errorf("invalid outlet specified: %d", index)
+	}
+	override.RelayState = on
+	return c.setOutletOverrides(ctx, site, device, []OutletOverride{override})
+}
+
+// outletOverride returns the current override of the outlet, derived from the outlet table when the outlet
+// has no override yet
+func (d *Device) outletOverride(index int) (OutletOverride, bool) {
+	for _, o := range d.OutletOverrides {
+		if o.Index == index {
+			return o, true
+		}
+	}
+	for _, o := range d.OutletTable {
+		if o.Index == index {
+			return OutletOverride{Index: o.Index, Name: o.Name, RelayState: o.RelayState, CycleEnabled: o.CycleEnabled}, true
+		}
+	}
+	return OutletOverride{}, false
+}
+
+// setOutletOverrides merges the overrides into the existing overrides of the device
+func (c *Client) setOutletOverrides(ctx context.Context, site string, device *Device, overrides []OutletOverride) (*DevicesResponse, error) {
+	byIndex := make(map[int]OutletOverride, len(device.OutletOverrides)+len(overrides))
+	for _, o := range device.OutletOverrides {
+		byIndex[o.Index] = o
+	}
+	for _, o := range overrides {
+		byIndex[o.Index] = o
+	}
+	merged := make([]OutletOverride, 0, len(byIndex))
+	for _, o := range byIndex {
+		merged = append(merged, o)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Index < merged[j].Index
+	})
+
+	return c.SetDeviceOutletOverrides(ctx, site, device.ID, merged)
+}
@@ -0,0 +1,151 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// DeviceRadioStats defines the statistics of an access point radio (uap only)
+type DeviceRadioStats struct {
+	Name           string      `json:"name"`
+	Radio          string      `json:"radio"`   // `ng` for 2.4GHz, `na` for 5GHz
+	Channel        interface{} `json:"channel"` // sometimes string or int
+	TXPower        interface{} `json:"tx_power"`
+	State          string      `json:"state"`
+	NumberSTA      int         `json:"num_sta"`
+	UserNumberSTA  int         `json:"user-num_sta"`
+	GuestNumberSTA int         `json:"guest-num_sta"`
+	Satisfaction   int         `json:"satisfaction"`
+	CUTotal        int         `json:"cu_total"` // channel utilization, percent
+	CUSelfRX       int         `json:"cu_self_rx"`
+	CUSelfTX       int         `json:"cu_self_tx"`
+	TXPackets      int64       `json:"tx_packets"`
+	TXRetries      int64       `json:"tx_retries"`
+	RXPackets      int64       `json:"rx_packets"`
+	RXBytes        int64       `json:"rx_bytes"`
+	TXBytes        int64       `json:"tx_bytes"`
+}
+
+// DeviceSysStats defines the load and memory statistics of a device
+type DeviceSysStats struct {
+	LoadAvg1  interface{} `json:"loadavg_1"`  // these come back as strings
+	LoadAvg5  interface{} `json:"loadavg_5"`  // these come back as strings
+	LoadAvg15 interface{} `json:"loadavg_15"` // these come back as strings
+	MemTotal  int64       `json:"mem_total"`
+	MemUsed   int64       `json:"mem_used"`
+	MemBuffer int64       `json:"mem_buffer"`
+}
+
+// DeviceTemperature defines a temperature sensor reading
+type DeviceTemperature struct {
+	Name  string  `json:"name"`
+	Type  string  `json:"type"` // cpu, board or phy
+	Value float64 `json:"value"`
+}
+
+// DeviceStats defines the detailed statistics of a device
+type DeviceStats struct {
+	ID           string      `json:"_id"`
+	MAC          string      `json:"mac"`
+	Name         string      `json:"name"`
+	Model        string      `json:"model"`
+	Type         DeviceType  `json:"type"`
+	Version      string      `json:"version"`
+	State        DeviceState `json:"state"`
+	Uptime       int64       `json:"uptime"`
+	LastSeen     int64       `json:"last_seen"`
+	Satisfaction int         `json:"satisfaction"`
+	NumberSTA    int         `json:"num_sta"`
+	Bytes        int64       `json:"bytes"`
+	RXBytes      int64       `json:"rx_bytes"`
+	TXBytes      int64       `json:"tx_bytes"`
+
+	Uplink             DeviceUplink                   `json:"uplink"`
+	SystemStats        SitesVerboseGatewaySystemStats `json:"system-stats"`
+	SysStats           DeviceSysStats                 `json:"sys_stats"`
+	HasTemperature     bool                           `json:"has_temperature"`
+	GeneralTemperature float64                        `json:"general_temperature"`
+	Temperatures       []DeviceTemperature            `json:"temperatures,omitempty"`
+	HasFan             bool                           `json:"has_fan"`
+	FanLevel           int                            `json:"fan_level"`
+
+	// uap
+	RadioTable      []DeviceRadio      `json:"radio_table,omitempty"`
+	RadioTableStats []DeviceRadioStats `json:"radio_table_stats,omitempty"`
+
+	// usw & ugw
+	PortTable     []DevicePort `json:"port_table,omitempty"`
+	TotalMaxPower int          `json:"total_max_power,omitempty"` // PoE budget, watts
+
+	// ugw
+	WAN1 *DeviceWAN `json:"wan1,omitempty"`
+	WAN2 *DeviceWAN `json:"wan2,omitempty"`
+}
+
+// CPU returns the CPU usage, percent
+func (s DeviceStats) CPU() float64 {
+	return s.SystemStats.GetCPUUsage()
+}
+
+// Memory returns the memory usage, percent
+func (s DeviceStats) Memory() float64 {
+	return s.SystemStats.GetMemoryUsage()
+}
+
+// Temperature returns the highest reported temperature, 0 if the device has no sensor
+func (s DeviceStats) Temperature() float64 {
+	t := s.GeneralTemperature
+	for _, sensor := range s.Temperatures {
+		if sensor.Value > t {
+			t = sensor.Value
+		}
+	}
+	return t
+}
+
+// deviceStatsResponse contains the stat/device response decoded as device statistics
+type deviceStatsResponse struct {
+	Meta CommonMeta    `json:"meta"`
+	Data []DeviceStats `json:"data"`
+}
+
+// ListDeviceStats returns the detailed statistics of the devices for the site
+// site - the site to query
+// filterMACs - optional list of device macs to limit the results to
+func (c *Client) ListDeviceStats(ctx context.Context, site string, filterMACs ...string) ([]DeviceStats, error) {
+	macs := make([]string, 0, len(filterMACs))
+	for _, mac := range filterMACs {
+		macs = append(macs, strings.ToLower(mac))
+	}
+	payload := map[string]interface{}{}
+	if len(macs) > 0 {
+		payload["macs"] = macs
+	}
+	data, _ := json.Marshal(payload)
+
+	var resp deviceStatsResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "stat/device", bytes.NewReader(data), &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// DeviceStats returns the detailed statistics of a single device
+// site - the site to query
+// mac - the device mac
+func (c *Client) DeviceStats(ctx context.Context, site string, mac string) (*DeviceStats, error) {
+	stats, err := c.ListDeviceStats(ctx, site, mac)
+	if err != nil {
+		return nil, err
+	}
+	for i := range stats {
+		if strings.EqualFold(stats[i].MAC, mac) {
+			return &stats[i], nil
+		}
+	}
+	return nil, ErrDeviceNotFound
+}
@@ -0,0 +1,233 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DeviceType defines the device type as reported by the controller
+type DeviceType string
+
+// The known device types
+const (
+	DeviceTypeAccessPoint  DeviceType = "uap"
+	DeviceTypeSwitch       DeviceType = "usw"
+	DeviceTypeGateway      DeviceType = "ugw"
+	DeviceTypeDreamMachine DeviceType = "udm"
+)
+
+// DeviceState defines the device state as reported by the controller
+type DeviceState int
+
+// The known device states
+const (
+	DeviceStateDisconnected    DeviceState = 0
+	DeviceStateConnected       DeviceState = 1
+	DeviceStatePendingAdoption DeviceState = 2
+	DeviceStateUpgrading       DeviceState = 4
+	DeviceStateProvisioning    DeviceState = 5
+	DeviceStateHeartbeatMissed DeviceState = 6
+	DeviceStateAdopting        DeviceState = 7
+	DeviceStateAdoptionFailed  DeviceState = 9
+	DeviceStateIsolated        DeviceState = 10
+	DeviceStateManagedByOther  DeviceState = 11
+)
+
+// DeviceUplink defines the uplink of a device
+type DeviceUplink struct {
+	Type             string `json:"type"`
+	Name             string `json:"name"`
+	IP               string `json:"ip"`
+	MAC              string `json:"mac"`
+	Speed            int    `json:"speed"`
+	FullDuplex       bool   `json:"full_duplex"`
+	Up               bool   `json:"up"`
+	UplinkMAC        string `json:"uplink_mac"`
+	UplinkRemotePort int    `json:"uplink_remote_port"`
+	RXBytes          int64  `json:"rx_bytes"`
+	TXBytes          int64  `json:"tx_bytes"`
+	RXBytesR         int64  `json:"rx_bytes-r"`
+	TXBytesR         int64  `json:"tx_bytes-r"`
+}
+
+// DeviceRadio defines a radio on an access point (uap only)
+type DeviceRadio struct {
+	Name               string      `json:"name"`
+	Radio              string      `json:"radio"`   // `ng` for 2.4GHz, `na` for 5GHz
+	Channel            interface{} `json:"channel"` // sometimes string (auto) or int
+	HT                 interface{} `json:"ht"`      // sometimes string or int
+	TXPowerMode        string      `json:"tx_power_mode"`
+	TXPower            interface{} `json:"tx_power"` // sometimes string or int
+	MinRSSIEnabled     bool        `json:"min_rssi_enabled"`
+	MinRSSI            int         `json:"min_rssi"`
+	NSS                int         `json:"nss"`
+	MaxTXPower         int         `json:"max_txpower"`
+	MinTXPower         int         `json:"min_txpower"`
+	BuiltinAntennaGain int         `json:"builtin_antenna_gain"`
+	Is11AC             bool        `json:"is_11ac"`
+}
+
+// DevicePort defines a switch or gateway port (usw/ugw only)
+type DevicePort struct {
+	PortIdx     int         `json:"port_idx"`
+	Name        string      `json:"name"`
+	Media       string      `json:"media"`
+	Enable      bool        `json:"enable"`
+	Up          bool        `json:"up"`
+	IsUplink    bool        `json:"is_uplink"`
+	Speed       int         `json:"speed"`
+	FullDuplex  bool        `json:"full_duplex"`
+	Autoneg     bool        `json:"autoneg"`
+	OpMode      string      `json:"op_mode"`
+	PortConfID  string      `json:"portconf_id"`
+	PortPoE     bool        `json:"port_poe"`
+	PoECaps     int         `json:"poe_caps"`
+	PoEEnable   bool        `json:"poe_enable"`
+	PoEMode     string      `json:"poe_mode"`
+	PoEPower    interface{} `json:"poe_power"`   // sometimes string or float
+	PoEVoltage  interface{} `json:"poe_voltage"` // sometimes string or float
+	PoECurrent  interface{} `json:"poe_current"` // sometimes string or float
+	STPState    string      `json:"stp_state"`
+	STPPathCost int         `json:"stp_pathcost"`
+	RXBytes     int64       `json:"rx_bytes"`
+	TXBytes     int64       `json:"tx_bytes"`
+	RXBytesR    float64     `json:"rx_bytes-r"`
+	TXBytesR    float64     `json:"tx_bytes-r"`
+	RXPackets   int64       `json:"rx_packets"`
+	TXPackets   int64       `json:"tx_packets"`
+	RXErrors    int64       `json:"rx_errors"`
+	TXErrors    int64       `json:"tx_errors"`
+	RXDropped   int64       `json:"rx_dropped"`
+	TXDropped   int64       `json:"tx_dropped"`
+}
+
+// DeviceWAN defines a gateway WAN interface (ugw/udm only)
+type DeviceWAN struct {
+	Name       string   `json:"name"`
+	IFName     string   `json:"ifname"`
+	IP         string   `json:"ip"`
+	Netmask    string   `json:"netmask"`
+	Gateway    string   `json:"gateway"`
+	MAC        string   `json:"mac"`
+	DNS        []string `json:"dns"`
+	Enable     bool     `json:"enable"`
+	Up         bool     `json:"up"`
+	Speed      int      `json:"speed"`
+	FullDuplex bool     `json:"full_duplex"`
+	RXBytes    int64    `json:"rx_bytes"`
+	TXBytes    int64    `json:"tx_bytes"`
+	RXBytesR   float64  `json:"rx_bytes-r"`
+	TXBytesR   float64  `json:"tx_bytes-r"`
+}
+
+// Device defines an adopted or pending device. This covers uap, usw and ugw devices,
+// device specific fields are only populated for the relevant device type.
+type Device struct {
+	ID                 string      `json:"_id"`
+	SiteID             string      `json:"site_id"`
+	MAC                string      `json:"mac"`
+	IP                 string      `json:"ip"`
+	Name               string      `json:"name"`
+	Model              string      `json:"model"`
+	Type               DeviceType  `json:"type"`
+	Version            string      `json:"version"`
+	Serial             string      `json:"serial"`
+	BoardRevision      int         `json:"board_rev"`
+	KernelVersion      string      `json:"kernel_version"`
+	Architecture       string      `json:"architecture"`
+	Adopted            bool        `json:"adopted"`
+	Default            bool        `json:"default"`
+	Disabled           bool        `json:"disabled"`
+	State              DeviceState `json:"state"`
+	Locating           bool        `json:"locating"`
+	LEDOverride        LEDOverride `json:"led_override"`
+	LEDColor           string      `json:"led_override_color"`
+	LEDBrightness      int         `json:"led_override_color_brightness"`
+	Upgradable         bool        `json:"upgradable"`
+	UpgradeToFirmware  string      `json:"upgrade_to_firmware"`
+	ConfigVersion      string      `json:"cfgversion"`
+	KnownConfigVersion string      `json:"known_cfgversion"`
+	InformURL          string      `json:"inform_url"`
+	InformIP           string      `json:"inform_ip"`
+	ConnectRequestIP   string      `json:"connect_request_ip"`
+	ConnectRequestPort interface{} `json:"connect_request_port"` // sometimes string or int
+	ProvisionedAt      int64       `json:"provisioned_at"`
+	Uptime             int64       `json:"uptime"`
+	LastSeen           int64       `json:"last_seen"`
+	Satisfaction       int         `json:"satisfaction"`
+	NumberSTA          int         `json:"num_sta"`
+	UserNumberSTA      int         `json:"user-num_sta"`
+	GuestNumberSTA     int         `json:"guest-num_sta"`
+	Bytes              int64       `json:"bytes"`
+	RXBytes            int64       `json:"rx_bytes"`
+	TXBytes            int64       `json:"tx_bytes"`
+
+	SystemStats SitesVerboseGatewaySystemStats `json:"system-stats"`
+	Uplink      DeviceUplink                   `json:"uplink"`
+
+	// uap
+	RadioTable []DeviceRadio `json:"radio_table,omitempty"`
+
+	// usw & ugw
+	PortTable     []DevicePort   `json:"port_table,omitempty"`
+	PortOverrides []PortOverride `json:"port_overrides,omitempty"`
+
+	// usp
+	OutletTable     []DeviceOutlet   `json:"outlet_table,omitempty"`
+	OutletOverrides []OutletOverride `json:"outlet_overrides,omitempty"`
+
+	// ugw
+	WAN1 *DeviceWAN `json:"wan1,omitempty"`
+	WAN2 *DeviceWAN `json:"wan2,omitempty"`
+}
+
+// DevicesResponse contains the stat/device response data
+type DevicesResponse struct {
+	Meta CommonMeta `json:"meta"`
+	Data []Device   `json:"data"`
+}
+
+// ListDevices lists the devices for the site
+// site - the site to query
+// filterMACs - optional list of device macs to limit the results to
+func (c *Client) ListDevices(ctx context.Context, site string, filterMACs ...string) (*DevicesResponse, error) {
+	var sendBody io.Reader
+	method := http.MethodGet
+	if len(filterMACs) > 0 {
+		method = http.MethodPost
+
+		macs := make([]string, 0, len(filterMACs))
+		for _, mac := range filterMACs {
+			macs = append(macs, strings.ToLower(mac))
+		}
+		payload := map[string]interface{}{
+			"macs": macs,
+		}
+		data, _ := json.Marshal(payload)
+		sendBody = bytes.NewReader(data)
+	}
+
+	var resp DevicesResponse
+	err := c.doSiteRequest(ctx, method, site, "stat/device", sendBody, &resp)
+	return &resp, err
+}
+
+// GetDevice returns a single device for the site
+// site - the site to query
+// mac - the device mac
+func (c *Client) GetDevice(ctx context.Context, site string, mac string) (*Device, error) {
+	resp, err := c.ListDevices(ctx, site, mac)
+	if err != nil {
+		return nil, err
+	}
+	for i := range resp.Data {
+		if strings.EqualFold(resp.Data[i].MAC, mac) {
+			return &resp.Data[i], nil
+		}
+	}
+	return nil, ErrDeviceNotFound
+}
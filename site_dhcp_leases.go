@@ -0,0 +1,152 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// DHCPLease defines an address assignment, either an active client address or a static lease (fixed IP)
+type DHCPLease struct {
+	MAC       string
+	IP        string
+	HostName  string
+	Name      string
+	NetworkID string
+	Network   string
+	UserID    string // the user/client device ID, empty for active clients without a configuration
+	Static    bool   // the address is a fixed IP reservation
+	Active    bool   // the client is currently connected
+}
+
+// ListDHCPLeases lists the active client addresses merged with the configured static leases, sorted by mac
+// site - the site to query
+func (c *Client) ListDHCPLeases(ctx context.Context, site string) ([]DHCPLease, error) {
+	active, err := c.SiteActiveClients(ctx, site, "")
+	if err != nil {
+		return nil, err
+	}
+	users, err := c.ListConfiguredUsers(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	leases := make(map[string]*DHCPLease)
+	for _, u := range users.Data {
+		if !u.UseFixedIP || u.FixedIP == "" {
+			continue
+		}
+		mac := strings.ToLower(u.MAC)
+		leases[mac] = &DHCPLease{
+			MAC:       mac,
+			IP:        u.FixedIP,
+			HostName:  u.HostName,
+			Name:      u.Name,
+			NetworkID: u.NetworkID,
+			UserID:    u.ID,
+			Static:    true,
+		}
+	}
+	for _, sta := range active.Data {
+		if sta.IP == "" {
+			continue
+		}
+		mac := strings.ToLower(sta.MAC)
+		lease, ok := leases[mac]
+		if !ok {
+			lease = &DHCPLease{
+				MAC:       mac,
+				IP:        sta.IP,
+				NetworkID: sta.NetworkID,
+				UserID:    sta.UserID,
+			}
+			leases[mac] = lease
+		}
+		lease.Active = true
+		lease.Network = sta.Network
+		if lease.HostName == "" {
+			lease.HostName = sta.HostName
+		}
+	}
+
+	ret := make([]DHCPLease, 0, len(leases))
+	for _, lease := range leases {
+		ret = append(ret, *lease)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].MAC < ret[j].MAC
+	})
+	return ret, nil
+}
+
+// findConfiguredUser returns the configured user/client device for the mac, nil if there is none
+func (c *Client) findConfiguredUser(ctx context.Context, site string, mac string) (*User, error) {
+	users, err := c.ListConfiguredUsers(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	for i := range users.Data {
+		if strings.EqualFold(users.Data[i].MAC, mac) {
+			return &users.Data[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateStaticLease will reserve a fixed IP for a mac, creating the user/client device when it is not known yet
+// site - the site to modify
+// mac - the client mac
+// networkID - the ID of the network the fixed IP belongs to
+// ip - the fixed IP to assign
+// name - optional name to set on the user/client device
+func (c *Client) CreateStaticLease(ctx context.Context, site string, mac string, networkID string, ip string, name string) (*UsersResponse, error) {
+	if mac == "" || networkID == "" || ip == "" {
+		return nil, fmt.Errorf("must specify the mac, network ID and fixed IP")
+	}
+
+	user, err := c.findConfiguredUser(ctx, site, mac)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"mac":         strings.ToLower(mac),
+		"use_fixedip": true,
+		"network_id":  networkID,
+		"fixed_ip":    ip,
+	}
+	if name != "" {
+		payload["name"] = name
+	}
+
+	method := http.MethodPost
+	extPath := "rest/user"
+	if user != nil {
+		method = http.MethodPut
+		extPath = fmt.Sprintf("rest/user/%s", user.ID)
+		payload["_id"] = user.ID
+	}
+	data, _ := json.Marshal(payload)
+
+	var resp UsersResponse
+	err = c.doSiteRequest(ctx, method, site, extPath, bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// RemoveStaticLease will remove the fixed IP reserved for a mac
+// site - the site to modify
+// mac - the client mac
+func (c *Client) RemoveStaticLease(ctx context.Context, site string, mac string) (*GenericResponse, error) {
+	user, err := c.findConfiguredUser(ctx, site, mac)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.UseFixedIP {
+		return nil, fmt.Errorf("no static lease for %s", mac)
+	}
+	return c.ClearClientFixedIP(ctx, site, user.ID)
+}
@@ -0,0 +1,156 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DPIType defines how DPI statistics are grouped
+type DPIType string
+
+// The supported DPI groupings
+const (
+	DPITypeByApplication DPIType = "by_app"
+	DPITypeByCategory    DPIType = "by_cat"
+)
+
+// IsValid returns true if it's a valid DPI type.
+// there are only a few valid types
+func (t DPIType) IsValid() bool {
+	switch t {
+	case DPITypeByApplication, DPITypeByCategory:
+		return true
+	default:
+		return false
+	}
+}
+
+// DPICategory is a DPI category ID
+type DPICategory int
+
+// Name returns the category name from the bundled table, or `Unknown (<id>)` for unknown categories
+func (c DPICategory) Name() string {
+	if name, ok := DPICategoryNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown (%d)", int(c))
+}
+
+// DPIApplication identifies a DPI application, application IDs are only unique within their category
+type DPIApplication struct {
+	Category DPICategory
+	App      int
+}
+
+// Name returns the application name from the bundled table, or `<category> (<id>)` for unknown applications
+func (a DPIApplication) Name() string {
+	if name, ok := DPIApplicationNames[a]; ok {
+		return name
+	}
+	return fmt.Sprintf("%s (%d)", a.Category.Name(), a.App)
+}
+
+// DPIStat contains the DPI counters for a single application or category
+type DPIStat struct {
+	App          int         `json:"app"` // only set when grouped by application
+	Category     DPICategory `json:"cat"`
+	Apps         []int       `json:"apps,omitempty"` // only set when grouped by category
+	RXBytes      int64       `json:"rx_bytes"`
+	TXBytes      int64       `json:"tx_bytes"`
+	RXPackets    int64       `json:"rx_packets"`
+	TXPackets    int64       `json:"tx_packets"`
+	KnownClients int         `json:"known_clients,omitempty"`
+	Clients      []struct {
+		MAC       string `json:"mac"`
+		RXBytes   int64  `json:"rx_bytes"`
+		TXBytes   int64  `json:"tx_bytes"`
+		RXPackets int64  `json:"rx_packets"`
+		TXPackets int64  `json:"tx_packets"`
+	} `json:"clients,omitempty"`
+}
+
+// Application returns the DPI application of the stat
+func (s DPIStat) Application() DPIApplication {
+	return DPIApplication{Category: s.Category, App: s.App}
+}
+
+// DPIData contains the DPI statistics for a site or a single client
+type DPIData struct {
+	MAC           string    `json:"mac,omitempty"` // only set for client DPI statistics
+	ByApplication []DPIStat `json:"by_app,omitempty"`
+	ByCategory    []DPIStat `json:"by_cat,omitempty"`
+}
+
+// Applications returns the per application statistics keyed by application
+func (d DPIData) Applications() map[DPIApplication]DPIStat {
+	ret := make(map[DPIApplication]DPIStat, len(d.ByApplication))
+	for _, s := range d.ByApplication {
+		ret[s.Application()] = s
+	}
+	return ret
+}
+
+// Categories returns the per category statistics keyed by category
+func (d DPIData) Categories() map[DPICategory]DPIStat {
+	ret := make(map[DPICategory]DPIStat, len(d.ByCategory))
+	for _, s := range d.ByCategory {
+		ret[s.Category] = s
+	}
+	return ret
+}
+
+// DPIResponse contains the DPI statistics response
+type DPIResponse struct {
+	Meta CommonMeta `json:"meta"`
+	Data []DPIData  `json:"data"`
+}
+
+// SiteDPI returns the site-wide DPI statistics
+// site - the site to query
+// dpiType - group the statistics by application or category
+// filterCategories - optional list of categories to limit the results to, only used when grouped by application
+func (c *Client) SiteDPI(ctx context.Context, site string, dpiType DPIType, filterCategories ...DPICategory) (*DPIResponse, error) {
+	if !dpiType.IsValid() {
+		return nil, fmt.Errorf("invalid dpi type specified: %s", dpiType)
+	}
+	payload := map[string]interface{}{
+		"type": dpiType,
+	}
+	if len(filterCategories) > 0 {
+		payload["cats"] = filterCategories
+	}
+	data, _ := json.Marshal(payload)
+
+	var resp DPIResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "stat/sitedpi", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// ClientDPI returns the per-client DPI statistics
+// site - the site to query
+// dpiType - group the statistics by application or category
+// filterMACs - optional list of client macs to limit the results to
+func (c *Client) ClientDPI(ctx context.Context, site string, dpiType DPIType, filterMACs ...string) (*DPIResponse, error) {
+	if !dpiType.IsValid() {
+		return nil, fmt.Errorf("invalid dpi type specified: %s", dpiType)
+	}
+	payload := map[string]interface{}{
+		"type": dpiType,
+	}
+	if len(filterMACs) > 0 {
+		macs := make([]string, 0, len(filterMACs))
+		for _, mac := range filterMACs {
+			macs = append(macs, strings.ToLower(mac))
+		}
+		payload["macs"] = macs
+	}
+	data, _ := json.Marshal(payload)
+
+	var resp DPIResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "stat/stadpi", bytes.NewReader(data), &resp)
+	return &resp, err
+}
@@ -0,0 +1,56 @@
+package unifi
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// The bundled application list internal/dpigen/dpi_apps.json is still empty, until it is filled from a controller
+// export DPIApplication.Name falls back to `<category> (<id>)` unless LoadDPIApplicationNames is used.
+//go:generate go run ./internal/dpigen -in internal/dpigen/dpi_apps.json -o site_dpi_apps_gen.go
+
+// DPICategoryNames maps the DPI category IDs to their names as displayed by the controller
+var DPICategoryNames = map[DPICategory]string{
+	0:   "Instant messengers",
+	1:   "Peer-to-peer networks",
+	3:   "File sharing services and tools",
+	4:   "Streaming media",
+	5:   "Mail and collaboration tools",
+	6:   "Voice over IP services",
+	7:   "Database tools",
+	8:   "Online games",
+	9:   "Management tools and protocols",
+	10:  "Remote access terminals",
+	11:  "Bypass proxies and tunnels",
+	12:  "Stock market",
+	13:  "Web",
+	14:  "Security update",
+	15:  "Web IM",
+	17:  "Business",
+	18:  "Network protocols",
+	19:  "Network protocols",
+	20:  "Network protocols",
+	23:  "Private protocols",
+	24:  "Social networks",
+	255: "Unknown",
+}
+
+// LoadDPIApplicationNames adds the applications of a DPI application list exported from the controller to
+// DPIApplicationNames, the list has the format read by internal/dpigen. It is not safe to call concurrently with Name.
+// r - the application list, a JSON array of objects with the cat, id and name keys
+func LoadDPIApplicationNames(r io.Reader) error {
+	var apps []struct {
+		Category DPICategory `json:"cat"`
+		App      int         `json:"id"`
+		Name     string      `json:"name"`
+	}
+	if err := json.NewDecoder(r).Decode(&apps); err != nil {
+		return errors.Wrap(err, "invalid dpi application list")
+	}
+	for _, app := range apps {
+		DPIApplicationNames[DPIApplication{Category: app.Category, App: app.App}] = app.Name
+	}
+	return nil
+}
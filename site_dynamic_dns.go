@@ -0,0 +1,81 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DynamicDNS defines a dynamic DNS configuration of a gateway WAN interface
+type DynamicDNS struct {
+	ID        string   `json:"_id,omitempty"`
+	SiteID    string   `json:"site_id,omitempty"`
+	Service   string   `json:"service"`   // the provider, e.g. dyndns, noip, namecheap, cloudflare, duckdns, custom
+	HostName  string   `json:"host_name"` // the hostname to update
+	Login     string   `json:"login,omitempty"`
+	Password  string   `json:"x_password,omitempty"`
+	Server    string   `json:"server,omitempty"`    // the update server, custom service only
+	Interface string   `json:"interface,omitempty"` // wan or wan2
+	Options   []string `json:"options,omitempty"`
+}
+
+// DynamicDNSResponse contains the dynamic DNS configuration response
+type DynamicDNSResponse struct {
+	Meta CommonMeta   `json:"meta"`
+	Data []DynamicDNS `json:"data"`
+}
+
+// ListDynamicDNS will list the dynamic DNS configurations
+// site - the site to query
+func (c *Client) ListDynamicDNS(ctx context.Context, site string) (*DynamicDNSResponse, error) {
+	var resp DynamicDNSResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/dynamicdns", nil, &resp)
+	return &resp, err
+}
+
+// CreateDynamicDNS will create a new dynamic DNS configuration
+// site - the site to modify
+// ddns - the dynamic DNS configuration to create
+func (c *Client) CreateDynamicDNS(ctx context.Context, site string, ddns *DynamicDNS) (*DynamicDNSResponse, error) {
+	if ddns.Service == "" || ddns.HostName == "" {
+		return nil, fmt.Errorf("must specify the dynamic DNS service and hostname")
+	}
+	if ddns.Interface == "" {
+		ddns.Interface = "wan"
+	}
+	data, _ := json.Marshal(ddns)
+
+	var resp DynamicDNSResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "rest/dynamicdns", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// UpdateDynamicDNS will update an existing dynamic DNS configuration
+// site - the site to modify
+// ddns - the dynamic DNS configuration to update, the ID must be set
+func (c *Client) UpdateDynamicDNS(ctx context.Context, site string, ddns *DynamicDNS) (*DynamicDNSResponse, error) {
+	if ddns.ID == "" {
+		return nil, fmt.Errorf("must specify the dynamic DNS ID")
+	}
+	data, _ := json.Marshal(ddns)
+
+	extPath := fmt.Sprintf("rest/dynamicdns/%s", strings.TrimSpace(ddns.ID))
+
+	var resp DynamicDNSResponse
+	err := c.doSiteRequest(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// DeleteDynamicDNS will delete an existing dynamic DNS configuration
+// site - the site to modify
+// ddnsID - the ID of the dynamic DNS configuration
+func (c *Client) DeleteDynamicDNS(ctx context.Context, site string, ddnsID string) (*GenericResponse, error) {
+	extPath := fmt.Sprintf("rest/dynamicdns/%s", strings.TrimSpace(ddnsID))
+
+	var resp GenericResponse
+	err := c.doSiteRequest(ctx, http.MethodDelete, site, extPath, nil, &resp)
+	return &resp, err
+}
@@ -0,0 +1,343 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Known event keys
+const (
+	EventKeyWirelessUserConnected     = "EVT_WU_Connected"
+	EventKeyWirelessUserDisconnected  = "EVT_WU_Disconnected"
+	EventKeyWirelessUserRoam          = "EVT_WU_Roam"
+	EventKeyWirelessUserRoamRadio     = "EVT_WU_RoamRadio"
+	EventKeyWirelessGuestConnected    = "EVT_WG_Connected"
+	EventKeyWirelessGuestDisconnected = "EVT_WG_Disconnected"
+	EventKeyWirelessGuestRoam         = "EVT_WG_Roam"
+	EventKeyLANUserConnected          = "EVT_LU_Connected"
+	EventKeyLANUserDisconnected       = "EVT_LU_Disconnected"
+	EventKeyLANGuestConnected         = "EVT_LG_Connected"
+	EventKeyLANGuestDisconnected      = "EVT_LG_Disconnected"
+	EventKeyAPConnected               = "EVT_AP_Connected"
+	EventKeyAPLostContact             = "EVT_AP_Lost_Contact"
+	EventKeyAPUpgraded                = "EVT_AP_Upgraded"
+	EventKeyAPRestarted               = "EVT_AP_Restarted"
+	EventKeyAPRestartedUnknown        = "EVT_AP_RestartedUnknown"
+	EventKeyAPAdopted                 = "EVT_AP_Adopted"
+	EventKeySwitchConnected           = "EVT_SW_Connected"
+	EventKeySwitchLostContact         = "EVT_SW_Lost_Contact"
+	EventKeySwitchUpgraded            = "EVT_SW_Upgraded"
+	EventKeySwitchRestarted           = "EVT_SW_Restarted"
+	EventKeySwitchRestartedUnknown    = "EVT_SW_RestartedUnknown"
+	EventKeySwitchAdopted             = "EVT_SW_Adopted"
+	EventKeyGatewayConnected          = "EVT_GW_Connected"
+	EventKeyGatewayLostContact        = "EVT_GW_Lost_Contact"
+	EventKeyGatewayUpgraded           = "EVT_GW_Upgraded"
+	EventKeyGatewayRestarted          = "EVT_GW_Restarted"
+	EventKeyGatewayRestartedUnknown   = "EVT_GW_RestartedUnknown"
+	EventKeyGatewayWANTransition      = "EVT_GW_WANTransition"
+	EventKeyIPSAlert                  = "EVT_IPS_IpsAlert"
+	EventKeyAdminLogin                = "EVT_AD_Login"
+)
+
+// Event is implemented by every typed event
+type Event interface {
+	// EventKey returns the event key, e.g. `EVT_WU_Connected`
+	EventKey() string
+	// EventTime returns the time the event occurred
+	EventTime() time.Time
+}
+
+// EventBase contains the fields common to every event
+type EventBase struct {
+	ID          string `json:"_id"`
+	Key         string `json:"key"`
+	Message     string `json:"msg"`
+	SiteID      string `json:"site_id"`
+	SubSystem   string `json:"subsystem"`
+	Time        int64  `json:"time"` // epoch milliseconds
+	DatetimeStr string `json:"datetime"`
+	IsAdmin     bool   `json:"is_admin"`
+}
+
+// EventKey implements Event
+func (e EventBase) EventKey() string {
+	return e.Key
+}
+
+// EventTime implements Event
+func (e EventBase) EventTime() time.Time {
+	return time.Unix(0, e.Time*int64(time.Millisecond)).UTC()
+}
+
+// GenericEvent is used for events without a dedicated type, the raw event is kept in Raw
+type GenericEvent struct {
+	EventBase
+	Raw json.RawMessage `json:"-"`
+}
+
+// EventWirelessClientConnected is a wireless user or guest connection event
+type EventWirelessClientConnected struct {
+	EventBase
+
+	User     string      `json:"user"` // the client mac
+	Guest    string      `json:"guest"`
+	Hostname string      `json:"hostname"`
+	SSID     string      `json:"ssid"`
+	AP       string      `json:"ap"`
+	Radio    string      `json:"radio"`
+	Channel  interface{} `json:"channel"` // sometimes string or int
+}
+
+// EventWirelessClientDisconnected is a wireless user or guest disconnection event
+type EventWirelessClientDisconnected struct {
+	EventBase
+
+	User     string `json:"user"` // the client mac
+	Guest    string `json:"guest"`
+	Hostname string `json:"hostname"`
+	SSID     string `json:"ssid"`
+	AP       string `json:"ap"`
+	Duration int64  `json:"duration"` // seconds
+	Bytes    int64  `json:"bytes"`
+}
+
+// EventWirelessClientRoam is a wireless user or guest roaming between access points or radios
+type EventWirelessClientRoam struct {
+	EventBase
+
+	User        string      `json:"user"` // the client mac
+	Guest       string      `json:"guest"`
+	Hostname    string      `json:"hostname"`
+	SSID        string      `json:"ssid"`
+	AP          string      `json:"ap"`
+	APFrom      string      `json:"ap_from"`
+	APTo        string      `json:"ap_to"`
+	RadioFrom   string      `json:"radio_from"`
+	RadioTo     string      `json:"radio_to"`
+	ChannelFrom interface{} `json:"channel_from"` // sometimes string or int
+	ChannelTo   interface{} `json:"channel_to"`   // sometimes string or int
+}
+
+// EventLANClient is a wired user or guest connection or disconnection event
+type EventLANClient struct {
+	EventBase
+
+	User       string `json:"user"` // the client mac
+	Guest      string `json:"guest"`
+	Hostname   string `json:"hostname"`
+	Network    string `json:"network"`
+	Switch     string `json:"sw"`
+	SwitchName string `json:"sw_name"`
+	Port       int    `json:"port"`
+	Duration   int64  `json:"duration"` // seconds, disconnection only
+	Bytes      int64  `json:"bytes"`    // disconnection only
+}
+
+// EventDevice is a device lifecycle event, e.g. connected, lost contact, upgraded or restarted.
+// Only the mac and name fields of the relevant device type are populated.
+type EventDevice struct {
+	EventBase
+
+	AP           string `json:"ap"`
+	APName       string `json:"ap_name"`
+	APModel      string `json:"ap_model"`
+	Switch       string `json:"sw"`
+	SwitchName   string `json:"sw_name"`
+	SwitchModel  string `json:"sw_model"`
+	Gateway      string `json:"gw"`
+	GatewayName  string `json:"gw_name"`
+	GatewayModel string `json:"gw_model"`
+	VersionFrom  string `json:"version_from"` // upgrade only
+	VersionTo    string `json:"version_to"`   // upgrade only
+	Admin        string `json:"admin"`        // set when an admin triggered the event
+}
+
+// MAC returns the mac of the device the event is about
+func (e EventDevice) MAC() string {
+	switch {
+	case e.AP != "":
+		return e.AP
+	case e.Switch != "":
+		return e.Switch
+	default:
+		return e.Gateway
+	}
+}
+
+// EventGatewayWANTransition is a gateway WAN interface state change
+type EventGatewayWANTransition struct {
+	EventBase
+
+	Gateway     string `json:"gw"`
+	GatewayName string `json:"gw_name"`
+	IFName      string `json:"iface"`
+	State       string `json:"state"`
+	IP          string `json:"ip"`
+}
+
+// EventIPSAlert is an intrusion prevention alert
+type EventIPSAlert struct {
+	EventBase
+
+	Gateway               string      `json:"gw"`
+	Host                  string      `json:"host"`
+	CatName               string      `json:"catname"`
+	EventType             string      `json:"event_type"`
+	FlowID                int64       `json:"flow_id"`
+	InterfaceIn           string      `json:"in_iface"`
+	Protocol              string      `json:"proto"`
+	AppProtocol           string      `json:"app_proto"`
+	SourceIP              string      `json:"src_ip"`
+	SourceMAC             string      `json:"src_mac"`
+	SourcePort            int         `json:"src_port"`
+	SourceIPCountry       interface{} `json:"srcipCountry"` // seems to be false sometimes
+	DestinationIP         string      `json:"dest_ip"`
+	DestinationMAC        string      `json:"dst_mac"`
+	DestinationPort       int         `json:"dest_port"`
+	DestinationIPCountry  interface{} `json:"dstipCountry"` // seems to be false sometimes
+	InnerAlertAction      string      `json:"inner_alert_action"`
+	InnerAlertCategory    string      `json:"inner_alert_category"`
+	InnerAlertGID         int         `json:"inner_alert_gid"`
+	InnerAlertRevision    int         `json:"inner_alert_rev"`
+	InnerAlertSeverity    int         `json:"inner_alert_severity"`
+	InnerAlertSignature   string      `json:"inner_alert_signature"`
+	InnerAlertSignatureID int         `json:"inner_alert_signature_id"`
+	UniqueAlertID         string      `json:"unique_alertid"`
+}
+
+// EventAdminLogin is an admin login event
+type EventAdminLogin struct {
+	EventBase
+
+	Admin string `json:"admin"`
+	IP    string `json:"ip"`
+}
+
+// eventTypes maps event keys to their typed event
+var eventTypes = map[string]func() Event{
+	EventKeyWirelessUserConnected:     func() Event { return &EventWirelessClientConnected{} },
+	EventKeyWirelessGuestConnected:    func() Event { return &EventWirelessClientConnected{} },
+	EventKeyWirelessUserDisconnected:  func() Event { return &EventWirelessClientDisconnected{} },
+	EventKeyWirelessGuestDisconnected: func() Event { return &EventWirelessClientDisconnected{} },
+	EventKeyWirelessUserRoam:          func() Event { return &EventWirelessClientRoam{} },
+	EventKeyWirelessUserRoamRadio:     func() Event { return &EventWirelessClientRoam{} },
+	EventKeyWirelessGuestRoam:         func() Event { return &EventWirelessClientRoam{} },
+	EventKeyLANUserConnected:          func() Event { return &EventLANClient{} },
+	EventKeyLANUserDisconnected:       func() Event { return &EventLANClient{} },
+	EventKeyLANGuestConnected:         func() Event { return &EventLANClient{} },
+	EventKeyLANGuestDisconnected:      func() Event { return &EventLANClient{} },
+	EventKeyAPConnected:               func() Event { return &EventDevice{} },
+	EventKeyAPLostContact:             func() Event { return &EventDevice{} },
+	EventKeyAPUpgraded:                func() Event { return &EventDevice{} },
+	EventKeyAPRestarted:               func() Event { return &EventDevice{} },
+	EventKeyAPRestartedUnknown:        func() Event { return &EventDevice{} },
+	EventKeyAPAdopted:                 func() Event { return &EventDevice{} },
+	EventKeySwitchConnected:           func() Event { return &EventDevice{} },
+	EventKeySwitchLostContact:         func() Event { return &EventDevice{} },
+	EventKeySwitchUpgraded:            func() Event { return &EventDevice{} },
+	EventKeySwitchRestarted:           func() Event { return &EventDevice{} },
+	EventKeySwitchRestartedUnknown:    func() Event { return &EventDevice{} },
+	EventKeySwitchAdopted:             func() Event { return &EventDevice{} },
+	EventKeyGatewayConnected:          func() Event { return &EventDevice{} },
+	EventKeyGatewayLostContact:        func() Event { return &EventDevice{} },
+	EventKeyGatewayUpgraded:           func() Event { return &EventDevice{} },
+	EventKeyGatewayRestarted:          func() Event { return &EventDevice{} },
+	EventKeyGatewayRestartedUnknown:   func() Event { return &EventDevice{} },
+	EventKeyGatewayWANTransition:      func() Event { return &EventGatewayWANTransition{} },
+	EventKeyIPSAlert:                  func() Event { return &EventIPSAlert{} },
+	EventKeyAdminLogin:                func() Event { return &EventAdminLogin{} },
+}
+
+// RegisterEventType registers a typed event for the event key, replacing any existing registration.
+// This is not thread-safe and should be called during initialization.
+// key - the event key, e.g. `EVT_WU_Connected`
+// factory - returns a new pointer to the typed event
+func RegisterEventType(key string, factory func() Event) {
+	eventTypes[key] = factory
+}
+
+// DecodeEvent decodes a raw event into its typed event based on the `key` field.
+// Events with unknown keys are returned as *GenericEvent.
+func DecodeEvent(data []byte) (Event, error) {
+	var base EventBase
+	if err := json.Unmarshal(data, &base); err != nil {
+		return nil, errors.Wrap(err, ErrJSONDecode.Error())
+	}
+
+	factory, ok := eventTypes[base.Key]
+	if !ok {
+		return &GenericEvent{EventBase: base, Raw: json.RawMessage(data)}, nil
+	}
+	evt := factory()
+	if err := json.Unmarshal(data, evt); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("unable to decode event %s", base.Key))
+	}
+	return evt, nil
+}
+
+// Event decodes the stream event data as a typed event, only valid for `events` messages
+func (e StreamEvent) Event() (Event, error) {
+	if e.Message != "events" {
+		return nil, fmt.Errorf("stream message %s is not an event", e.Message)
+	}
+	return DecodeEvent(e.Data)
+}
+
+// rawEventsResponse contains undecoded events
+type rawEventsResponse struct {
+	Meta CommonMeta        `json:"meta"`
+	Data []json.RawMessage `json:"data"`
+}
+
+// ListTypedEvents lists the events for the site decoded as typed events
+// site - site to query
+// filter - the event filters
+func (c *Client) ListTypedEvents(ctx context.Context, site string, filter EventFilter) ([]Event, error) {
+	if filter.WithinHours <= 0 {
+		filter.WithinHours = 720
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 100
+	} else if filter.Limit > 3000 {
+		// there is a default max
+		filter.Limit = 3000
+	}
+	if filter.Order == "" {
+		filter.Order = EventSortOrderTimeDescending
+	}
+	if !filter.Order.IsValid() {
+		return nil, fmt.Errorf("invalid sort order: %s", filter.Order)
+	}
+
+	payload := map[string]interface{}{
+		"_sort":  string(filter.Order),
+		"within": filter.WithinHours,
+		"type":   nil,
+		"_start": filter.Start,
+		"_limit": filter.Limit,
+	}
+	data, _ := json.Marshal(&payload)
+
+	var resp rawEventsResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "stat/event", bytes.NewReader(data), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(resp.Data))
+	for _, raw := range resp.Data {
+		evt, err := DecodeEvent(raw)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, evt)
+	}
+	return events, nil
+}
@@ -2,11 +2,72 @@ package unifi
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
 )
 
 // ArchiveAllAlarms will archive all alarms
-func (c *Client) ArchiveAllAlarms(site string) error {
+func (c *Client) ArchiveAllAlarms(ctx context.Context, site string) error {
 	data := []byte(`{"cmd": "archive-all-alarms"}`)
-	return c.doSiteRequest(http.MethodPost, site, "cmd/evtmgt", bytes.NewReader(data), nil)
+	return c.doSiteRequest(ctx, http.MethodPost, site, "cmd/evtmgr", bytes.NewReader(data), &GenericResponse{})
+}
+
+// ArchiveAlarm will archive a single alarm
+// site - the site the alarm belongs to
+// alarmID - the ID of the alarm
+func (c *Client) ArchiveAlarm(ctx context.Context, site string, alarmID string) error {
+	if strings.TrimSpace(alarmID) == "" {
+		return fmt.Errorf("must specify the alarm ID")
+	}
+	payload := map[string]interface{}{
+		"cmd": "archive-alarm",
+		"_id": strings.TrimSpace(alarmID),
+	}
+	data, _ := json.Marshal(payload)
+	return c.doSiteRequest(ctx, http.MethodPost, site, "cmd/evtmgr", bytes.NewReader(data), &GenericResponse{})
+}
+
+// ArchiveAlarms will archive the alarms, every alarm is archived even when archiving another one fails
+// site - the site the alarms belong to
+// alarmIDs - the IDs of the alarms
+func (c *Client) ArchiveAlarms(ctx context.Context, site string, alarmIDs ...string) error {
+	var failed []string
+	var lastErr error
+	for _, id := range alarmIDs {
+		if err := c.ArchiveAlarm(ctx, site, id); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			failed = append(failed, id)
+			lastErr = err
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Wrapf(lastErr, "unable to archive alarms %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// ArchiveAlarmsMatching will archive the unarchived alarms the match function returns true for, e.g. to
+// acknowledge known noise, and returns the number of matching alarms
+// site - the site the alarms belong to
+// match - decides if the alarm is archived
+func (c *Client) ArchiveAlarmsMatching(ctx context.Context, site string, match func(Alarm) bool) (int, error) {
+	archived := false
+	resp, err := c.ListAlarms(ctx, site, AlarmFilter{Archived: &archived})
+	if err != nil {
+		return 0, err
+	}
+	var ids []string
+	for _, a := range resp.Data {
+		if match(a) {
+			ids = append(ids, a.ID)
+		}
+	}
+	return len(ids), c.ArchiveAlarms(ctx, site, ids...)
 }
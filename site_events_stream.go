@@ -0,0 +1,173 @@
+package unifi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// event stream reconnect backoff bounds
+const (
+	eventStreamMinBackoff = time.Second
+	eventStreamMaxBackoff = time.Minute
+)
+
+// StreamEvent is a single entry received from the controller websocket event stream
+type StreamEvent struct {
+	Site    string          // the site this event was received for
+	Message string          // the meta message type, e.g. `events`, `sta:sync`, `device:sync`
+	Data    json.RawMessage // the raw event data
+}
+
+// streamMessage is the envelope for every websocket message sent by the controller
+type streamMessage struct {
+	Meta struct {
+		ResponseCode ResponseCode `json:"rc"`
+		Message      string       `json:"message"`
+	} `json:"meta"`
+	Data []json.RawMessage `json:"data"`
+}
+
+// Events subscribes to the controller websocket event stream for the site.
+// The stream automatically reconnects with an exponential backoff when the connection drops.
+// The returned channel is closed once the context is done.
+// site - the site to subscribe to
+func (c *Client) Events(ctx context.Context, site string) (<-chan StreamEvent, error) {
+	if site == "" {
+		site = c.defaultSite
+	}
+	conn, err := c.dialEventStream(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan StreamEvent)
+	go func() {
+		defer close(events)
+		backoff := eventStreamMinBackoff
+		for {
+			if conn != nil {
+				backoff = eventStreamMinBackoff
+				c.readEventStream(ctx, site, conn, events)
+				if ctx.Err() == nil {
+					c.log().Warnw("event stream disconnected", "site", site)
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+			if backoff > eventStreamMaxBackoff {
+				backoff = eventStreamMaxBackoff
+			}
+			var err error
+			conn, err = c.dialEventStream(ctx, site)
+			if err != nil {
+				c.log().Warnw("event stream reconnect failed", "site", site, "error", err, "retry_in", backoff)
+			} else {
+				c.log().Infow("event stream reconnected", "site", site)
+			}
+		}
+	}()
+	return events, nil
+}
+
+// dialEventStream opens the websocket connection for the site event stream
+func (c *Client) dialEventStream(ctx context.Context, site string) (*websocket.Conn, error) {
+	u := *c.baseURL
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	case "http":
+		u.Scheme = "ws"
+	}
+	u.Path = path.Join(u.Path, c.apiPath(fmt.Sprintf("/wss/s/%s/events", site)))
+	u.RawQuery = ""
+
+	dialer := c.eventStreamDialer()
+	header := http.Header{}
+	header.Set("User-Agent", c.userAgent)
+	if c.apiKey != "" {
+		header.Set(APIKeyHeader, c.apiKey)
+	}
+	cookies, csrfToken, _ := c.sessionState()
+	if csrfToken != "" {
+		header.Set(CSRFTokenHeader, csrfToken)
+	}
+	for _, cookie := range cookies {
+		header.Add("Cookie", cookie.String())
+	}
+
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, resp, err := dialer.DialContext(ctx, u.String(), header)
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	return conn, err
+}
+
+// readEventStream reads from the connection until it fails or the context is done
+func (c *Client) readEventStream(ctx context.Context, site string, conn *websocket.Conn, events chan<- StreamEvent) {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+			conn.Close()
+		}
+	}()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var msg streamMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			continue
+		}
+		for _, d := range msg.Data {
+			select {
+			case events <- StreamEvent{Site: site, Message: msg.Meta.Message, Data: d}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// eventStreamDialer returns the websocket dialer with the proxy, dialer and TLS configuration of the http
+// transport, so WithProxy, WithoutProxy, WithDialer and the TLS options apply to the event stream as well
+func (c *Client) eventStreamDialer() *websocket.Dialer {
+	dialer := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: c.RetryTimeout,
+	}
+	if c.HTTPClient == nil {
+		return dialer
+	}
+	tr, ok := c.HTTPClient.Transport.(*http.Transport)
+	if !ok && c.HTTPClient.Transport == nil {
+		tr, ok = http.DefaultTransport.(*http.Transport)
+	}
+	if ok {
+		dialer.Proxy = tr.Proxy
+		dialer.NetDialContext = tr.DialContext
+		dialer.TLSClientConfig = tr.TLSClientConfig
+	}
+	return dialer
+}
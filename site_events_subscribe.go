@@ -0,0 +1,296 @@
+package unifi
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// streamEventFields are the fields of the event data the filters match on, decoded once per event
+type streamEventFields struct {
+	Key      string `json:"key"`
+	MAC      string `json:"mac"`
+	AP       string `json:"ap"`
+	SW       string `json:"sw"`
+	GW       string `json:"gw"`
+	User     string `json:"user"`
+	Guest    string `json:"guest"`
+	Severity int    `json:"inner_alert_severity"`
+}
+
+// StreamFilter decides if a stream event is delivered to a subscriber, see EventHub.Subscribe
+type StreamFilter func(e *StreamFilterEvent) bool
+
+// StreamFilterEvent is the stream event passed to a StreamFilter, the common fields are decoded once for all filters
+type StreamFilterEvent struct {
+	StreamEvent
+	fields *streamEventFields
+}
+
+// newStreamFilterEvent decodes the fields of the stream event
+func newStreamFilterEvent(e StreamEvent) *StreamFilterEvent {
+	var fields streamEventFields
+	_ = json.Unmarshal(e.Data, &fields)
+	return &StreamFilterEvent{StreamEvent: e, fields: &fields}
+}
+
+// Key returns the event key, empty for messages other than events and alarms
+func (e *StreamFilterEvent) Key() string {
+	return e.fields.Key
+}
+
+// MACs returns the device and client macs the event refers to
+func (e *StreamFilterEvent) MACs() []string {
+	var macs []string
+	for _, mac := range []string{e.fields.MAC, e.fields.AP, e.fields.SW, e.fields.GW, e.fields.User, e.fields.Guest} {
+		if mac != "" {
+			macs = append(macs, strings.ToLower(mac))
+		}
+	}
+	return macs
+}
+
+// Severity returns the IPS alert severity, 1 is the most severe, 0 for events without a severity
+func (e *StreamFilterEvent) Severity() int {
+	return e.fields.Severity
+}
+
+// MatchKeys matches events with one of the event keys, e.g. EventKeyAPLostContact
+func MatchKeys(keys ...string) StreamFilter {
+	return func(e *StreamFilterEvent) bool {
+		return containsString(keys, e.Key())
+	}
+}
+
+// MatchKeyPrefix matches events whose key starts with the prefix, e.g. `EVT_AP_` for all access point events
+func MatchKeyPrefix(prefix string) StreamFilter {
+	return func(e *StreamFilterEvent) bool {
+		return strings.HasPrefix(e.Key(), prefix)
+	}
+}
+
+// MatchSites matches events received for one of the sites
+func MatchSites(sites ...string) StreamFilter {
+	return func(e *StreamFilterEvent) bool {
+		return containsString(sites, e.Site)
+	}
+}
+
+// MatchMessages matches stream messages of one of the types, e.g. `events`, `alarm` or `device:sync`
+func MatchMessages(messages ...string) StreamFilter {
+	return func(e *StreamFilterEvent) bool {
+		return containsString(messages, e.Message)
+	}
+}
+
+// MatchMACs matches events referring to one of the device or client macs
+func MatchMACs(macs ...string) StreamFilter {
+	want := make(map[string]struct{}, len(macs))
+	for _, mac := range macs {
+		want[strings.ToLower(mac)] = struct{}{}
+	}
+	return func(e *StreamFilterEvent) bool {
+		for _, mac := range e.MACs() {
+			if _, ok := want[mac]; ok {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchSeverity matches IPS alerts at least as severe as the severity, 1 being the most severe
+func MatchSeverity(severity int) StreamFilter {
+	return func(e *StreamFilterEvent) bool {
+		return e.Severity() > 0 && e.Severity() <= severity
+	}
+}
+
+// MatchAll matches events matching every filter, it matches all events without filters
+func MatchAll(filters ...StreamFilter) StreamFilter {
+	return func(e *StreamFilterEvent) bool {
+		for _, f := range filters {
+			if !f(e) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// MatchAny matches events matching at least one filter
+func MatchAny(filters ...StreamFilter) StreamFilter {
+	return func(e *StreamFilterEvent) bool {
+		for _, f := range filters {
+			if f(e) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchNot matches events not matching the filter
+func MatchNot(filter StreamFilter) StreamFilter {
+	return func(e *StreamFilterEvent) bool {
+		return !filter(e)
+	}
+}
+
+// Subscription receives the stream events matching its filter, see EventHub.Subscribe
+type Subscription struct {
+	C <-chan StreamEvent // closed when the subscription is closed or the hub stopped
+
+	c       chan StreamEvent
+	filter  StreamFilter
+	hub     *EventHub
+	dropped uint64
+	once    sync.Once
+}
+
+// Dropped returns the number of events dropped because the subscriber did not keep up
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Close unsubscribes and closes the channel
+func (s *Subscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+// close closes the channel once
+func (s *Subscription) close() {
+	s.once.Do(func() {
+		close(s.c)
+	})
+}
+
+// EventHub shares one event stream connection per site among many in-process subscribers
+type EventHub struct {
+	client *Client
+	sites  []string
+
+	mu          sync.Mutex
+	subscribers map[*Subscription]struct{}
+	stopped     bool
+}
+
+// NewEventHub returns a hub for the event streams of the sites, see EventHub.Run
+// sites - the sites to subscribe to, the default site of the client when empty
+func (c *Client) NewEventHub(sites ...string) *EventHub {
+	if len(sites) == 0 {
+		sites = []string{c.defaultSite}
+	}
+	return &EventHub{
+		client:      c,
+		sites:       sites,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe returns a subscription for the events matching the filter, e.g.
+//
+//	sub := hub.Subscribe(unifi.MatchAll(unifi.MatchKeyPrefix("EVT_AP_"), unifi.MatchMACs(mac)), 16)
+//	defer sub.Close()
+//	for e := range sub.C { ... }
+//
+// Events are dropped for a subscriber whose buffer is full, so a slow subscriber never blocks the others.
+// filter - the events to deliver, nil for all events
+// buffer - the number of buffered events
+func (h *EventHub) Subscribe(filter StreamFilter, buffer int) *Subscription {
+	if filter == nil {
+		filter = MatchAll()
+	}
+	c := make(chan StreamEvent, buffer)
+	sub := &Subscription{C: c, c: c, filter: filter, hub: h}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.stopped {
+		sub.close()
+		return sub
+	}
+	h.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// unsubscribe removes and closes the subscription
+func (h *EventHub) unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscribers, sub)
+	sub.close()
+}
+
+// Run connects to the event streams and fans the events out to the subscribers until the context is done,
+// then every subscription is closed. The streams already opened are closed when connecting to a later site fails.
+func (h *EventHub) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	streams := make([]<-chan StreamEvent, 0, len(h.sites))
+	for _, site := range h.sites {
+		events, err := h.client.Events(ctx, site)
+		if err != nil {
+			cancel()
+			h.stop()
+			return err
+		}
+		streams = append(streams, events)
+	}
+
+	var wg sync.WaitGroup
+	for _, events := range streams {
+		wg.Add(1)
+		go func(events <-chan StreamEvent) {
+			defer wg.Done()
+			for e := range events {
+				h.publish(e)
+			}
+		}(events)
+	}
+	wg.Wait()
+	h.stop()
+	return nil
+}
+
+// publish delivers the event to every matching subscriber without blocking
+func (h *EventHub) publish(e StreamEvent) {
+	fe := newStreamFilterEvent(e)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subscribers {
+		if !sub.filter(fe) {
+			continue
+		}
+		select {
+		case sub.c <- e:
+		default:
+			atomic.AddUint64(&sub.dropped, 1)
+		}
+	}
+}
+
+// stop closes every subscription, later subscriptions are closed right away
+func (h *EventHub) stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.stopped = true
+	for sub := range h.subscribers {
+		delete(h.subscribers, sub)
+		sub.close()
+	}
+}
+
+// containsString returns true if the value is in the values
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
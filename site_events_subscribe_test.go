@@ -0,0 +1,53 @@
+package unifi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestEventHubRunClosesOpenedStreamsOnFailure(t *testing.T) {
+	closed := make(chan struct{})
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wss/s/good/events" {
+			http.NotFound(w, r)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				close(closed)
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+	c, err := NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetUniFiOS(false)
+
+	hub := c.NewEventHub("good", "bad")
+	sub := hub.Subscribe(nil, 1)
+	if err := hub.Run(context.Background()); err == nil {
+		t.Fatal("expected the subscription of the bad site to fail")
+	}
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the stream of the good site was not closed")
+	}
+	if _, ok := <-sub.C; ok {
+		t.Error("expected the subscription to be closed")
+	}
+}
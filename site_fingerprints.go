@@ -0,0 +1,146 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ClientFingerprint defines the device identification of a client, the ids refer to the FingerprintDatabase
+type ClientFingerprint struct {
+	DevID             int    `json:"dev_id"`
+	DevCat            int    `json:"dev_cat"`
+	DevFamily         int    `json:"dev_family"`
+	DevVendor         int    `json:"dev_vendor"`
+	OSClass           int    `json:"os_class"`
+	OSName            int    `json:"os_name"`
+	DevIDOverride     int    `json:"dev_id_override,omitempty"` // set when the fingerprint was overridden
+	FingerprintSource int    `json:"fingerprint_source"`
+	Confidence        int    `json:"confidence"`
+	FingerprintEngine string `json:"fingerprint_engine_version,omitempty"`
+}
+
+// EffectiveDevID returns the overridden device id when set, otherwise the detected device id
+func (f ClientFingerprint) EffectiveDevID() int {
+	if f.DevIDOverride != 0 {
+		return f.DevIDOverride
+	}
+	return f.DevID
+}
+
+// FingerprintDevice is a single device of the fingerprint database
+type FingerprintDevice struct {
+	Name         string `json:"name"`
+	DeviceTypeID int    `json:"dev_type_id"`
+	FamilyID     int    `json:"family_id"`
+	VendorID     int    `json:"vendor_id"`
+	OSClassID    int    `json:"os_class_id"`
+	OSNameID     int    `json:"os_name_id"`
+}
+
+// FingerprintDatabase is the controller fingerprint database used to name the ids of a ClientFingerprint
+type FingerprintDatabase struct {
+	Devices     map[string]FingerprintDevice `json:"dev_ids"`
+	DeviceTypes map[string]string            `json:"dev_type_ids"`
+	Families    map[string]string            `json:"family_ids"`
+	Vendors     map[string]string            `json:"vendor_ids"`
+	OSClasses   map[string]string            `json:"os_class_ids"`
+	OSNames     map[string]string            `json:"os_name_ids"`
+}
+
+// FingerprintDescription is a ClientFingerprint resolved to names
+type FingerprintDescription struct {
+	Device     string
+	DeviceType string
+	Family     string
+	Vendor     string
+	OSClass    string
+	OSName     string
+}
+
+// Device returns the device with the id
+func (db *FingerprintDatabase) Device(id int) (FingerprintDevice, bool) {
+	d, ok := db.Devices[strconv.Itoa(id)]
+	return d, ok
+}
+
+// Describe resolves the fingerprint ids to names, unknown ids are left empty
+func (db *FingerprintDatabase) Describe(f ClientFingerprint) FingerprintDescription {
+	name := func(names map[string]string, id int) string {
+		return names[strconv.Itoa(id)]
+	}
+	desc := FingerprintDescription{
+		DeviceType: name(db.DeviceTypes, f.DevCat),
+		Family:     name(db.Families, f.DevFamily),
+		Vendor:     name(db.Vendors, f.DevVendor),
+		OSClass:    name(db.OSClasses, f.OSClass),
+		OSName:     name(db.OSNames, f.OSName),
+	}
+	if d, ok := db.Device(f.EffectiveDevID()); ok {
+		desc.Device = d.Name
+		if f.DevIDOverride != 0 {
+			// the override replaces the detected classification
+			desc.DeviceType = name(db.DeviceTypes, d.DeviceTypeID)
+			desc.Family = name(db.Families, d.FamilyID)
+			desc.Vendor = name(db.Vendors, d.VendorID)
+			desc.OSClass = name(db.OSClasses, d.OSClassID)
+			desc.OSName = name(db.OSNames, d.OSNameID)
+		}
+	}
+	return desc
+}
+
+// Search returns the ids of the devices whose name contains the query, ignoring case, in ascending order
+func (db *FingerprintDatabase) Search(query string) []int {
+	query = strings.ToLower(query)
+	ids := make([]int, 0)
+	for key, d := range db.Devices {
+		if !strings.Contains(strings.ToLower(d.Name), query) {
+			continue
+		}
+		if id, err := strconv.Atoi(key); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// FingerprintDatabase returns the controller fingerprint database
+func (c *Client) FingerprintDatabase(ctx context.Context) (*FingerprintDatabase, error) {
+	var db FingerprintDatabase
+	err := c.doRequest(ctx, http.MethodGet, "/v2/api/fingerprint_devices/0", nil, &db)
+	return &db, err
+}
+
+// SetClientFingerprint overrides the detected device of the client
+// site - the site to modify
+// mac - the client mac
+// devID - the device id from the FingerprintDatabase
+func (c *Client) SetClientFingerprint(ctx context.Context, site string, mac string, devID int) error {
+	if devID <= 0 {
+		return fmt.Errorf("invalid device id specified: %d", devID)
+	}
+	mac = strings.ToLower(mac)
+	payload := map[string]interface{}{
+		"mac":             mac,
+		"dev_id_override": devID,
+	}
+	data, _ := json.Marshal(payload)
+
+	extPath := fmt.Sprintf("station/%s/fingerprint_override", mac)
+	return c.doSiteV2Request(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), nil)
+}
+
+// ClearClientFingerprint removes the device override of the client so the detected device is used again
+// site - the site to modify
+// mac - the client mac
+func (c *Client) ClearClientFingerprint(ctx context.Context, site string, mac string) error {
+	extPath := fmt.Sprintf("station/%s/fingerprint_override", strings.ToLower(mac))
+	return c.doSiteV2Request(ctx, http.MethodDelete, site, extPath, nil, nil)
+}
@@ -2,9 +2,11 @@ package unifi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 )
 
@@ -19,9 +21,9 @@ type SiteFirewallRuleResponse struct {
 
 // SiteFirewallRules queries the site firewall rules
 // site - the site to query
-func (c *Client) SiteFirewallRules(site string) (*SiteFirewallRuleResponse, error) {
+func (c *Client) SiteFirewallRules(ctx context.Context, site string) (*SiteFirewallRuleResponse, error) {
 	var resp SiteFirewallRuleResponse
-	err := c.doSiteRequest(http.MethodGet, site, "rest/firewallrule", nil, &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/firewallrule", nil, &resp)
 	return &resp, err
 }
 
@@ -37,14 +39,14 @@ type SiteFirewallGroupResponse struct {
 // SiteFirewallGroups will list firewall groups
 // site - the site to query
 // groupID - filter on the associated group, if zero-value it returns all for the entire site.
-func (c *Client) SiteFirewallGroups(site string, groupID string) (*SiteFirewallGroupResponse, error) {
+func (c *Client) SiteFirewallGroups(ctx context.Context, site string, groupID string) (*SiteFirewallGroupResponse, error) {
 	extPath := "rest/firewallgroup"
 	if groupID != "" {
 		extPath = extPath + "/" + strings.TrimSpace(groupID)
 	}
 
 	var resp SiteFirewallGroupResponse
-	err := c.doSiteRequest(http.MethodGet, site, extPath, nil, &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, extPath, nil, &resp)
 	return &resp, err
 }
 
@@ -81,7 +83,7 @@ type FirewallGroupMembers struct {
 // name - the name of the firewall group
 // groupType - the type of firewall group
 // groupMembers - the firewall group member configuration
-func (c *Client) CreateFirewallGroup(site string, name string, groupType FirewallGroupType, groupMembers FirewallGroupMembers) (*GenericResponse, error) {
+func (c *Client) CreateFirewallGroup(ctx context.Context, site string, name string, groupType FirewallGroupType, groupMembers FirewallGroupMembers) (*GenericResponse, error) {
 	if !groupType.IsValid() {
 		return nil, fmt.Errorf("invalid groupType specified: %s", groupType)
 	}
@@ -111,7 +113,7 @@ func (c *Client) CreateFirewallGroup(site string, name string, groupType Firewal
 	data, _ := json.Marshal(payload)
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "rest/firewallgroup", bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "rest/firewallgroup", bytes.NewReader(data), &resp)
 	return &resp, err
 }
 
@@ -122,7 +124,7 @@ func (c *Client) CreateFirewallGroup(site string, name string, groupType Firewal
 // name - the name of the firewall group
 // groupType - the type of firewall group, note you cannot change a group type
 // groupMembers - the firewall group member configuration
-func (c *Client) UpdateFirewallGroup(site string, siteID string, groupID string, name string, groupType FirewallGroupType, groupMembers FirewallGroupMembers) (*GenericResponse, error) {
+func (c *Client) UpdateFirewallGroup(ctx context.Context, site string, siteID string, groupID string, name string, groupType FirewallGroupType, groupMembers FirewallGroupMembers) (*GenericResponse, error) {
 	if !groupType.IsValid() {
 		return nil, fmt.Errorf("invalid groupType specified: %s", groupType)
 	}
@@ -156,7 +158,7 @@ func (c *Client) UpdateFirewallGroup(site string, siteID string, groupID string,
 	extPath := fmt.Sprintf("rest/firewallgroup/%s", strings.TrimSpace(groupID))
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, extPath, bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
 	return &resp, err
 }
 
@@ -164,10 +166,312 @@ func (c *Client) UpdateFirewallGroup(site string, siteID string, groupID string,
 // site - the site to modify
 // siteID - the ID of the site
 // groupID - the ID of the firewall group
-func (c *Client) DeleteFirewallGroup(site string, groupID string) (*GenericResponse, error) {
+func (c *Client) DeleteFirewallGroup(ctx context.Context, site string, groupID string) (*GenericResponse, error) {
 	extPath := fmt.Sprintf("rest/firewallgroup/%s", strings.TrimSpace(groupID))
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodDelete, site, extPath, nil, &resp)
+	err := c.doSiteRequest(ctx, http.MethodDelete, site, extPath, nil, &resp)
+	return &resp, err
+}
+
+// FirewallRuleset defines the firewall ruleset a rule belongs to
+type FirewallRuleset string
+
+// The supported firewall rulesets
+const (
+	FirewallRulesetWANIn      FirewallRuleset = "WAN_IN"
+	FirewallRulesetWANOut     FirewallRuleset = "WAN_OUT"
+	FirewallRulesetWANLocal   FirewallRuleset = "WAN_LOCAL"
+	FirewallRulesetLANIn      FirewallRuleset = "LAN_IN"
+	FirewallRulesetLANOut     FirewallRuleset = "LAN_OUT"
+	FirewallRulesetLANLocal   FirewallRuleset = "LAN_LOCAL"
+	FirewallRulesetGuestIn    FirewallRuleset = "GUEST_IN"
+	FirewallRulesetGuestOut   FirewallRuleset = "GUEST_OUT"
+	FirewallRulesetGuestLocal FirewallRuleset = "GUEST_LOCAL"
+	FirewallRulesetWANv6In    FirewallRuleset = "WANv6_IN"
+	FirewallRulesetWANv6Out   FirewallRuleset = "WANv6_OUT"
+	FirewallRulesetWANv6Local FirewallRuleset = "WANv6_LOCAL"
+	FirewallRulesetLANv6In    FirewallRuleset = "LANv6_IN"
+	FirewallRulesetLANv6Out   FirewallRuleset = "LANv6_OUT"
+	FirewallRulesetLANv6Local FirewallRuleset = "LANv6_LOCAL"
+)
+
+// IsValid returns true if it's a valid firewall ruleset.
+// there are only a few valid types
+func (r FirewallRuleset) IsValid() bool {
+	switch r {
+	case FirewallRulesetWANIn, FirewallRulesetWANOut, FirewallRulesetWANLocal:
+		fallthrough
+	case FirewallRulesetLANIn, FirewallRulesetLANOut, FirewallRulesetLANLocal:
+		fallthrough
+	case FirewallRulesetGuestIn, FirewallRulesetGuestOut, FirewallRulesetGuestLocal:
+		fallthrough
+	case FirewallRulesetWANv6In, FirewallRulesetWANv6Out, FirewallRulesetWANv6Local:
+		fallthrough
+	case FirewallRulesetLANv6In, FirewallRulesetLANv6Out, FirewallRulesetLANv6Local:
+		return true
+	default:
+		return false
+	}
+}
+
+// FirewallRuleAction defines the action taken when a firewall rule matches
+type FirewallRuleAction string
+
+// The supported firewall rule actions
+const (
+	FirewallRuleActionAccept FirewallRuleAction = "accept"
+	FirewallRuleActionDrop   FirewallRuleAction = "drop"
+	FirewallRuleActionReject FirewallRuleAction = "reject"
+)
+
+// IsValid returns true if it's a valid firewall rule action.
+// there are only a few valid types
+func (a FirewallRuleAction) IsValid() bool {
+	switch a {
+	case FirewallRuleActionAccept, FirewallRuleActionDrop, FirewallRuleActionReject:
+		return true
+	default:
+		return false
+	}
+}
+
+// FirewallRuleIndexBase is the first rule index available for user defined rules
+const FirewallRuleIndexBase = 2000
+
+// FirewallRule defines a typed firewall rule
+type FirewallRule struct {
+	ID                    string             `json:"_id,omitempty"`
+	SiteID                string             `json:"site_id,omitempty"`
+	Name                  string             `json:"name"`
+	Enabled               bool               `json:"enabled"`
+	Action                FirewallRuleAction `json:"action"`
+	Ruleset               FirewallRuleset    `json:"ruleset"`
+	RuleIndex             int                `json:"rule_index"`
+	Protocol              string             `json:"protocol"` // all, tcp, udp, tcp_udp, icmp, or a protocol number
+	ProtocolMatchExcepted bool               `json:"protocol_match_excepted"`
+	ProtocolV6            string             `json:"protocol_v6,omitempty"`
+	ICMPTypeName          string             `json:"icmp_typename,omitempty"`
+	ICMPv6TypeName        string             `json:"icmpv6_typename,omitempty"`
+	Logging               bool               `json:"logging"`
+	StateEstablished      bool               `json:"state_established"`
+	StateInvalid          bool               `json:"state_invalid"`
+	StateNew              bool               `json:"state_new"`
+	StateRelated          bool               `json:"state_related"`
+	IPSec                 string             `json:"ipsec"` // "", match-ipsec, match-none
+
+	SourceFirewallGroupIDs []string `json:"src_firewallgroup_ids"`
+	SourceMACAddress       string   `json:"src_mac_address"`
+	SourceAddress          string   `json:"src_address"`
+	SourcePort             string   `json:"src_port,omitempty"`
+	SourceNetworkConfID    string   `json:"src_networkconf_id"`
+	SourceNetworkConfType  string   `json:"src_networkconf_type"` // NETv4, ADDRv4
+
+	DestinationFirewallGroupIDs []string `json:"dst_firewallgroup_ids"`
+	DestinationAddress          string   `json:"dst_address"`
+	DestinationPort             string   `json:"dst_port,omitempty"`
+	DestinationNetworkConfID    string   `json:"dst_networkconf_id"`
+	DestinationNetworkConfType  string   `json:"dst_networkconf_type"` // NETv4, ADDRv4
+
+	AttrNoDelete bool   `json:"attr_no_delete,omitempty"`
+	AttrHiddenID string `json:"attr_hidden_id,omitempty"`
+}
+
+// FirewallRulesResponse contains the typed firewall rules response
+type FirewallRulesResponse struct {
+	Meta CommonMeta     `json:"meta"`
+	Data []FirewallRule `json:"data"`
+}
+
+// ListFirewallRules will list the typed firewall rules ordered by rule index
+// site - the site to query
+func (c *Client) ListFirewallRules(ctx context.Context, site string) (*FirewallRulesResponse, error) {
+	var resp FirewallRulesResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/firewallrule", nil, &resp)
+	sort.SliceStable(resp.Data, func(i, j int) bool {
+		return resp.Data[i].RuleIndex < resp.Data[j].RuleIndex
+	})
+	return &resp, err
+}
+
+// CreateFirewallRule will create a new firewall rule
+// site - the site to modify
+// rule - the firewall rule to create
+func (c *Client) CreateFirewallRule(ctx context.Context, site string, rule *FirewallRule) (*FirewallRulesResponse, error) {
+	if !rule.Ruleset.IsValid() {
+		return nil, fmt.Errorf("invalid ruleset specified: %s", rule.Ruleset)
+	}
+	if !rule.Action.IsValid() {
+		return nil, fmt.Errorf("invalid action specified: %s", rule.Action)
+	}
+	data, _ := json.Marshal(rule)
+
+	var resp FirewallRulesResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "rest/firewallrule", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// UpdateFirewallRule will update an existing firewall rule
+// site - the site to modify
+// rule - the firewall rule to update, the ID must be set
+func (c *Client) UpdateFirewallRule(ctx context.Context, site string, rule *FirewallRule) (*FirewallRulesResponse, error) {
+	if rule.ID == "" {
+		return nil, fmt.Errorf("must specify the firewall rule ID")
+	}
+	if !rule.Ruleset.IsValid() {
+		return nil, fmt.Errorf("invalid ruleset specified: %s", rule.Ruleset)
+	}
+	if !rule.Action.IsValid() {
+		return nil, fmt.Errorf("invalid action specified: %s", rule.Action)
+	}
+	data, _ := json.Marshal(rule)
+
+	extPath := fmt.Sprintf("rest/firewallrule/%s", strings.TrimSpace(rule.ID))
+
+	var resp FirewallRulesResponse
+	err := c.doSiteRequest(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// DeleteFirewallRule will delete an existing firewall rule
+// site - the site to modify
+// ruleID - the ID of the firewall rule
+func (c *Client) DeleteFirewallRule(ctx context.Context, site string, ruleID string) (*GenericResponse, error) {
+	extPath := fmt.Sprintf("rest/firewallrule/%s", strings.TrimSpace(ruleID))
+
+	var resp GenericResponse
+	err := c.doSiteRequest(ctx, http.MethodDelete, site, extPath, nil, &resp)
+	return &resp, err
+}
+
+// FirewallReorderError is returned when ReorderFirewallRules fails part way, the rules listed were already changed
+type FirewallReorderError struct {
+	Changed []FirewallRule // the rules whose index was changed, with the index they have now
+	Err     error
+}
+
+// Error implements error
+func (e *FirewallReorderError) Error() string {
+	names := make([]string, 0, len(e.Changed))
+	for _, rule := range e.Changed {
+		names = append(names, fmt.Sprintf("%s (%d)", rule.Name, rule.RuleIndex))
+	}
+	return fmt.Sprintf("firewall rule reorder failed after changing %d rules [%s]: %s", len(e.Changed), strings.Join(names, ", "), e.Err)
+}
+
+// Unwrap returns the error of the failed update
+func (e *FirewallReorderError) Unwrap() error {
+	return e.Err
+}
+
+// ReorderFirewallRules will reassign rule indexes within a ruleset so they follow the given order.
+// The rules that move are first parked on free indexes above the highest index of the ruleset so no two rules
+// ever share an index, then moved to their targets. On failure a *FirewallReorderError lists the rules already changed.
+// site - the site to modify
+// ruleset - the ruleset being reordered
+// ruleIDs - the rule IDs in the desired order, rules in the ruleset that are not listed keep their relative order after these
+func (c *Client) ReorderFirewallRules(ctx context.Context, site string, ruleset FirewallRuleset, ruleIDs ...string) error {
+	if !ruleset.IsValid() {
+		return fmt.Errorf("invalid ruleset specified: %s", ruleset)
+	}
+
+	rules, err := c.ListFirewallRules(ctx, site)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[string]FirewallRule)
+	remaining := make([]FirewallRule, 0)
+	maxIndex := FirewallRuleIndexBase
+	for _, rule := range rules.Data {
+		if rule.Ruleset != ruleset {
+			continue
+		}
+		byID[rule.ID] = rule
+		remaining = append(remaining, rule)
+		if rule.RuleIndex > maxIndex {
+			maxIndex = rule.RuleIndex
+		}
+	}
+
+	ordered := make([]FirewallRule, 0, len(remaining))
+	seen := make(map[string]struct{})
+	for _, id := range ruleIDs {
+		rule, ok := byID[id]
+		if !ok {
+			return fmt.Errorf("firewall rule %s not found in ruleset %s", id, ruleset)
+		}
+		ordered = append(ordered, rule)
+		seen[id] = struct{}{}
+	}
+	for _, rule := range remaining {
+		if _, ok := seen[rule.ID]; !ok {
+			ordered = append(ordered, rule)
+		}
+	}
+
+	// park the moving rules above the highest index held or targeted, the targets are the first indexes
+	if last := FirewallRuleIndexBase + len(ordered) - 1; last > maxIndex {
+		maxIndex = last
+	}
+	var moving, parking []int
+	for i := range ordered {
+		if ordered[i].RuleIndex == FirewallRuleIndexBase+i {
+			continue
+		}
+		moving = append(moving, i)
+		parking = append(parking, maxIndex+len(moving))
+	}
+
+	var changed []*FirewallRule
+	update := func(rule *FirewallRule, index int) error {
+		previous := rule.RuleIndex
+		rule.RuleIndex = index
+		if _, err := c.UpdateFirewallRule(ctx, site, rule); err != nil {
+			rule.RuleIndex = previous
+			reorderErr := &FirewallReorderError{Err: err}
+			for _, r := range changed {
+				reorderErr.Changed = append(reorderErr.Changed, *r)
+			}
+			return reorderErr
+		}
+		if previous == byID[rule.ID].RuleIndex {
+			changed = append(changed, rule)
+		}
+		return nil
+	}
+	for k, i := range moving {
+		if err := update(&ordered[i], parking[k]); err != nil {
+			return err
+		}
+	}
+	for _, i := range moving {
+		if err := update(&ordered[i], FirewallRuleIndexBase+i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FirewallGroup defines a typed firewall group
+type FirewallGroup struct {
+	ID           string            `json:"_id,omitempty"`
+	SiteID       string            `json:"site_id,omitempty"`
+	Name         string            `json:"name"`
+	GroupType    FirewallGroupType `json:"group_type"`
+	GroupMembers []string          `json:"group_members"`
+}
+
+// FirewallGroupsResponse contains the typed firewall groups response
+type FirewallGroupsResponse struct {
+	Meta CommonMeta      `json:"meta"`
+	Data []FirewallGroup `json:"data"`
+}
+
+// ListFirewallGroups will list the typed firewall groups
+// site - the site to query
+func (c *Client) ListFirewallGroups(ctx context.Context, site string) (*FirewallGroupsResponse, error) {
+	var resp FirewallGroupsResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/firewallgroup", nil, &resp)
 	return &resp, err
 }
@@ -0,0 +1,130 @@
+package unifi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newFirewallController serves the firewall rules of the default site, rejecting updates to an index already in use.
+// The indexes of the updates are appended to indexes when it is not nil.
+func newFirewallController(t *testing.T, rules []FirewallRule, failOn int, indexes *[]int) *httptest.Server {
+	var mu sync.Mutex
+	updates := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/s/default/rest/firewallrule":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"meta": map[string]string{"rc": "ok"}, "data": rules})
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/api/s/default/rest/firewallrule/"):
+			var rule FirewallRule
+			_ = json.NewDecoder(r.Body).Decode(&rule)
+			updates++
+			if indexes != nil {
+				*indexes = append(*indexes, rule.RuleIndex)
+			}
+			if updates == failOn {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"meta":{"rc":"error","msg":"api.err.Invalid"},"data":[]}`))
+				return
+			}
+			for i := range rules {
+				if rules[i].ID != rule.ID && rules[i].Ruleset == rule.Ruleset && rules[i].RuleIndex == rule.RuleIndex {
+					t.Errorf("rule %s moved to index %d held by rule %s", rule.ID, rule.RuleIndex, rules[i].ID)
+				}
+			}
+			for i := range rules {
+				if rules[i].ID == rule.ID {
+					rules[i].RuleIndex = rule.RuleIndex
+				}
+			}
+			_, _ = fmt.Fprint(w, `{"meta":{"rc":"ok"},"data":[]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func testFirewallRules() []FirewallRule {
+	rules := make([]FirewallRule, 0, 4)
+	for i, id := range []string{"a", "b", "c", "d"} {
+		rules = append(rules, FirewallRule{
+			ID: id, Name: id, Ruleset: FirewallRulesetLANIn, Action: FirewallRuleActionAccept,
+			RuleIndex: FirewallRuleIndexBase + i,
+		})
+	}
+	return rules
+}
+
+func TestReorderFirewallRules(t *testing.T) {
+	rules := testFirewallRules()
+	srv := newFirewallController(t, rules, 0, nil)
+	c, err := NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetUniFiOS(false)
+
+	if err := c.ReorderFirewallRules(context.Background(), "default", FirewallRulesetLANIn, "c", "a", "b"); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"c": 2000, "a": 2001, "b": 2002, "d": 2003}
+	for _, rule := range rules {
+		if rule.RuleIndex != want[rule.ID] {
+			t.Errorf("rule %s has index %d, want %d", rule.ID, rule.RuleIndex, want[rule.ID])
+		}
+	}
+}
+
+func TestReorderFirewallRulesParksAboveHighestIndex(t *testing.T) {
+	rules := []FirewallRule{
+		{ID: "a", Name: "a", Ruleset: FirewallRulesetLANIn, Action: FirewallRuleActionAccept, RuleIndex: 2000},
+		{ID: "b", Name: "b", Ruleset: FirewallRulesetLANIn, Action: FirewallRuleActionAccept, RuleIndex: 2003},
+		{ID: "c", Name: "c", Ruleset: FirewallRulesetLANIn, Action: FirewallRuleActionAccept, RuleIndex: 2010},
+	}
+	var indexes []int
+	srv := newFirewallController(t, rules, 0, &indexes)
+	c, err := NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetUniFiOS(false)
+
+	if err := c.ReorderFirewallRules(context.Background(), "default", FirewallRulesetLANIn, "c", "b", "a"); err != nil {
+		t.Fatal(err)
+	}
+	// every rule moves, each is parked above 2010 before moving to its target
+	want := []int{2011, 2012, 2013, 2000, 2001, 2002}
+	if fmt.Sprint(indexes) != fmt.Sprint(want) {
+		t.Errorf("updated indexes %v, want %v", indexes, want)
+	}
+}
+
+func TestReorderFirewallRulesReportsChangedRules(t *testing.T) {
+	srv := newFirewallController(t, testFirewallRules(), 2, nil)
+	c, err := NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetUniFiOS(false)
+
+	err = c.ReorderFirewallRules(context.Background(), "default", FirewallRulesetLANIn, "c", "a", "b")
+	var reorderErr *FirewallReorderError
+	if !errors.As(err, &reorderErr) {
+		t.Fatalf("expected a *FirewallReorderError, got %v", err)
+	}
+	// c is parked first, the update of a fails
+	if len(reorderErr.Changed) != 1 || reorderErr.Changed[0].ID != "c" || reorderErr.Changed[0].RuleIndex != 2004 {
+		t.Errorf("expected only rule c to be parked, got %+v", reorderErr.Changed)
+	}
+}
@@ -0,0 +1,212 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// FirewallZone defines a firewall zone of the zone-based firewall (Network 9.x and newer)
+type FirewallZone struct {
+	ID         string   `json:"_id,omitempty"`
+	Name       string   `json:"name"`
+	ZoneKey    string   `json:"zone_key,omitempty"` // internal, external, gateway, vpn, hotspot, dmz - empty for custom zones
+	NetworkIDs []string `json:"network_ids"`
+}
+
+// IsCustom returns true if the zone was created by a user
+func (z FirewallZone) IsCustom() bool {
+	return z.ZoneKey == ""
+}
+
+// ListZones will list the firewall zones
+// site - the site to query
+func (c *Client) ListZones(ctx context.Context, site string) ([]FirewallZone, error) {
+	var zones []FirewallZone
+	err := c.doSiteV2Request(ctx, http.MethodGet, site, "firewall/zone", nil, &zones)
+	return zones, err
+}
+
+// CreateZone will create a new custom firewall zone
+// site - the site to modify
+// zone - the zone to create
+func (c *Client) CreateZone(ctx context.Context, site string, zone *FirewallZone) (*FirewallZone, error) {
+	if zone.Name == "" {
+		return nil, fmt.Errorf("must specify the zone name")
+	}
+	if zone.NetworkIDs == nil {
+		zone.NetworkIDs = make([]string, 0)
+	}
+	data, _ := json.Marshal(zone)
+
+	var created FirewallZone
+	err := c.doSiteV2Request(ctx, http.MethodPost, site, "firewall/zone", bytes.NewReader(data), &created)
+	return &created, err
+}
+
+// UpdateZone will update an existing firewall zone, e.g. to move networks between zones
+// site - the site to modify
+// zone - the zone to update, the ID must be set
+func (c *Client) UpdateZone(ctx context.Context, site string, zone *FirewallZone) (*FirewallZone, error) {
+	if zone.ID == "" {
+		return nil, fmt.Errorf("must specify the zone ID")
+	}
+	if zone.NetworkIDs == nil {
+		zone.NetworkIDs = make([]string, 0)
+	}
+	data, _ := json.Marshal(zone)
+
+	extPath := fmt.Sprintf("firewall/zone/%s", strings.TrimSpace(zone.ID))
+
+	var updated FirewallZone
+	err := c.doSiteV2Request(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &updated)
+	return &updated, err
+}
+
+// DeleteZone will delete a custom firewall zone
+// site - the site to modify
+// zoneID - the ID of the zone
+func (c *Client) DeleteZone(ctx context.Context, site string, zoneID string) error {
+	extPath := fmt.Sprintf("firewall/zone/%s", strings.TrimSpace(zoneID))
+	return c.doSiteV2Request(ctx, http.MethodDelete, site, extPath, nil, nil)
+}
+
+// FirewallPolicyAction defines the action of a zone policy
+type FirewallPolicyAction string
+
+// The supported zone policy actions
+const (
+	FirewallPolicyActionAllow  FirewallPolicyAction = "ALLOW"
+	FirewallPolicyActionBlock  FirewallPolicyAction = "BLOCK"
+	FirewallPolicyActionReject FirewallPolicyAction = "REJECT"
+)
+
+// IsValid returns true if it's a valid zone policy action.
+// there are only a few valid types
+func (a FirewallPolicyAction) IsValid() bool {
+	switch a {
+	case FirewallPolicyActionAllow, FirewallPolicyActionBlock, FirewallPolicyActionReject:
+		return true
+	default:
+		return false
+	}
+}
+
+// FirewallPolicyEndpoint defines the source or destination of a zone policy
+type FirewallPolicyEndpoint struct {
+	ZoneID           string   `json:"zone_id"`
+	MatchingTarget   string   `json:"matching_target"` // ANY, IP, NETWORK, CLIENT, REGION
+	IPs              []string `json:"ips,omitempty"`
+	NetworkIDs       []string `json:"network_ids,omitempty"`
+	ClientMACs       []string `json:"client_macs,omitempty"`
+	Regions          []string `json:"regions,omitempty"`
+	MatchOppositeIPs bool     `json:"match_opposite_ips"`
+	PortMatchingType string   `json:"port_matching_type"` // ANY, SPECIFIC or OBJECT
+	Port             string   `json:"port,omitempty"`     // a port, range `8000-8010` or list `80,443`
+}
+
+// FirewallPolicy defines a zone policy, allowing or blocking traffic between two zones
+type FirewallPolicy struct {
+	ID                  string                 `json:"_id,omitempty"`
+	Name                string                 `json:"name"`
+	Description         string                 `json:"description,omitempty"`
+	Enabled             bool                   `json:"enabled"`
+	Action              FirewallPolicyAction   `json:"action"`
+	Index               int                    `json:"index,omitempty"`
+	Predefined          bool                   `json:"predefined,omitempty"`
+	Protocol            string                 `json:"protocol"`   // all, tcp, udp, tcp_udp, icmp
+	IPVersion           string                 `json:"ip_version"` // BOTH, IPV4 or IPV6
+	Logging             bool                   `json:"logging"`
+	ConnectionStateType string                 `json:"connection_state_type"` // ALL, RESPOND_ONLY or CUSTOM
+	ConnectionStates    []string               `json:"connection_states,omitempty"`
+	CreateAllowRespond  bool                   `json:"create_allow_respond"`
+	Schedule            TrafficSchedule        `json:"schedule"`
+	Source              FirewallPolicyEndpoint `json:"source"`
+	Destination         FirewallPolicyEndpoint `json:"destination"`
+}
+
+// validateFirewallPolicy checks the policy action and fills the defaults
+func validateFirewallPolicy(policy *FirewallPolicy) error {
+	if !policy.Action.IsValid() {
+		return fmt.Errorf("invalid policy action specified: %s", policy.Action)
+	}
+	if policy.Source.ZoneID == "" || policy.Destination.ZoneID == "" {
+		return fmt.Errorf("must specify the source and destination zone")
+	}
+	if policy.Protocol == "" {
+		policy.Protocol = "all"
+	}
+	if policy.IPVersion == "" {
+		policy.IPVersion = "BOTH"
+	}
+	if policy.ConnectionStateType == "" {
+		policy.ConnectionStateType = "ALL"
+	}
+	if policy.Schedule.Mode == "" {
+		policy.Schedule.Mode = "ALWAYS"
+	}
+	for _, e := range []*FirewallPolicyEndpoint{&policy.Source, &policy.Destination} {
+		if e.MatchingTarget == "" {
+			e.MatchingTarget = "ANY"
+		}
+		if e.PortMatchingType == "" {
+			e.PortMatchingType = "ANY"
+		}
+	}
+	return nil
+}
+
+// ListFirewallPolicies will list the zone policies
+// site - the site to query
+func (c *Client) ListFirewallPolicies(ctx context.Context, site string) ([]FirewallPolicy, error) {
+	var policies []FirewallPolicy
+	err := c.doSiteV2Request(ctx, http.MethodGet, site, "firewall-policies", nil, &policies)
+	return policies, err
+}
+
+// CreateFirewallPolicy will create a new zone policy
+// site - the site to modify
+// policy - the zone policy to create
+func (c *Client) CreateFirewallPolicy(ctx context.Context, site string, policy *FirewallPolicy) (*FirewallPolicy, error) {
+	if err := validateFirewallPolicy(policy); err != nil {
+		return nil, err
+	}
+	data, _ := json.Marshal(policy)
+
+	var created FirewallPolicy
+	err := c.doSiteV2Request(ctx, http.MethodPost, site, "firewall-policies", bytes.NewReader(data), &created)
+	return &created, err
+}
+
+// UpdateFirewallPolicy will update an existing zone policy
+// site - the site to modify
+// policy - the zone policy to update, the ID must be set
+func (c *Client) UpdateFirewallPolicy(ctx context.Context, site string, policy *FirewallPolicy) (*FirewallPolicy, error) {
+	if policy.ID == "" {
+		return nil, fmt.Errorf("must specify the policy ID")
+	}
+	if err := validateFirewallPolicy(policy); err != nil {
+		return nil, err
+	}
+	data, _ := json.Marshal(policy)
+
+	extPath := fmt.Sprintf("firewall-policies/%s", strings.TrimSpace(policy.ID))
+
+	var updated FirewallPolicy
+	err := c.doSiteV2Request(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &updated)
+	return &updated, err
+}
+
+// DeleteFirewallPolicies will delete zone policies, predefined policies can not be deleted
+// site - the site to modify
+// policyIDs - the IDs of the zone policies
+func (c *Client) DeleteFirewallPolicies(ctx context.Context, site string, policyIDs ...string) error {
+	if len(policyIDs) == 0 {
+		return nil
+	}
+	data, _ := json.Marshal(policyIDs)
+	return c.doSiteV2Request(ctx, http.MethodPost, site, "firewall-policies/batch-delete", bytes.NewReader(data), nil)
+}
@@ -0,0 +1,180 @@
+package unifi
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultUpgradeOrder upgrades access points first and gateways last so the controller stays reachable
+var DefaultUpgradeOrder = []DeviceType{
+	DeviceTypeAccessPoint,
+	DeviceTypeSwitch,
+	DeviceTypeGateway,
+	DeviceTypeDreamMachine,
+}
+
+// UpgradeStage defines the stage of a device upgrade reported to the progress callback
+type UpgradeStage string
+
+// The upgrade stages
+const (
+	UpgradeStageStarted   UpgradeStage = "started"   // the device accepted the upgrade command
+	UpgradeStageCompleted UpgradeStage = "completed" // the device reconnected with the new firmware, only with WaitPerType
+	UpgradeStageFailed    UpgradeStage = "failed"
+)
+
+// UpgradeProgress is reported for every device of an UpgradeAll rollout
+type UpgradeProgress struct {
+	Device Device
+	Stage  UpgradeStage
+	Err    error // only set for UpgradeStageFailed
+	Done   int   // the number of devices that completed or failed so far, or were started when not waiting
+	Total  int   // the total number of devices to upgrade
+}
+
+// UpgradeAllOptions configures an UpgradeAll rollout
+type UpgradeAllOptions struct {
+	Order           []DeviceType          // the device types to upgrade first in order, defaults to DefaultUpgradeOrder, other types follow
+	Stagger         time.Duration         // delay between starting upgrades of devices of the same type
+	WaitPerType     bool                  // wait for all devices of a type to finish before starting the next type, bounded by the context
+	PollInterval    time.Duration         // device state poll interval when waiting, defaults to 15 seconds
+	ContinueOnError bool                  // keep upgrading the remaining devices when an upgrade can not be started
+	Progress        func(UpgradeProgress) // optional progress callback
+}
+
+// UpgradeAll will upgrade every adopted device with a pending firmware upgrade, one device type at a time.
+// The types of the order go first, the types missing from it follow in name order.
+// Without WaitPerType devices are only reported as started, the rollout does not wait for the upgrades to finish.
+// site - the site to upgrade
+// opts - the rollout options
+func (c *Client) UpgradeAll(ctx context.Context, site string, opts UpgradeAllOptions) error {
+	if len(opts.Order) == 0 {
+		opts.Order = DefaultUpgradeOrder
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 15 * time.Second
+	}
+
+	resp, err := c.ListDevices(ctx, site)
+	if err != nil {
+		return err
+	}
+	byType := make(map[DeviceType][]Device)
+	total := 0
+	for _, d := range resp.Data {
+		if d.Adopted && d.Upgradable {
+			byType[d.Type] = append(byType[d.Type], d)
+			total++
+		}
+	}
+
+	progress := func(p UpgradeProgress) {
+		if opts.Progress != nil {
+			p.Total = total
+			opts.Progress(p)
+		}
+	}
+
+	done := 0
+	for _, deviceType := range upgradeOrder(opts.Order, byType) {
+		devices := byType[deviceType]
+		started := make([]Device, 0, len(devices))
+		for i, d := range devices {
+			if i > 0 && opts.Stagger > 0 {
+				if err := sleepContext(ctx, opts.Stagger); err != nil {
+					return err
+				}
+			}
+			if _, err := c.UpgradeDevice(ctx, site, d.MAC); err != nil {
+				done++
+				progress(UpgradeProgress{Device: d, Stage: UpgradeStageFailed, Err: err, Done: done})
+				if !opts.ContinueOnError {
+					return errors.Wrap(err, "unable to upgrade device "+d.MAC)
+				}
+				continue
+			}
+			if !opts.WaitPerType {
+				done++
+			}
+			progress(UpgradeProgress{Device: d, Stage: UpgradeStageStarted, Done: done})
+			started = append(started, d)
+		}
+
+		if !opts.WaitPerType {
+			continue
+		}
+		if err := c.waitForUpgrades(ctx, site, started, opts.PollInterval, func(d Device) {
+			done++
+			progress(UpgradeProgress{Device: d, Stage: UpgradeStageCompleted, Done: done})
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// upgradeOrder returns the device types of the order followed by the other types of the devices in name order
+func upgradeOrder(order []DeviceType, byType map[DeviceType][]Device) []DeviceType {
+	ret := make([]DeviceType, 0, len(order)+len(byType))
+	listed := make(map[DeviceType]bool, len(order))
+	for _, deviceType := range order {
+		if !listed[deviceType] {
+			listed[deviceType] = true
+			ret = append(ret, deviceType)
+		}
+	}
+	var others []DeviceType
+	for deviceType := range byType {
+		if !listed[deviceType] {
+			others = append(others, deviceType)
+		}
+	}
+	sort.Slice(others, func(i, j int) bool {
+		return others[i] < others[j]
+	})
+	return append(ret, others...)
+}
+
+// waitForUpgrades polls the devices until none of them is upgrading or the context is done
+func (c *Client) waitForUpgrades(ctx context.Context, site string, devices []Device, interval time.Duration, completed func(Device)) error {
+	pending := make(map[string]bool, len(devices))
+	for _, d := range devices {
+		pending[d.MAC] = true
+	}
+	for len(pending) > 0 {
+		if err := sleepContext(ctx, interval); err != nil {
+			return err
+		}
+		macs := make([]string, 0, len(pending))
+		for mac := range pending {
+			macs = append(macs, mac)
+		}
+		resp, err := c.ListDevices(ctx, site, macs...)
+		if err != nil {
+			// the controller may be briefly unavailable while devices reboot
+			continue
+		}
+		for _, d := range resp.Data {
+			if pending[d.MAC] && d.State == DeviceStateConnected && !d.Upgradable {
+				delete(pending, d.MAC)
+				completed(d)
+			}
+		}
+	}
+	return nil
+}
+
+// sleepContext sleeps for the duration or until the context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
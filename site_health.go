@@ -1,6 +1,7 @@
 package unifi
 
 import (
+	"context"
 	"net/http"
 )
 
@@ -39,6 +40,42 @@ type SiteHealthData struct {
 	XPutUp   float64 `json:"xput_up"`
 
 	LANIP string `json:"lan_ip"`
+
+	// vpn
+	RemoteUserEnabled        bool  `json:"remote_user_enabled"`
+	RemoteUserNumberActive   int   `json:"remote_user_num_active"`
+	RemoteUserNumberInactive int   `json:"remote_user_num_inactive"`
+	RemoteUserRXBytes        int64 `json:"remote_user_rx_bytes"`
+	RemoteUserTXBytes        int64 `json:"remote_user_tx_bytes"`
+	SiteToSiteEnabled        bool  `json:"site_to_site_enabled"`
+	SiteToSiteNumberActive   int   `json:"site_to_site_num_active"`
+	SiteToSiteRXBytes        int64 `json:"site_to_site_rx_bytes"`
+	SiteToSiteTXBytes        int64 `json:"site_to_site_tx_bytes"`
+}
+
+// HealthSubsystem defines the health subsystem names
+type HealthSubsystem string
+
+// The known health subsystems
+const (
+	HealthSubsystemWAN  HealthSubsystem = "wan"
+	HealthSubsystemWWW  HealthSubsystem = "www"
+	HealthSubsystemLAN  HealthSubsystem = "lan"
+	HealthSubsystemWLAN HealthSubsystem = "wlan"
+	HealthSubsystemVPN  HealthSubsystem = "vpn"
+)
+
+// Health status values reported per subsystem
+const (
+	HealthStatusOK      = "ok"
+	HealthStatusWarning = "warning"
+	HealthStatusError   = "error"
+	HealthStatusUnknown = "unknown"
+)
+
+// IsOK returns true if the subsystem reports a healthy status
+func (d SiteHealthData) IsOK() bool {
+	return d.Status == HealthStatusOK
 }
 
 // SiteHealthResponse contains the site health response data from stat/health
@@ -49,8 +86,43 @@ type SiteHealthResponse struct {
 
 // SiteHealth queries the site for its health
 // site - the site to query
-func (c *Client) SiteHealth(site string) (*SiteHealthResponse, error) {
+func (c *Client) SiteHealth(ctx context.Context, site string) (*SiteHealthResponse, error) {
 	var resp SiteHealthResponse
-	err := c.doSiteRequest(http.MethodGet, site, "stat/health", nil, &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "stat/health", nil, &resp)
 	return &resp, err
 }
+
+// Subsystem returns the health data for the subsystem, or nil if the controller did not report it
+func (r *SiteHealthResponse) Subsystem(subsystem HealthSubsystem) *SiteHealthData {
+	for i := range r.Data {
+		if r.Data[i].SubSystem == string(subsystem) {
+			return &r.Data[i]
+		}
+	}
+	return nil
+}
+
+// WAN returns the wan subsystem health, or nil if not reported
+func (r *SiteHealthResponse) WAN() *SiteHealthData {
+	return r.Subsystem(HealthSubsystemWAN)
+}
+
+// WWW returns the internet connectivity health, or nil if not reported
+func (r *SiteHealthResponse) WWW() *SiteHealthData {
+	return r.Subsystem(HealthSubsystemWWW)
+}
+
+// LAN returns the lan subsystem health, or nil if not reported
+func (r *SiteHealthResponse) LAN() *SiteHealthData {
+	return r.Subsystem(HealthSubsystemLAN)
+}
+
+// WLAN returns the wlan subsystem health, or nil if not reported
+func (r *SiteHealthResponse) WLAN() *SiteHealthData {
+	return r.Subsystem(HealthSubsystemWLAN)
+}
+
+// VPN returns the vpn subsystem health, or nil if not reported
+func (r *SiteHealthResponse) VPN() *SiteHealthData {
+	return r.Subsystem(HealthSubsystemVPN)
+}
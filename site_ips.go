@@ -0,0 +1,234 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// IPSMode defines the threat management mode of the gateway
+type IPSMode string
+
+// The supported threat management modes
+const (
+	IPSModeDisabled  IPSMode = "disabled"
+	IPSModeIDS       IPSMode = "ids"       // detect and alert only
+	IPSModeIPS       IPSMode = "ips"       // detect, alert and block
+	IPSModeIPSInline IPSMode = "ipsInline" // inline blocking, UniFi OS gateways only
+)
+
+// IsValid returns true if it's a valid threat management mode.
+// there are only a few valid types
+func (m IPSMode) IsValid() bool {
+	switch m {
+	case IPSModeDisabled, IPSModeIDS, IPSModeIPS, IPSModeIPSInline:
+		return true
+	default:
+		return false
+	}
+}
+
+// IPSSuppressionAlert suppresses an alert signature, optionally only for some traffic
+type IPSSuppressionAlert struct {
+	ID        int                    `json:"id"` // the signature id
+	Signature string                 `json:"signature,omitempty"`
+	Category  string                 `json:"category,omitempty"`
+	Type      string                 `json:"type"` // all or track
+	Tracking  []IPSSuppressionTarget `json:"tracking,omitempty"`
+}
+
+// IPSSuppressionTarget defines the traffic an alert suppression or allow list entry applies to
+type IPSSuppressionTarget struct {
+	Direction string `json:"direction"` // src, dest or both
+	Mode      string `json:"mode"`      // ip, subnet or network
+	Value     string `json:"value"`
+}
+
+// IPSSuppression defines the suppressed alerts and allow listed traffic
+type IPSSuppression struct {
+	Alerts    []IPSSuppressionAlert  `json:"alerts"`
+	Whitelist []IPSSuppressionTarget `json:"whitelist"`
+}
+
+// IPSSettings contains the threat management settings, the ips setting section
+type IPSSettings struct {
+	ID     string `json:"_id,omitempty"`
+	SiteID string `json:"site_id,omitempty"`
+	Key    string `json:"key,omitempty"`
+
+	Mode                IPSMode        `json:"ips_mode"`
+	EnabledCategories   []string       `json:"enabled_categories"`
+	EnabledNetworks     []string       `json:"enabled_networks,omitempty"`
+	Suppression         IPSSuppression `json:"suppression"`
+	AdBlockingEnabled   bool           `json:"ad_blocking_enabled"`
+	DNSFiltering        bool           `json:"dns_filtering"`
+	HoneypotEnabled     bool           `json:"honeypot_enabled"`
+	RestrictTor         bool           `json:"restrict_tor"`
+	RestrictIPAddresses bool           `json:"restrict_ip_addresses"`
+}
+
+// GetIPSSettings returns the threat management settings
+// site - the site to query
+func (c *Client) GetIPSSettings(ctx context.Context, site string) (*IPSSettings, error) {
+	var settings IPSSettings
+	err := c.GetSetting(ctx, site, "ips", &settings)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateIPSSettings will update the threat management settings
+// site - the site to modify
+// settings - the settings to apply, the ID must be set, see GetIPSSettings
+func (c *Client) UpdateIPSSettings(ctx context.Context, site string, settings *IPSSettings) (*IPSSettings, error) {
+	if !settings.Mode.IsValid() {
+		return nil, fmt.Errorf("invalid ips mode specified: %s", settings.Mode)
+	}
+	if settings.EnabledCategories == nil {
+		settings.EnabledCategories = make([]string, 0)
+	}
+	if settings.Suppression.Alerts == nil {
+		settings.Suppression.Alerts = make([]IPSSuppressionAlert, 0)
+	}
+	if settings.Suppression.Whitelist == nil {
+		settings.Suppression.Whitelist = make([]IPSSuppressionTarget, 0)
+	}
+	settings.Key = "ips"
+	var updated IPSSettings
+	err := c.UpdateSetting(ctx, site, "ips", settings.ID, settings, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// IPSEventFilter defines the filters applied when listing IPS/IDS events
+type IPSEventFilter struct {
+	Start  time.Time      // defaults to 24 hours before End
+	End    time.Time      // defaults to now
+	Offset int            // pagination offset
+	Limit  int            // pagination limit, defaults to 100, at most 10000
+	Order  EventSortOrder // defaults to newest first
+}
+
+// ipsEventsResponse contains the stat/ips/event response
+type ipsEventsResponse struct {
+	Meta CommonMeta      `json:"meta"`
+	Data []EventIPSAlert `json:"data"`
+}
+
+// ListIPSEvents lists the intrusion alerts raised by the gateway
+// site - site to query
+// filter - the event filters
+func (c *Client) ListIPSEvents(ctx context.Context, site string, filter IPSEventFilter) ([]EventIPSAlert, error) {
+	if filter.End.IsZero() {
+		filter.End = time.Now().UTC()
+	}
+	if filter.Start.IsZero() {
+		filter.Start = filter.End.Add(-24 * time.Hour)
+	}
+	if !filter.Start.Before(filter.End) {
+		return nil, fmt.Errorf("end time must come after start time")
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 100
+	} else if filter.Limit > 10000 {
+		// there is a default max
+		filter.Limit = 10000
+	}
+	if filter.Order == "" {
+		filter.Order = EventSortOrderTimeDescending
+	}
+	if !filter.Order.IsValid() {
+		return nil, fmt.Errorf("invalid sort order: %s", filter.Order)
+	}
+
+	payload := map[string]interface{}{
+		"_sort":  string(filter.Order),
+		"start":  filter.Start.UTC().Unix() * 1000,
+		"end":    filter.End.UTC().Unix() * 1000,
+		"_start": filter.Offset,
+		"_limit": filter.Limit,
+	}
+	data, _ := json.Marshal(&payload)
+
+	var resp ipsEventsResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "stat/ips/event", bytes.NewReader(data), &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// Threat summarizes the alerts raised for a single signature
+type Threat struct {
+	SignatureID int
+	Signature   string
+	Category    string
+	Severity    int
+	Action      string // the action taken on the last alert, e.g. `allowed` or `blocked`
+	Count       int
+	FirstSeen   time.Time
+	LastSeen    time.Time
+	SourceIPs   []string
+}
+
+// ListThreats lists the intrusion alerts grouped by signature, the most frequent first.
+// Every alert of the time range is fetched, starting at the filter offset, the filter limit is the page size.
+// site - site to query
+// filter - the event filters
+func (c *Client) ListThreats(ctx context.Context, site string, filter IPSEventFilter) ([]Threat, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 10000
+	}
+	events, err := c.IPSEventsPager(site, filter).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bySignature := make(map[int]*Threat)
+	sources := make(map[int]map[string]bool)
+	threats := make([]*Threat, 0)
+	for _, e := range events {
+		seen := e.EventTime()
+		t, ok := bySignature[e.InnerAlertSignatureID]
+		if !ok {
+			t = &Threat{
+				SignatureID: e.InnerAlertSignatureID,
+				Signature:   e.InnerAlertSignature,
+				Category:    e.InnerAlertCategory,
+				Severity:    e.InnerAlertSeverity,
+				FirstSeen:   seen,
+			}
+			bySignature[e.InnerAlertSignatureID] = t
+			sources[e.InnerAlertSignatureID] = make(map[string]bool)
+			threats = append(threats, t)
+		}
+		t.Count++
+		if seen.Before(t.FirstSeen) {
+			t.FirstSeen = seen
+		}
+		if !seen.Before(t.LastSeen) {
+			t.LastSeen = seen
+			t.Action = e.InnerAlertAction
+		}
+		if e.SourceIP != "" && !sources[e.InnerAlertSignatureID][e.SourceIP] {
+			sources[e.InnerAlertSignatureID][e.SourceIP] = true
+			t.SourceIPs = append(t.SourceIPs, e.SourceIP)
+		}
+	}
+
+	sort.SliceStable(threats, func(i, j int) bool {
+		return threats[i].Count > threats[j].Count
+	})
+	ret := make([]Threat, 0, len(threats))
+	for _, t := range threats {
+		ret = append(ret, *t)
+	}
+	return ret, nil
+}
@@ -0,0 +1,84 @@
+package unifi
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// eveTimeFormat is the timestamp format of Suricata EVE records
+const eveTimeFormat = "2006-01-02T15:04:05.000000-0700"
+
+// EVEAlert is the alert object of a Suricata EVE alert record
+type EVEAlert struct {
+	Action      string `json:"action"` // `allowed` or `blocked`
+	GID         int    `json:"gid"`
+	SignatureID int    `json:"signature_id"`
+	Rev         int    `json:"rev"`
+	Signature   string `json:"signature"`
+	Category    string `json:"category"`
+	Severity    int    `json:"severity"` // 1 is the most severe
+}
+
+// EVERecord is a Suricata EVE JSON alert record, the format IDS tooling and SIEMs ingest natively
+type EVERecord struct {
+	Timestamp string   `json:"timestamp"`
+	FlowID    int64    `json:"flow_id,omitempty"`
+	InIface   string   `json:"in_iface,omitempty"`
+	EventType string   `json:"event_type"`
+	SrcIP     string   `json:"src_ip"`
+	SrcPort   int      `json:"src_port,omitempty"`
+	DestIP    string   `json:"dest_ip"`
+	DestPort  int      `json:"dest_port,omitempty"`
+	Proto     string   `json:"proto,omitempty"`
+	AppProto  string   `json:"app_proto,omitempty"`
+	Host      string   `json:"host,omitempty"` // the gateway that raised the alert
+	Alert     EVEAlert `json:"alert"`
+}
+
+// DecodeIPSAlert decodes a raw event, e.g. the data of a stream event, as an intrusion prevention alert
+func DecodeIPSAlert(data []byte) (*EventIPSAlert, error) {
+	var alert EventIPSAlert
+	if err := json.Unmarshal(data, &alert); err != nil {
+		return nil, errors.Wrap(err, ErrJSONDecode.Error())
+	}
+	if alert.Key != EventKeyIPSAlert && alert.InnerAlertSignatureID == 0 {
+		return nil, fmt.Errorf("event %s is not an ips alert", alert.Key)
+	}
+	return &alert, nil
+}
+
+// EVE returns the alert as a Suricata EVE alert record
+func (e EventIPSAlert) EVE() EVERecord {
+	eventType := e.EventType
+	if eventType == "" {
+		eventType = "alert"
+	}
+	host := e.Host
+	if host == "" {
+		host = e.Gateway
+	}
+	return EVERecord{
+		Timestamp: e.EventTime().Format(eveTimeFormat),
+		FlowID:    e.FlowID,
+		InIface:   e.InterfaceIn,
+		EventType: eventType,
+		SrcIP:     e.SourceIP,
+		SrcPort:   e.SourcePort,
+		DestIP:    e.DestinationIP,
+		DestPort:  e.DestinationPort,
+		Proto:     e.Protocol,
+		AppProto:  e.AppProtocol,
+		Host:      host,
+		Alert: EVEAlert{
+			Action:      e.InnerAlertAction,
+			GID:         e.InnerAlertGID,
+			SignatureID: e.InnerAlertSignatureID,
+			Rev:         e.InnerAlertRevision,
+			Signature:   e.InnerAlertSignature,
+			Category:    e.InnerAlertCategory,
+			Severity:    e.InnerAlertSeverity,
+		},
+	}
+}
@@ -2,15 +2,16 @@ package unifi
 
 import (
 	"bytes"
+	"context"
 	"net/http"
 )
 
 // ResetDPICounters will reset the site-wide DPI counters
 // site - site this device currently registered to
-func (c *Client) ResetDPICounters(site string) (*GenericResponse, error) {
+func (c *Client) ResetDPICounters(ctx context.Context, site string) (*GenericResponse, error) {
 	data := []byte(`{"cmd": "clear-dpi"}`)
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "cmd/stat", bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/stat", bytes.NewReader(data), &resp)
 	return &resp, err
 }
@@ -2,26 +2,33 @@ package unifi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
 )
 
 // ListBackups will list all auto-backup files
 // site - site this device currently registered to
-// mac - the device mac
-// firmwareURL - the firmware URL
-func (c *Client) ListBackups(site string) (*GenericResponse, error) {
+//
+// Deprecated: use ListAutoBackups for typed results.
+func (c *Client) ListBackups(ctx context.Context, site string) (*GenericResponse, error) {
 	data := []byte(`{"cmd": "list-backup"}`)
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "cmd/backup", bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/backup", bytes.NewReader(data), &resp)
 	return &resp, err
 }
 
 // DeleteBackup will delete a backup on the filesystem
 // site - site this device currently registered to
 // filename - the backup file to delete
-func (c *Client) DeleteBackup(site string, filename string) (*GenericResponse, error) {
+func (c *Client) DeleteBackup(ctx context.Context, site string, filename string) (*GenericResponse, error) {
 	payload := map[string]interface{}{
 		"cmd":      "delete-backup",
 		"filename": filename,
@@ -29,16 +36,162 @@ func (c *Client) DeleteBackup(site string, filename string) (*GenericResponse, e
 	data, _ := json.Marshal(payload)
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "cmd/backup", bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/backup", bytes.NewReader(data), &resp)
 	return &resp, err
 }
 
 // CreateBackup will create a backup to a fixed location on the filesystem.
 // site - site this device currently registered to
-func (c *Client) CreateBackup(site string) (*GenericResponse, error) {
+func (c *Client) CreateBackup(ctx context.Context, site string) (*GenericResponse, error) {
 	data := []byte(`{"cmd": "backup"}`)
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "cmd/system", bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/system", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// BackupResponse contains the cmd/backup response with the download location of the backup
+type BackupResponse struct {
+	Meta CommonMeta `json:"meta"`
+	Data []struct {
+		URL string `json:"url"`
+	} `json:"data"`
+}
+
+// Backup will create a new backup and return the .unf backup file, the caller must close the returned reader.
+// site - site to backup, backups always contain the full controller configuration
+// days - days of statistics history to include, -1 for all history and 0 for settings only
+func (c *Client) Backup(ctx context.Context, site string, days int) (io.ReadCloser, error) {
+	payload := map[string]interface{}{
+		"cmd":  "backup",
+		"days": days,
+	}
+	data, _ := json.Marshal(payload)
+
+	var resp BackupResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/backup", bytes.NewReader(data), &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 || resp.Data[0].URL == "" {
+		return nil, fmt.Errorf("controller did not return the backup location")
+	}
+	return c.doDownload(ctx, resp.Data[0].URL)
+}
+
+// AutoBackup defines an automatic backup file stored on the controller
+type AutoBackup struct {
+	Filename   string `json:"filename"`
+	Controller string `json:"controller_name"`
+	Version    string `json:"version"`
+	Format     string `json:"format"`
+	Days       int    `json:"days"`
+	Size       int64  `json:"size"`
+	Time       int64  `json:"time"` // epoch milliseconds
+	DateTime   string `json:"datetime"`
+}
+
+// Created returns the time the backup was created
+func (b AutoBackup) Created() time.Time {
+	return time.Unix(0, b.Time*int64(time.Millisecond)).UTC()
+}
+
+// AutoBackupsResponse contains the list-backups response
+type AutoBackupsResponse struct {
+	Meta CommonMeta   `json:"meta"`
+	Data []AutoBackup `json:"data"`
+}
+
+// ListAutoBackups will list the automatic backup files
+// site - site to query
+func (c *Client) ListAutoBackups(ctx context.Context, site string) (*AutoBackupsResponse, error) {
+	data := []byte(`{"cmd": "list-backups"}`)
+
+	var resp AutoBackupsResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/backup", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// DownloadAutoBackup will return the automatic backup file, the caller must close the returned reader.
+// filename - the backup file name, see ListAutoBackups
+func (c *Client) DownloadAutoBackup(ctx context.Context, filename string) (io.ReadCloser, error) {
+	filename = strings.TrimSpace(filename)
+	if filename == "" || strings.ContainsAny(filename, "/\\") || filename == "." || filename == ".." {
+		return nil, fmt.Errorf("invalid backup filename specified: %s", filename)
+	}
+	return c.doDownload(ctx, path.Join("/dl/autobackup", url.PathEscape(filename)))
+}
+
+// LatestAutoBackup returns the most recent automatic backup file
+// site - site to query
+func (c *Client) LatestAutoBackup(ctx context.Context, site string) (*AutoBackup, error) {
+	resp, err := c.ListAutoBackups(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	var latest *AutoBackup
+	for i := range resp.Data {
+		if latest == nil || resp.Data[i].Time > latest.Time {
+			latest = &resp.Data[i]
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("controller has no automatic backups")
+	}
+	return latest, nil
+}
+
+// AutoBackupSettings contains the automatic backup settings, part of the super_mgmt settings.
+// The backups are controller wide and contain every site.
+type AutoBackupSettings struct {
+	ID       string `json:"_id,omitempty"`
+	Key      string `json:"key,omitempty"`
+	Enabled  bool   `json:"autobackup_enabled"`
+	CronExpr string `json:"autobackup_cron_expr"` // the schedule, e.g. `0 0 * * 1` for weekly
+	Timezone string `json:"autobackup_timezone"`
+	Days     int    `json:"autobackup_days"`      // days of statistics history to include, -1 for all and 0 for settings only
+	MaxFiles int    `json:"autobackup_max_files"` // the number of backups to retain
+}
+
+// AutoBackupSettingsResponse contains the automatic backup settings response
+type AutoBackupSettingsResponse struct {
+	Meta CommonMeta           `json:"meta"`
+	Data []AutoBackupSettings `json:"data"`
+}
+
+// GetAutoBackupSettings returns the automatic backup settings
+// site - site to query, these are controller wide settings usually stored on the `default` site
+func (c *Client) GetAutoBackupSettings(ctx context.Context, site string) (*AutoBackupSettings, error) {
+	var resp AutoBackupSettingsResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/setting/super_mgmt", nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("controller did not return the super_mgmt settings")
+	}
+	return &resp.Data[0], nil
+}
+
+// UpdateAutoBackupSettings will update the automatic backup settings
+// site - site to modify, these are controller wide settings usually stored on the `default` site
+// settings - the settings to apply, the ID must be set, see GetAutoBackupSettings
+func (c *Client) UpdateAutoBackupSettings(ctx context.Context, site string, settings *AutoBackupSettings) (*AutoBackupSettingsResponse, error) {
+	if settings.ID == "" {
+		return nil, fmt.Errorf("must specify the settings ID")
+	}
+	if settings.Enabled && settings.MaxFiles < 1 {
+		return nil, fmt.Errorf("invalid backup retention specified: %d", settings.MaxFiles)
+	}
+	if settings.Days < -1 {
+		return nil, fmt.Errorf("invalid backup history days specified: %d", settings.Days)
+	}
+	settings.Key = "super_mgmt"
+	data, _ := json.Marshal(settings)
+
+	extPath := path.Join("rest/setting/super_mgmt", strings.TrimSpace(settings.ID))
+
+	var resp AutoBackupSettingsResponse
+	err := c.doSiteRequest(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
 	return &resp, err
 }
@@ -2,14 +2,17 @@ package unifi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 )
 
 // AdoptDevice will adopt a device onto the current site.
 // site - site this device currently registered to
 // mac - the device mac
-func (c *Client) AdoptDevice(site string, mac string) (*GenericResponse, error) {
+func (c *Client) AdoptDevice(ctx context.Context, site string, mac string) (*GenericResponse, error) {
 	payload := map[string]interface{}{
 		"cmd": "adopt",
 		"mac": mac,
@@ -17,14 +20,14 @@ func (c *Client) AdoptDevice(site string, mac string) (*GenericResponse, error)
 	data, _ := json.Marshal(payload)
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
 	return &resp, err
 }
 
 // RestartDevice will restart a device.
 // site - site this device currently registered to
 // mac - the device mac
-func (c *Client) RestartDevice(site string, mac string) (*GenericResponse, error) {
+func (c *Client) RestartDevice(ctx context.Context, site string, mac string) (*GenericResponse, error) {
 	payload := map[string]interface{}{
 		"cmd": "restart",
 		"mac": mac,
@@ -32,14 +35,14 @@ func (c *Client) RestartDevice(site string, mac string) (*GenericResponse, error
 	data, _ := json.Marshal(payload)
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
 	return &resp, err
 }
 
 // ForceProvisionDevice will force-provision an existing device.
 // site - site this device currently registered to
 // mac - the device mac
-func (c *Client) ForceProvisionDevice(site string, mac string) (*GenericResponse, error) {
+func (c *Client) ForceProvisionDevice(ctx context.Context, site string, mac string) (*GenericResponse, error) {
 	payload := map[string]interface{}{
 		"cmd": "force-provision",
 		"mac": mac,
@@ -47,7 +50,7 @@ func (c *Client) ForceProvisionDevice(site string, mac string) (*GenericResponse
 	data, _ := json.Marshal(payload)
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
 	return &resp, err
 }
 
@@ -55,43 +58,44 @@ func (c *Client) ForceProvisionDevice(site string, mac string) (*GenericResponse
 // site - site this device currently registered to
 // mac - the device mac
 // portIdx - PoE port to cycle
-func (c *Client) PowerCycleDevice(site string, mac string, portIdx int) (*GenericResponse, error) {
+//
+// Deprecated: use PowerCyclePort.
+func (c *Client) PowerCycleDevice(ctx context.Context, site string, mac string, portIdx int) (*GenericResponse, error) {
+	return c.PowerCyclePort(ctx, site, mac, portIdx)
+}
+
+// PowerCyclePort will power cycle the PoE output of a switch port, rebooting the powered device.
+// site - site this switch is currently registered to
+// switchMAC - the switch mac
+// portIdx - PoE port to cycle, starting at 1
+func (c *Client) PowerCyclePort(ctx context.Context, site string, switchMAC string, portIdx int) (*GenericResponse, error) {
+	if portIdx < 1 {
+		return nil, fmt.Errorf("invalid port index specified: %d", portIdx)
+	}
 	payload := map[string]interface{}{
 		"cmd":      "power-cycle",
-		"mac":      mac,
+		"mac":      strings.ToLower(switchMAC),
 		"port_idx": portIdx,
 	}
 	data, _ := json.Marshal(payload)
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
 	return &resp, err
 }
 
 // StartSpeedTest will start a speed test.
 // site - site this device currently registered to
-func (c *Client) StartSpeedTest(site string) (*GenericResponse, error) {
-	data := []byte(`{"cmd": "speedtest"}`)
-
-	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
-	return &resp, err
-}
-
-// SpeedTestStatus will get the current state of a speet test.
-// site - site this device currently registered to
-func (c *Client) SpeedTestStatus(site string) (*GenericResponse, error) {
-	data := []byte(`{"cmd": "speedtest-status"}`)
-
-	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
-	return &resp, err
+//
+// Deprecated: use RunSpeedTest.
+func (c *Client) StartSpeedTest(ctx context.Context, site string) (*GenericResponse, error) {
+	return c.RunSpeedTest(ctx, site)
 }
 
 // SetLocateDevice will blink a device unit to locate it.
 // site - site this device currently registered to
 // mac - the device mac
-func (c *Client) SetLocateDevice(site string, mac string) (*GenericResponse, error) {
+func (c *Client) SetLocateDevice(ctx context.Context, site string, mac string) (*GenericResponse, error) {
 	payload := map[string]interface{}{
 		"cmd": "set-locate",
 		"mac": mac,
@@ -99,14 +103,14 @@ func (c *Client) SetLocateDevice(site string, mac string) (*GenericResponse, err
 	data, _ := json.Marshal(payload)
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
 	return &resp, err
 }
 
 // UnsetLocateDevice will return a blinking device led to normal state.
 // site - site this device currently registered to
 // mac - the device mac
-func (c *Client) UnsetLocateDevice(site string, mac string) (*GenericResponse, error) {
+func (c *Client) UnsetLocateDevice(ctx context.Context, site string, mac string) (*GenericResponse, error) {
 	payload := map[string]interface{}{
 		"cmd": "unset-locate",
 		"mac": mac,
@@ -114,22 +118,22 @@ func (c *Client) UnsetLocateDevice(site string, mac string) (*GenericResponse, e
 	data, _ := json.Marshal(payload)
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
 	return &resp, err
 }
 
-// UpgradeDevice will trigger a firmware upgrade for the device
+// UpgradeDevice will trigger a firmware upgrade for the device to the latest firmware known to the controller
 // site - site this device currently registered to
 // mac - the device mac
-func (c *Client) UpgradeDevice(site string, mac string) (*GenericResponse, error) {
+func (c *Client) UpgradeDevice(ctx context.Context, site string, mac string) (*GenericResponse, error) {
 	payload := map[string]interface{}{
 		"cmd": "upgrade",
-		"mac": mac,
+		"mac": strings.ToLower(mac),
 	}
 	data, _ := json.Marshal(payload)
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
 	return &resp, err
 }
 
@@ -137,33 +141,39 @@ func (c *Client) UpgradeDevice(site string, mac string) (*GenericResponse, error
 // site - site this device currently registered to
 // mac - the device mac
 // firmwareURL - the firmware URL
-func (c *Client) UpgradeExternalDevice(site string, mac string, firmwareURL string) (*GenericResponse, error) {
+//
+// Deprecated: use UpgradeDeviceExternal.
+func (c *Client) UpgradeExternalDevice(ctx context.Context, site string, mac string, firmwareURL string) (*GenericResponse, error) {
+	return c.UpgradeDeviceExternal(ctx, site, mac, firmwareURL)
+}
+
+// UpgradeDeviceExternal will trigger a firmware upgrade for the device with the provided URL location for the firmware.
+// site - site this device currently registered to
+// mac - the device mac
+// firmwareURL - the firmware URL, this must be reachable from the device
+func (c *Client) UpgradeDeviceExternal(ctx context.Context, site string, mac string, firmwareURL string) (*GenericResponse, error) {
+	if firmwareURL == "" {
+		return nil, fmt.Errorf("must specify the firmware URL")
+	}
 	payload := map[string]interface{}{
 		"cmd": "upgrade-external",
-		"mac": mac,
+		"mac": strings.ToLower(mac),
 		"url": firmwareURL,
 	}
 	data, _ := json.Marshal(payload)
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
 	return &resp, err
 }
 
 // SpectrumScanDevice will trigger a RF scan (AP's only)
 // site - site this device currently registered to
 // mac - the device mac
-// firmwareURL - the firmware URL
-func (c *Client) SpectrumScanDevice(site string, mac string) (*GenericResponse, error) {
-	payload := map[string]interface{}{
-		"cmd": "spectrum-scan",
-		"mac": mac,
-	}
-	data, _ := json.Marshal(payload)
-
-	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
-	return &resp, err
+//
+// Deprecated: use StartSpectrumScan.
+func (c *Client) SpectrumScanDevice(ctx context.Context, site string, mac string) (*GenericResponse, error) {
+	return c.StartSpectrumScan(ctx, site, mac)
 }
 
 // CreateNewUserClientDevice will create a new User/Client device
@@ -172,7 +182,7 @@ func (c *Client) SpectrumScanDevice(site string, mac string) (*GenericResponse,
 //               use ListUserGroups to obtain this.
 // name - optional name to provide the user/client device
 // note - optional note to provide the user/client device
-func (c *Client) CreateNewUserClientDevice(site string, mac string, userGroupID string, name string, note string) (*GenericResponse, error) {
+func (c *Client) CreateNewUserClientDevice(ctx context.Context, site string, mac string, userGroupID string, name string, note string) (*GenericResponse, error) {
 	userPayload := map[string]interface{}{
 		"mac":          mac,
 		"usergroup_id": userGroupID,
@@ -195,7 +205,7 @@ func (c *Client) CreateNewUserClientDevice(site string, mac string, userGroupID
 	data, _ := json.Marshal(payload)
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "group/user", bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "group/user", bytes.NewReader(data), &resp)
 	return &resp, err
 }
 
@@ -203,31 +213,18 @@ func (c *Client) CreateNewUserClientDevice(site string, mac string, userGroupID
 // userID - client user ID obtained from SiteDevicesDetailed
 // note - optional note to provide the user/client device
 //        when note is empty, the existing note for the client-device will be removed
-func (c *Client) SetUserClientDeviceNote(site string, userID string, note string) (*GenericResponse, error) {
-	payload := map[string]interface{}{
-		"noted": note != "",
-		"note":  note,
-	}
-
-	data, _ := json.Marshal(payload)
-
-	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "upd/user", bytes.NewReader(data), &resp)
-	return &resp, err
+//
+// Deprecated: use SetClientNote.
+func (c *Client) SetUserClientDeviceNote(ctx context.Context, site string, userID string, note string) (*GenericResponse, error) {
+	return c.SetClientNote(ctx, site, userID, note)
 }
 
 // SetUserClientDeviceName will update a name on a user/client device.
 // userID - client user ID obtained from SiteDevicesDetailed
 // name - optional name to provide the user/client device
 //        when note is empty, the existing note for the client-device will be removed
-func (c *Client) SetUserClientDeviceName(site string, userID string, name string) (*GenericResponse, error) {
-	payload := map[string]interface{}{
-		"name": name,
-	}
-
-	data, _ := json.Marshal(payload)
-
-	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "upd/user", bytes.NewReader(data), &resp)
-	return &resp, err
+//
+// Deprecated: use SetClientName.
+func (c *Client) SetUserClientDeviceName(ctx context.Context, site string, userID string, name string) (*GenericResponse, error) {
+	return c.SetClientName(ctx, site, userID, name)
 }
@@ -0,0 +1,191 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"time"
+)
+
+// RestoreStatus defines the progress of a backup restore
+type RestoreStatus string
+
+// The restore statuses
+const (
+	RestoreStatusUploading  RestoreStatus = "uploading"  // the backup file is uploaded
+	RestoreStatusRestoring  RestoreStatus = "restoring"  // the restore was requested
+	RestoreStatusRestarting RestoreStatus = "restarting" // the controller is down, restarting with the restored configuration
+	RestoreStatusDone       RestoreStatus = "done"       // the controller is up again
+)
+
+// RestoreOptions configures RestoreBackup
+type RestoreOptions struct {
+	Filename     string              // the name of the uploaded file, defaults to `backup.unf`
+	PollInterval time.Duration       // controller status poll interval, defaults to 5 seconds
+	Timeout      time.Duration       // time for the controller to restart after the restore, defaults to 15 minutes
+	Progress     func(RestoreStatus) // optional callback on every status change
+}
+
+// UploadedBackup defines a backup file uploaded to the controller
+type UploadedBackup struct {
+	BackupID string `json:"backup_id"`
+	Filename string `json:"filename"`
+	Version  string `json:"version"` // the controller version of the backup
+	Days     int    `json:"days"`
+	Size     int64  `json:"size"`
+}
+
+// UploadBackupResponse contains the upload/backup response
+type UploadBackupResponse struct {
+	Meta CommonMeta       `json:"meta"`
+	Data []UploadedBackup `json:"data"`
+}
+
+// UploadBackup will upload a .unf backup file to the controller without restoring it.
+// The file is read into memory, so the upload can be repeated after a relogin.
+// filename - the name of the uploaded file
+// r - the backup file
+func (c *Client) UploadBackup(ctx context.Context, filename string, r io.Reader) (*UploadedBackup, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return nil, err
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	ctx = WithRequestOptions(ctx, withContentType(mw.FormDataContentType()))
+	var resp UploadBackupResponse
+	err = c.doRequest(ctx, http.MethodPost, "/upload/backup", bytes.NewReader(body.Bytes()), &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("controller did not return the uploaded backup")
+	}
+	return &resp.Data[0], nil
+}
+
+// RestoreBackup will upload the .unf backup file, restore it and wait until the controller restarted with the
+// restored configuration. The restore replaces the configuration of every site and ends the session, later
+// requests log in again when the client has credentials, see WithCredentialsProvider.
+// r - the backup file, e.g. from Backup or DownloadAutoBackup
+// opts - the restore options
+func (c *Client) RestoreBackup(ctx context.Context, r io.Reader, opts RestoreOptions) error {
+	if opts.Filename == "" {
+		opts.Filename = "backup.unf"
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 5 * time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 15 * time.Minute
+	}
+	progress := func(status RestoreStatus) {
+		if opts.Progress != nil {
+			opts.Progress(status)
+		}
+	}
+
+	progress(RestoreStatusUploading)
+	backup, err := c.UploadBackup(ctx, opts.Filename, r)
+	if err != nil {
+		return err
+	}
+
+	progress(RestoreStatusRestoring)
+	// the controller may restart between two polls, it is then recognized by its start time or version
+	before, _ := c.controllerInstance(ctx)
+	payload := map[string]interface{}{
+		"cmd":       "restore",
+		"backup_id": backup.BackupID,
+	}
+	data, _ := json.Marshal(payload)
+
+	var resp GenericResponse
+	err = c.doSiteRequest(ctx, http.MethodPost, "", "cmd/backup", bytes.NewReader(data), &resp)
+	if err != nil {
+		return err
+	}
+
+	return c.waitForRestart(ctx, before, opts.PollInterval, opts.Timeout, func() {
+		progress(RestoreStatusRestarting)
+	}, func() {
+		progress(RestoreStatusDone)
+	})
+}
+
+// restartSlack is the tolerance of the start time computed from the controller uptime
+const restartSlack = 10 * time.Second
+
+// controllerInstance identifies a controller run by its version and start time
+type controllerInstance struct {
+	version string
+	started time.Time // zero when the system info is not available, e.g. without a session
+}
+
+// controllerInstance returns the running controller instance, false if the controller is down
+func (c *Client) controllerInstance(ctx context.Context) (controllerInstance, bool) {
+	status, err := c.ControllerStatus(ctx)
+	if err != nil || !status.Meta.Up {
+		return controllerInstance{}, false
+	}
+	instance := controllerInstance{version: status.Meta.ServerVersion}
+	if info, err := c.SysInfo(ctx); err == nil && info.Uptime > 0 {
+		instance.started = time.Now().Add(-time.Duration(info.Uptime) * time.Second)
+	}
+	return instance, true
+}
+
+// restartedSince returns true if the instance is a later run of the controller than before
+func (i controllerInstance) restartedSince(before controllerInstance) bool {
+	if before.version != "" && i.version != "" && i.version != before.version {
+		return true
+	}
+	return !before.started.IsZero() && !i.started.IsZero() && i.started.Sub(before.started) > restartSlack
+}
+
+// waitForRestart polls the controller status until the controller went down and came up again, or came up with
+// a later start time or another version than before. The first polls are a second apart, the poll interval doubles
+// up to interval so a quick restart is not missed.
+func (c *Client) waitForRestart(ctx context.Context, before controllerInstance, interval time.Duration, timeout time.Duration, down func(), up func()) error {
+	deadline := time.Now().Add(timeout)
+	wentDown := false
+	wait := time.Second
+	for {
+		if wait > interval {
+			wait = interval
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+		wait *= 2
+
+		instance, running := c.controllerInstance(ctx)
+		switch {
+		case !running:
+			if !wentDown {
+				wentDown = true
+				down()
+			}
+		case wentDown || instance.restartedSince(before):
+			if !wentDown {
+				down()
+			}
+			up()
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("controller did not restart within %s", timeout)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultInformPort is the port devices send their inform requests to
+const DefaultInformPort = "8080"
+
+// MoveDeviceToSite will move a device from the current site to another site of the controller, the site is
+// looked up by name, see MoveDevice to move by site ID.
+// site - site this device currently registered to
+// mac - the device mac
+// targetSite - the name of the site to move the device to, e.g. `default`
+func (c *Client) MoveDeviceToSite(ctx context.Context, site string, mac string, targetSite string) (*GenericResponse, error) {
+	target, err := c.GetSite(ctx, targetSite)
+	if err != nil {
+		return nil, err
+	}
+	return c.MoveDevice(ctx, site, mac, target.ID)
+}
+
+// MigrateDevice will point a device at another controller, the device keeps its configuration and shows up
+// as pending adoption on the controller behind the inform url.
+// site - site this device currently registered to
+// mac - the device mac
+// informURL - the inform url of the new controller, e.g. `http://controller:8080/inform`
+func (c *Client) MigrateDevice(ctx context.Context, site string, mac string, informURL string) (*GenericResponse, error) {
+	payload := map[string]interface{}{
+		"cmd":        "migrate",
+		"mac":        strings.ToLower(mac),
+		"inform_url": informURL,
+	}
+	data, _ := json.Marshal(payload)
+
+	var resp GenericResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// CancelDeviceMigration will cancel a pending migration, the device informs the current controller again.
+// site - site this device currently registered to
+// mac - the device mac
+func (c *Client) CancelDeviceMigration(ctx context.Context, site string, mac string) (*GenericResponse, error) {
+	payload := map[string]interface{}{
+		"cmd": "cancel-migrate",
+		"mac": strings.ToLower(mac),
+	}
+	data, _ := json.Marshal(payload)
+
+	var resp GenericResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// InformURL returns the default inform url of the controller, the host of the base url on DefaultInformPort.
+// Controllers behind a proxy or with a custom inform host need the url from the site settings instead.
+func (c *Client) InformURL() string {
+	return "http://" + net.JoinHostPort(c.baseURL.Hostname(), DefaultInformPort) + "/inform"
+}
+
+// MigrateOptions configures MigrateDeviceTo
+type MigrateOptions struct {
+	InformURL    string        // the inform url of the target controller, defaults to target.InformURL()
+	PollInterval time.Duration // device state poll interval, defaults to 5 seconds
+	Timeout      time.Duration // time for the device to appear and to connect on the target, defaults to 5 minutes
+	Forget       bool          // forget the device on this controller once it is connected to the target
+}
+
+// MigrateDeviceTo re-homes a device onto a site of another controller without a factory reset: the device is
+// migrated to the inform url of the target, adopted on the target site once it shows up as pending adoption
+// and the connected device on the target is returned. The migration is cancelled when the device does not
+// show up on the target in time.
+// site - site this device currently registered to
+// mac - the device mac
+// target - the client of the target controller
+// targetSite - the site of the target controller to adopt the device onto
+// opts - the migrate options
+func (c *Client) MigrateDeviceTo(ctx context.Context, site string, mac string, target *Client, targetSite string, opts MigrateOptions) (*Device, error) {
+	mac = strings.ToLower(strings.TrimSpace(mac))
+	if opts.InformURL == "" {
+		opts.InformURL = target.InformURL()
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 5 * time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Minute
+	}
+
+	if _, err := c.MigrateDevice(ctx, site, mac, opts.InformURL); err != nil {
+		return nil, err
+	}
+
+	device, err := target.waitForDeviceState(ctx, targetSite, mac, opts.PollInterval, opts.Timeout,
+		DeviceStatePendingAdoption, DeviceStateConnected)
+	if err != nil {
+		if _, cancelErr := c.CancelDeviceMigration(ctx, site, mac); cancelErr != nil {
+			c.log().Warnw("unable to cancel the device migration", "mac", mac, "error", cancelErr)
+		}
+		return nil, err
+	}
+	if device.State == DeviceStatePendingAdoption {
+		if _, err := target.AdoptDevice(ctx, targetSite, mac); err != nil {
+			return nil, err
+		}
+		device, err = target.waitForDeviceState(ctx, targetSite, mac, opts.PollInterval, opts.Timeout, DeviceStateConnected)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Forget {
+		if _, err := c.DeleteDevice(ctx, site, mac); err != nil {
+			return device, err
+		}
+	}
+	return device, nil
+}
+
+// waitForDeviceState polls the device until it is in one of the states, the adoption failed or the timeout passed
+func (c *Client) waitForDeviceState(ctx context.Context, site string, mac string, interval time.Duration, timeout time.Duration, states ...DeviceState) (*Device, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		// the device is not listed until it informs the controller, errors are retried until the timeout
+		resp, err := c.ListDevices(ctx, site, mac)
+		if err == nil {
+			for i := range resp.Data {
+				d := &resp.Data[i]
+				if !strings.EqualFold(d.MAC, mac) {
+					continue
+				}
+				if d.State == DeviceStateAdoptionFailed {
+					return d, fmt.Errorf("adoption of %s failed", mac)
+				}
+				for _, state := range states {
+					if d.State == state {
+						return d, nil
+					}
+				}
+			}
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("device %s not in the expected state after %s", mac, timeout)
+		}
+		if err := sleepContext(ctx, interval); err != nil {
+			return nil, err
+		}
+	}
+}
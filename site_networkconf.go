@@ -0,0 +1,162 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// NetworkPurpose defines the purpose of a network
+type NetworkPurpose string
+
+// The supported network purposes
+const (
+	NetworkPurposeCorporate     NetworkPurpose = "corporate"
+	NetworkPurposeGuest         NetworkPurpose = "guest"
+	NetworkPurposeVLANOnly      NetworkPurpose = "vlan-only"
+	NetworkPurposeWAN           NetworkPurpose = "wan"
+	NetworkPurposeRemoteUserVPN NetworkPurpose = "remote-user-vpn"
+	NetworkPurposeSiteVPN       NetworkPurpose = "site-vpn"
+)
+
+// IsValid returns true if it's a valid network purpose.
+// there are only a few valid types
+func (p NetworkPurpose) IsValid() bool {
+	switch p {
+	case NetworkPurposeCorporate, NetworkPurposeGuest, NetworkPurposeVLANOnly, NetworkPurposeWAN,
+		NetworkPurposeRemoteUserVPN, NetworkPurposeSiteVPN:
+		return true
+	default:
+		return false
+	}
+}
+
+// DHCPOption defines a custom DHCP option served on a network
+type DHCPOption struct {
+	Code  int    `json:"code"`
+	Name  string `json:"name,omitempty"`
+	Type  string `json:"type"` // text, ipaddress, int8, int16, int32, boolean, hexarray
+	Value string `json:"value"`
+}
+
+// Network defines a network configuration (rest/networkconf)
+type Network struct {
+	ID           string         `json:"_id,omitempty"`
+	SiteID       string         `json:"site_id,omitempty"`
+	Name         string         `json:"name"`
+	Purpose      NetworkPurpose `json:"purpose"`
+	Enabled      bool           `json:"enabled"`
+	NetworkGroup string         `json:"networkgroup,omitempty"` // LAN, LAN2, ...
+	VLANEnabled  bool           `json:"vlan_enabled"`
+	VLAN         interface{}    `json:"vlan,omitempty"`      // sometimes string or int
+	IPSubnet     string         `json:"ip_subnet,omitempty"` // the gateway address and prefix, e.g. `192.168.1.1/24`
+	DomainName   string         `json:"domain_name,omitempty"`
+	IGMPSnooping bool           `json:"igmp_snooping"`
+	IGMPProxyFor string         `json:"igmp_proxy_upstream,omitempty"`
+	AttrNoDelete bool           `json:"attr_no_delete,omitempty"`
+	AttrHiddenID string         `json:"attr_hidden_id,omitempty"` // set on built-in networks, e.g. `LAN` and `WAN`
+
+	// dhcp server
+	DHCPDEnabled           bool         `json:"dhcpd_enabled"`
+	DHCPDStart             string       `json:"dhcpd_start,omitempty"`
+	DHCPDStop              string       `json:"dhcpd_stop,omitempty"`
+	DHCPDLeaseTime         int          `json:"dhcpd_leasetime,omitempty"` // seconds
+	DHCPDDNSEnabled        bool         `json:"dhcpd_dns_enabled"`
+	DHCPDDNS1              string       `json:"dhcpd_dns_1,omitempty"`
+	DHCPDDNS2              string       `json:"dhcpd_dns_2,omitempty"`
+	DHCPDDNS3              string       `json:"dhcpd_dns_3,omitempty"`
+	DHCPDDNS4              string       `json:"dhcpd_dns_4,omitempty"`
+	DHCPDGatewayEnabled    bool         `json:"dhcpd_gateway_enabled"`
+	DHCPDGateway           string       `json:"dhcpd_gateway,omitempty"`
+	DHCPDNTPEnabled        bool         `json:"dhcpd_ntp_enabled"`
+	DHCPDNTP1              string       `json:"dhcpd_ntp_1,omitempty"`
+	DHCPDNTP2              string       `json:"dhcpd_ntp_2,omitempty"`
+	DHCPDWINSEnabled       bool         `json:"dhcpd_wins_enabled"`
+	DHCPDTFTPServer        string       `json:"dhcpd_tftp_server,omitempty"`
+	DHCPDBootEnabled       bool         `json:"dhcpd_boot_enabled"`
+	DHCPDBootServer        string       `json:"dhcpd_boot_server,omitempty"`
+	DHCPDBootFilename      string       `json:"dhcpd_boot_filename,omitempty"`
+	DHCPDUnifiController   string       `json:"dhcpd_unifi_controller,omitempty"` // dhcp option 43
+	DHCPDTimeOffsetEnabled bool         `json:"dhcpd_time_offset_enabled"`
+	DHCPGuardEnabled       bool         `json:"dhcpguard_enabled"`
+	DHCPRelayEnabled       bool         `json:"dhcp_relay_enabled"`
+	DHCPOptions            []DHCPOption `json:"dhcpd_options,omitempty"`
+
+	// ipv6
+	IPv6InterfaceType       string `json:"ipv6_interface_type,omitempty"` // none, static, pd
+	IPv6Subnet              string `json:"ipv6_subnet,omitempty"`
+	IPv6PDInterface         string `json:"ipv6_pd_interface,omitempty"`
+	IPv6PDPrefixID          string `json:"ipv6_pd_prefixid,omitempty"`
+	IPv6PDStart             string `json:"ipv6_pd_start,omitempty"`
+	IPv6PDStop              string `json:"ipv6_pd_stop,omitempty"`
+	IPv6RAEnabled           bool   `json:"ipv6_ra_enabled"`
+	IPv6RAPriority          string `json:"ipv6_ra_priority,omitempty"` // high, medium, low
+	IPv6RAValidLifetime     int    `json:"ipv6_ra_valid_lifetime,omitempty"`
+	IPv6RAPreferredLifetime int    `json:"ipv6_ra_preferred_lifetime,omitempty"`
+	DHCPDV6Enabled          bool   `json:"dhcpdv6_enabled"`
+	DHCPDV6Start            string `json:"dhcpdv6_start,omitempty"`
+	DHCPDV6Stop             string `json:"dhcpdv6_stop,omitempty"`
+	DHCPDV6LeaseTime        int    `json:"dhcpdv6_leasetime,omitempty"`
+	DHCPDV6DNSAuto          bool   `json:"dhcpdv6_dns_auto"`
+}
+
+// NetworksResponse contains the network configuration response
+type NetworksResponse struct {
+	Meta CommonMeta `json:"meta"`
+	Data []Network  `json:"data"`
+}
+
+// ListNetworks will list the network configurations
+// site - the site to query
+func (c *Client) ListNetworks(ctx context.Context, site string) (*NetworksResponse, error) {
+	var resp NetworksResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/networkconf", nil, &resp)
+	return &resp, err
+}
+
+// CreateNetwork will create a new network
+// site - the site to modify
+// network - the network configuration to create
+func (c *Client) CreateNetwork(ctx context.Context, site string, network *Network) (*NetworksResponse, error) {
+	if !network.Purpose.IsValid() {
+		return nil, fmt.Errorf("invalid purpose specified: %s", network.Purpose)
+	}
+	data, _ := json.Marshal(network)
+
+	var resp NetworksResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "rest/networkconf", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// UpdateNetwork will update an existing network
+// site - the site to modify
+// network - the network configuration to update, the ID must be set
+func (c *Client) UpdateNetwork(ctx context.Context, site string, network *Network) (*NetworksResponse, error) {
+	if network.ID == "" {
+		return nil, fmt.Errorf("must specify the network ID")
+	}
+	if !network.Purpose.IsValid() {
+		return nil, fmt.Errorf("invalid purpose specified: %s", network.Purpose)
+	}
+	data, _ := json.Marshal(network)
+
+	extPath := fmt.Sprintf("rest/networkconf/%s", strings.TrimSpace(network.ID))
+
+	var resp NetworksResponse
+	err := c.doSiteRequest(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// DeleteNetwork will delete an existing network
+// site - the site to modify
+// networkID - the ID of the network
+func (c *Client) DeleteNetwork(ctx context.Context, site string, networkID string) (*GenericResponse, error) {
+	extPath := fmt.Sprintf("rest/networkconf/%s", strings.TrimSpace(networkID))
+
+	var resp GenericResponse
+	err := c.doSiteRequest(ctx, http.MethodDelete, site, extPath, nil, &resp)
+	return &resp, err
+}
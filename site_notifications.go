@@ -0,0 +1,121 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+)
+
+// AlertSetting defines how the admins are notified of an event, one entry of the alertsetting collection
+type AlertSetting struct {
+	ID     string `json:"_id,omitempty"`
+	SiteID string `json:"site_id,omitempty"`
+
+	Key          string `json:"key"`           // the event key, e.g. EventKeyAPLostContact
+	AlarmEnabled bool   `json:"alarm_enabled"` // raise an alarm in the controller
+	EmailEnabled bool   `json:"email_enabled"` // email the admins subscribed to alerts
+	PushEnabled  bool   `json:"push_enabled"`  // send a push notification to the mobile app
+}
+
+// sameChannels returns true if both settings notify on the same channels
+func (s AlertSetting) sameChannels(o AlertSetting) bool {
+	return s.AlarmEnabled == o.AlarmEnabled && s.EmailEnabled == o.EmailEnabled && s.PushEnabled == o.PushEnabled
+}
+
+// AlertSettingsResponse contains the alertsetting response
+type AlertSettingsResponse struct {
+	Meta CommonMeta     `json:"meta"`
+	Data []AlertSetting `json:"data"`
+}
+
+// ListAlertSettings lists the notification settings of the site, events without an entry use the controller defaults
+// site - the site to query
+func (c *Client) ListAlertSettings(ctx context.Context, site string) (*AlertSettingsResponse, error) {
+	var resp AlertSettingsResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/alertsetting", nil, &resp)
+	return &resp, err
+}
+
+// CreateAlertSetting will create the notification setting of an event
+// site - the site to modify
+// setting - the setting to create, the key must be set
+func (c *Client) CreateAlertSetting(ctx context.Context, site string, setting *AlertSetting) (*AlertSettingsResponse, error) {
+	if strings.TrimSpace(setting.Key) == "" {
+		return nil, fmt.Errorf("must specify the event key")
+	}
+	data, _ := json.Marshal(setting)
+
+	var resp AlertSettingsResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "rest/alertsetting", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// UpdateAlertSetting will update the notification setting of an event
+// site - the site to modify
+// setting - the setting to apply, the ID must be set, see ListAlertSettings
+func (c *Client) UpdateAlertSetting(ctx context.Context, site string, setting *AlertSetting) (*AlertSettingsResponse, error) {
+	if setting.ID == "" {
+		return nil, fmt.Errorf("must specify the alert setting ID")
+	}
+	data, _ := json.Marshal(setting)
+
+	extPath := path.Join("rest/alertsetting", strings.TrimSpace(setting.ID))
+
+	var resp AlertSettingsResponse
+	err := c.doSiteRequest(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// NotificationPolicy maps event keys to the channels their notifications are sent on, the keys of the
+// settings are ignored
+type NotificationPolicy map[string]AlertSetting
+
+// ApplyNotificationPolicy will create or update the notification settings of the site to match the policy,
+// events not in the policy are left unchanged. Running it for every site, e.g. with Manager.ForEachSite,
+// standardizes the alerting across sites. The changed settings are returned, ordered by event key.
+// site - the site to modify
+// policy - the notification channels per event key
+func (c *Client) ApplyNotificationPolicy(ctx context.Context, site string, policy NotificationPolicy) ([]AlertSetting, error) {
+	resp, err := c.ListAlertSettings(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]AlertSetting, len(resp.Data))
+	for _, s := range resp.Data {
+		existing[s.Key] = s
+	}
+
+	keys := make([]string, 0, len(policy))
+	for key := range policy {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var changed []AlertSetting
+	for _, key := range keys {
+		want := policy[key]
+		want.Key = key
+		current, ok := existing[key]
+		switch {
+		case !ok:
+			want.ID, want.SiteID = "", ""
+			if _, err := c.CreateAlertSetting(ctx, site, &want); err != nil {
+				return changed, err
+			}
+		case !current.sameChannels(want):
+			want.ID, want.SiteID = current.ID, current.SiteID
+			if _, err := c.UpdateAlertSetting(ctx, site, &want); err != nil {
+				return changed, err
+			}
+		default:
+			continue
+		}
+		changed = append(changed, want)
+	}
+	return changed, nil
+}
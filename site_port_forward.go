@@ -0,0 +1,111 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// PortForwardProtocol defines the protocol a port forward applies to
+type PortForwardProtocol string
+
+// The supported port forward protocols
+const (
+	PortForwardProtocolTCPUDP PortForwardProtocol = "tcp_udp"
+	PortForwardProtocolTCP    PortForwardProtocol = "tcp"
+	PortForwardProtocolUDP    PortForwardProtocol = "udp"
+)
+
+// IsValid returns true if it's a valid port forward protocol.
+// there are only a few valid types
+func (p PortForwardProtocol) IsValid() bool {
+	switch p {
+	case PortForwardProtocolTCPUDP, PortForwardProtocolTCP, PortForwardProtocolUDP:
+		return true
+	default:
+		return false
+	}
+}
+
+// PortForward defines a port forwarding (destination NAT) rule
+type PortForward struct {
+	ID               string              `json:"_id,omitempty"`
+	SiteID           string              `json:"site_id,omitempty"`
+	Name             string              `json:"name"`
+	Enabled          bool                `json:"enabled"`
+	Source           string              `json:"src"`            // `any` or a source IP/CIDR allowed to use the forward
+	DestinationPort  string              `json:"dst_port"`       // a port, port range `8000-8010` or list `80,443`
+	ForwardIP        string              `json:"fwd"`            // the internal IP to forward to
+	ForwardPort      string              `json:"fwd_port"`       // the internal port(s) to forward to
+	Protocol         PortForwardProtocol `json:"proto"`          // tcp_udp, tcp or udp
+	Log              bool                `json:"log"`            // log forwarded traffic
+	ForwardInterface string              `json:"pfwd_interface"` // wan, wan2 or both
+	DestinationIP    string              `json:"destination_ip,omitempty"`
+}
+
+// PortForwardsResponse contains the port forwarding rules response
+type PortForwardsResponse struct {
+	Meta CommonMeta    `json:"meta"`
+	Data []PortForward `json:"data"`
+}
+
+// ListPortForwards will list the port forwarding rules
+// site - the site to query
+func (c *Client) ListPortForwards(ctx context.Context, site string) (*PortForwardsResponse, error) {
+	var resp PortForwardsResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/portforward", nil, &resp)
+	return &resp, err
+}
+
+// CreatePortForward will create a new port forwarding rule
+// site - the site to modify
+// forward - the port forward to create
+func (c *Client) CreatePortForward(ctx context.Context, site string, forward *PortForward) (*PortForwardsResponse, error) {
+	if !forward.Protocol.IsValid() {
+		return nil, fmt.Errorf("invalid protocol specified: %s", forward.Protocol)
+	}
+	if forward.Source == "" {
+		forward.Source = "any"
+	}
+	if forward.ForwardInterface == "" {
+		forward.ForwardInterface = "wan"
+	}
+	data, _ := json.Marshal(forward)
+
+	var resp PortForwardsResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "rest/portforward", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// UpdatePortForward will update an existing port forwarding rule
+// site - the site to modify
+// forward - the port forward to update, the ID must be set
+func (c *Client) UpdatePortForward(ctx context.Context, site string, forward *PortForward) (*PortForwardsResponse, error) {
+	if forward.ID == "" {
+		return nil, fmt.Errorf("must specify the port forward ID")
+	}
+	if !forward.Protocol.IsValid() {
+		return nil, fmt.Errorf("invalid protocol specified: %s", forward.Protocol)
+	}
+	data, _ := json.Marshal(forward)
+
+	extPath := fmt.Sprintf("rest/portforward/%s", strings.TrimSpace(forward.ID))
+
+	var resp PortForwardsResponse
+	err := c.doSiteRequest(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// DeletePortForward will delete an existing port forwarding rule
+// site - the site to modify
+// forwardID - the ID of the port forward
+func (c *Client) DeletePortForward(ctx context.Context, site string, forwardID string) (*GenericResponse, error) {
+	extPath := fmt.Sprintf("rest/portforward/%s", strings.TrimSpace(forwardID))
+
+	var resp GenericResponse
+	err := c.doSiteRequest(ctx, http.MethodDelete, site, extPath, nil, &resp)
+	return &resp, err
+}
@@ -0,0 +1,238 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// PoEMode defines the PoE mode of a switch port
+type PoEMode string
+
+// The supported PoE modes
+const (
+	PoEModeAuto        PoEMode = "auto"
+	PoEModePassive24V  PoEMode = "pasv24"
+	PoEModePassthrough PoEMode = "passthrough"
+	PoEModeOff         PoEMode = "off"
+)
+
+// IsValid returns true if it's a valid PoE mode.
+// there are only a few valid types
+func (m PoEMode) IsValid() bool {
+	switch m {
+	case PoEModeAuto, PoEModePassive24V, PoEModePassthrough, PoEModeOff:
+		return true
+	default:
+		return false
+	}
+}
+
+// PortProfile defines a switch port profile (rest/portconf)
+type PortProfile struct {
+	ID                     string   `json:"_id,omitempty"`
+	SiteID                 string   `json:"site_id,omitempty"`
+	Name                   string   `json:"name"`
+	Forward                string   `json:"forward"` // all, native, customize, disabled
+	NativeNetworkConfID    string   `json:"native_networkconf_id,omitempty"`
+	TaggedNetworkConfIDs   []string `json:"tagged_networkconf_ids,omitempty"`
+	VoiceNetworkConfID     string   `json:"voice_networkconf_id,omitempty"`
+	PoEMode                PoEMode  `json:"poe_mode,omitempty"`
+	Autoneg                bool     `json:"autoneg"`
+	Speed                  int      `json:"speed,omitempty"`
+	FullDuplex             bool     `json:"full_duplex"`
+	Isolation              bool     `json:"isolation"`
+	STPPortMode            bool     `json:"stp_port_mode"`
+	LLDPMEDEnabled         bool     `json:"lldpmed_enabled"`
+	EgressRateLimitEnabled bool     `json:"egress_rate_limit_kbps_enabled"`
+	EgressRateLimitKbps    int      `json:"egress_rate_limit_kbps,omitempty"`
+	Dot1xCtrl              string   `json:"dot1x_ctrl,omitempty"` // force_authorized, auto, mac_based, multi_host
+	Dot1xIdleTimeout       int      `json:"dot1x_idle_timeout,omitempty"`
+	PortSecurityEnabled    bool     `json:"port_security_enabled"`
+	PortSecurityMACs       []string `json:"port_security_mac_address,omitempty"`
+	StormCtrlBcastEnabled  bool     `json:"stormctrl_bcast_enabled"`
+	StormCtrlBcastRate     int      `json:"stormctrl_bcast_rate,omitempty"`
+	OpMode                 string   `json:"op_mode,omitempty"` // switch, mirror, aggregate
+	AttrNoDelete           bool     `json:"attr_no_delete,omitempty"`
+	AttrHiddenID           string   `json:"attr_hidden_id,omitempty"` // set on built-in profiles, e.g. `All` and `Disabled`
+}
+
+// PortProfilesResponse contains the port profiles response
+type PortProfilesResponse struct {
+	Meta CommonMeta    `json:"meta"`
+	Data []PortProfile `json:"data"`
+}
+
+// ListPortProfiles will list the switch port profiles
+// site - the site to query
+func (c *Client) ListPortProfiles(ctx context.Context, site string) (*PortProfilesResponse, error) {
+	var resp PortProfilesResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/portconf", nil, &resp)
+	return &resp, err
+}
+
+// CreatePortProfile will create a new switch port profile
+// site - the site to modify
+// profile - the port profile to create
+func (c *Client) CreatePortProfile(ctx context.Context, site string, profile *PortProfile) (*PortProfilesResponse, error) {
+	if profile.PoEMode != "" && !profile.PoEMode.IsValid() {
+		return nil, fmt.Errorf("invalid poe mode specified: %s", profile.PoEMode)
+	}
+	data, _ := json.Marshal(profile)
+
+	var resp PortProfilesResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "rest/portconf", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// UpdatePortProfile will update an existing switch port profile
+// site - the site to modify
+// profile - the port profile to update, the ID must be set
+func (c *Client) UpdatePortProfile(ctx context.Context, site string, profile *PortProfile) (*PortProfilesResponse, error) {
+	if profile.ID == "" {
+		return nil, fmt.Errorf("must specify the port profile ID")
+	}
+	if profile.PoEMode != "" && !profile.PoEMode.IsValid() {
+		return nil, fmt.Errorf("invalid poe mode specified: %s", profile.PoEMode)
+	}
+	data, _ := json.Marshal(profile)
+
+	extPath := fmt.Sprintf("rest/portconf/%s", strings.TrimSpace(profile.ID))
+
+	var resp PortProfilesResponse
+	err := c.doSiteRequest(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// DeletePortProfile will delete an existing switch port profile
+// site - the site to modify
+// profileID - the ID of the port profile
+func (c *Client) DeletePortProfile(ctx context.Context, site string, profileID string) (*GenericResponse, error) {
+	extPath := fmt.Sprintf("rest/portconf/%s", strings.TrimSpace(profileID))
+
+	var resp GenericResponse
+	err := c.doSiteRequest(ctx, http.MethodDelete, site, extPath, nil, &resp)
+	return &resp, err
+}
+
+// PortOverride defines the per-port settings of a switch that override its port profile
+type PortOverride struct {
+	PortIdx    int     `json:"port_idx"`
+	Name       string  `json:"name,omitempty"`
+	PortConfID string  `json:"portconf_id,omitempty"` // the port profile to apply
+	PoEMode    PoEMode `json:"poe_mode,omitempty"`
+	OpMode     string  `json:"op_mode,omitempty"` // switch, mirror, aggregate
+	Forward    string  `json:"forward,omitempty"` // all, native, customize, disabled - newer controllers only
+	Enabled    *bool   `json:"enabled,omitempty"` // nil keeps the current state
+}
+
+// SetDevicePortOverrides will replace all port overrides of a switch
+// site - the site to modify
+// deviceID - the 24 char _id of the switch, see GetDevice
+// overrides - the complete set of port overrides
+func (c *Client) SetDevicePortOverrides(ctx context.Context, site string, deviceID string, overrides []PortOverride) (*DevicesResponse, error) {
+	for _, o := range overrides {
+		if o.PoEMode != "" && !o.PoEMode.IsValid() {
+			return nil, fmt.Errorf("invalid poe mode specified: %s", o.PoEMode)
+		}
+	}
+	if overrides == nil {
+		overrides = make([]PortOverride, 0)
+	}
+	payload := map[string]interface{}{
+		"port_overrides": overrides,
+	}
+	return c.updateDevice(ctx, site, deviceID, payload)
+}
+
+// SetPortOverride will set the override of a single switch port, keeping the overrides of the other ports
+// site - the site to modify
+// mac - the switch mac
+// override - the port override, merged into any existing override of the same port, see SetPortOverrides
+func (c *Client) SetPortOverride(ctx context.Context, site string, mac string, override PortOverride) (*DevicesResponse, error) {
+	return c.SetPortOverrides(ctx, site, mac, override)
+}
+
+// SetPortOverrides will set the overrides of multiple switch ports, keeping the overrides of the other ports.
+// The fields of each override are merged into the existing override of the same port, so the settings PortOverride
+// does not model, e.g. tagged VLANs, speed, STP or port security, are kept.
+// site - the site to modify
+// mac - the switch mac
+// overrides - the port overrides, only the fields that are set replace those of the existing override
+func (c *Client) SetPortOverrides(ctx context.Context, site string, mac string, overrides ...PortOverride) (*DevicesResponse, error) {
+	for _, o := range overrides {
+		if o.PoEMode != "" && !o.PoEMode.IsValid() {
+			return nil, fmt.Errorf("invalid poe mode specified: %s", o.PoEMode)
+		}
+	}
+	deviceID, current, err := c.rawPortOverrides(ctx, site, mac)
+	if err != nil {
+		return nil, err
+	}
+
+	byPort := make(map[int]map[string]interface{}, len(current)+len(overrides))
+	for _, o := range current {
+		idx, ok := o["port_idx"].(float64)
+		if !ok {
+			continue
+		}
+		byPort[int(idx)] = o
+	}
+	for _, o := range overrides {
+		var fields map[string]interface{}
+		data, _ := json.Marshal(o)
+		_ = json.Unmarshal(data, &fields)
+
+		existing, ok := byPort[o.PortIdx]
+		if !ok {
+			byPort[o.PortIdx] = fields
+			continue
+		}
+		for k, v := range fields {
+			existing[k] = v
+		}
+	}
+	ports := make([]int, 0, len(byPort))
+	for idx := range byPort {
+		ports = append(ports, idx)
+	}
+	sort.Ints(ports)
+	merged := make([]map[string]interface{}, 0, len(ports))
+	for _, idx := range ports {
+		merged = append(merged, byPort[idx])
+	}
+
+	payload := map[string]interface{}{
+		"port_overrides": merged,
+	}
+	return c.updateDevice(ctx, site, deviceID, payload)
+}
+
+// rawPortOverrides returns the _id and the port overrides of the switch with every field the controller stores
+func (c *Client) rawPortOverrides(ctx context.Context, site string, mac string) (string, []map[string]interface{}, error) {
+	var resp struct {
+		Meta CommonMeta `json:"meta"`
+		Data []struct {
+			ID            string                   `json:"_id"`
+			MAC           string                   `json:"mac"`
+			PortOverrides []map[string]interface{} `json:"port_overrides"`
+		} `json:"data"`
+	}
+	payload := map[string]interface{}{
+		"macs": []string{strings.ToLower(mac)},
+	}
+	data, _ := json.Marshal(payload)
+	if err := c.doSiteRequest(ctx, http.MethodPost, site, "stat/device", bytes.NewReader(data), &resp); err != nil {
+		return "", nil, err
+	}
+	for _, d := range resp.Data {
+		if strings.EqualFold(d.MAC, mac) {
+			return d.ID, d.PortOverrides, nil
+		}
+	}
+	return "", nil, ErrDeviceNotFound
+}
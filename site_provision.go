@@ -0,0 +1,150 @@
+package unifi
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// ProvisionStatus defines the outcome of a device provision
+type ProvisionStatus string
+
+// The provision statuses
+const (
+	ProvisionStatusPending      ProvisionStatus = "pending"      // the provision was requested
+	ProvisionStatusProvisioning ProvisionStatus = "provisioning" // the device is applying the configuration
+	ProvisionStatusConnected    ProvisionStatus = "connected"    // the device applied the configuration and reconnected
+	ProvisionStatusFailed       ProvisionStatus = "failed"       // the provision could not be requested
+	ProvisionStatusTimeout      ProvisionStatus = "timeout"      // the device did not reconnect within the timeout
+)
+
+// IsDone returns true if the status is final
+func (s ProvisionStatus) IsDone() bool {
+	switch s {
+	case ProvisionStatusConnected, ProvisionStatusFailed, ProvisionStatusTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// ProvisionResult is the outcome of provisioning a single device
+type ProvisionResult struct {
+	MAC      string
+	Status   ProvisionStatus
+	State    DeviceState // the last device state seen
+	Err      error       // set for ProvisionStatusFailed
+	Started  time.Time
+	Finished time.Time // zero until the status is final
+}
+
+// ProvisionOptions configures ProvisionDevices
+type ProvisionOptions struct {
+	PollInterval time.Duration         // device state poll interval, defaults to 5 seconds
+	Timeout      time.Duration         // time for a device to reconnect after the provision, defaults to 5 minutes
+	Progress     func(ProvisionResult) // optional callback on every status change
+}
+
+// ProvisionDevices force provisions the devices and polls their state until every device applied the configuration
+// and reconnected, failed or timed out. A device is done once it is connected with a newer provisioned_at than before.
+// The results are in the order of the macs, an error is only returned when the context is done.
+// site - the site of the devices
+// macs - the macs of the devices to provision
+// opts - the provision options
+func (c *Client) ProvisionDevices(ctx context.Context, site string, macs []string, opts ProvisionOptions) ([]ProvisionResult, error) {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 5 * time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Minute
+	}
+
+	results := make([]ProvisionResult, len(macs))
+	index := make(map[string]int, len(macs))
+	for i, mac := range macs {
+		mac = strings.ToLower(strings.TrimSpace(mac))
+		results[i] = ProvisionResult{MAC: mac, Status: ProvisionStatusPending}
+		index[mac] = i
+	}
+	update := func(i int, status ProvisionStatus, state DeviceState, err error) {
+		r := &results[i]
+		if r.Status == status && r.State == state {
+			return
+		}
+		r.Status, r.State, r.Err = status, state, err
+		if status.IsDone() {
+			r.Finished = time.Now()
+		}
+		if opts.Progress != nil {
+			opts.Progress(*r)
+		}
+	}
+
+	if len(macs) == 0 {
+		return results, nil
+	}
+
+	// remember the last provision so an earlier one is not mistaken for ours
+	baseline := make(map[string]int64, len(macs))
+	resp, err := c.ListDevices(ctx, site, macs...)
+	if err != nil {
+		return results, err
+	}
+	for _, d := range resp.Data {
+		baseline[strings.ToLower(d.MAC)] = d.ProvisionedAt
+	}
+
+	pending := 0
+	for i := range results {
+		r := &results[i]
+		r.Started = time.Now()
+		if _, ok := baseline[r.MAC]; !ok {
+			update(i, ProvisionStatusFailed, DeviceStateDisconnected, ErrDeviceNotFound)
+			continue
+		}
+		if _, err := c.ForceProvisionDevice(ctx, site, r.MAC); err != nil {
+			update(i, ProvisionStatusFailed, DeviceStateDisconnected, err)
+			continue
+		}
+		pending++
+	}
+
+	for pending > 0 {
+		if err := sleepContext(ctx, opts.PollInterval); err != nil {
+			return results, err
+		}
+		waiting := make([]string, 0, pending)
+		for _, r := range results {
+			if !r.Status.IsDone() {
+				waiting = append(waiting, r.MAC)
+			}
+		}
+		resp, err := c.ListDevices(ctx, site, waiting...)
+		if err != nil {
+			// the controller may be briefly unavailable, e.g. while provisioning the gateway
+			resp = &DevicesResponse{}
+		}
+		for _, d := range resp.Data {
+			i, ok := index[strings.ToLower(d.MAC)]
+			if !ok || results[i].Status.IsDone() {
+				continue
+			}
+			switch {
+			case d.State == DeviceStateConnected && d.ProvisionedAt > baseline[results[i].MAC]:
+				update(i, ProvisionStatusConnected, d.State, nil)
+				pending--
+			case d.State == DeviceStateProvisioning:
+				update(i, ProvisionStatusProvisioning, d.State, nil)
+			default:
+				update(i, results[i].Status, d.State, nil)
+			}
+		}
+		for i, r := range results {
+			if !r.Status.IsDone() && time.Since(r.Started) > opts.Timeout {
+				update(i, ProvisionStatusTimeout, r.State, nil)
+				pending--
+			}
+		}
+	}
+	return results, nil
+}
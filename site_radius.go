@@ -0,0 +1,182 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// RADIUSServer defines a RADIUS authentication or accounting server
+type RADIUSServer struct {
+	IP     string `json:"ip"`
+	Port   int    `json:"port"`
+	Secret string `json:"x_secret"`
+}
+
+// RADIUSProfile defines a RADIUS server profile used by WLANs and 802.1X switch ports
+type RADIUSProfile struct {
+	ID                    string         `json:"_id,omitempty"`
+	SiteID                string         `json:"site_id,omitempty"`
+	Name                  string         `json:"name"`
+	UseUSGAuthServer      bool           `json:"use_usg_auth_server"` // use the built-in gateway RADIUS server
+	UseUSGAcctServer      bool           `json:"use_usg_acct_server"` // use the built-in gateway accounting server
+	AuthServers           []RADIUSServer `json:"auth_servers"`
+	AcctServers           []RADIUSServer `json:"acct_servers"`
+	AccountingEnabled     bool           `json:"accounting_enabled"`
+	InterimUpdateEnabled  bool           `json:"interim_update_enabled"`
+	InterimUpdateInterval int            `json:"interim_update_interval,omitempty"` // seconds
+	VLANEnabled           bool           `json:"vlan_enabled"`
+	VLANWLANMode          string         `json:"vlan_wlan_mode,omitempty"` // disabled, optional, required
+}
+
+// RADIUSProfilesResponse contains the RADIUS profiles response
+type RADIUSProfilesResponse struct {
+	Meta CommonMeta      `json:"meta"`
+	Data []RADIUSProfile `json:"data"`
+}
+
+// ListRADIUSProfiles will list the RADIUS profiles
+// site - the site to query
+func (c *Client) ListRADIUSProfiles(ctx context.Context, site string) (*RADIUSProfilesResponse, error) {
+	var resp RADIUSProfilesResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/radiusprofile", nil, &resp)
+	return &resp, err
+}
+
+// CreateRADIUSProfile will create a new RADIUS profile
+// site - the site to modify
+// profile - the RADIUS profile to create
+func (c *Client) CreateRADIUSProfile(ctx context.Context, site string, profile *RADIUSProfile) (*RADIUSProfilesResponse, error) {
+	data, _ := json.Marshal(profile)
+
+	var resp RADIUSProfilesResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "rest/radiusprofile", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// UpdateRADIUSProfile will update an existing RADIUS profile
+// site - the site to modify
+// profile - the RADIUS profile to update, the ID must be set
+func (c *Client) UpdateRADIUSProfile(ctx context.Context, site string, profile *RADIUSProfile) (*RADIUSProfilesResponse, error) {
+	if profile.ID == "" {
+		return nil, fmt.Errorf("must specify the RADIUS profile ID")
+	}
+	data, _ := json.Marshal(profile)
+
+	extPath := fmt.Sprintf("rest/radiusprofile/%s", strings.TrimSpace(profile.ID))
+
+	var resp RADIUSProfilesResponse
+	err := c.doSiteRequest(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// DeleteRADIUSProfile will delete an existing RADIUS profile
+// site - the site to modify
+// profileID - the ID of the RADIUS profile
+func (c *Client) DeleteRADIUSProfile(ctx context.Context, site string, profileID string) (*GenericResponse, error) {
+	extPath := fmt.Sprintf("rest/radiusprofile/%s", strings.TrimSpace(profileID))
+
+	var resp GenericResponse
+	err := c.doSiteRequest(ctx, http.MethodDelete, site, extPath, nil, &resp)
+	return &resp, err
+}
+
+// RADIUS tunnel attributes used to assign VLANs to accounts and to mark VPN users
+const (
+	RADIUSTunnelTypeL2TP          = 3  // Tunnel-Type L2TP
+	RADIUSTunnelTypeVLAN          = 13 // Tunnel-Type VLAN
+	RADIUSTunnelMediumTypeIPv4    = 1  // Tunnel-Medium-Type IPv4
+	RADIUSTunnelMediumTypeIEEE802 = 6  // Tunnel-Medium-Type IEEE-802
+)
+
+// RADIUSAccount defines an account of the built-in RADIUS server
+type RADIUSAccount struct {
+	ID               string      `json:"_id,omitempty"`
+	SiteID           string      `json:"site_id,omitempty"`
+	Name             string      `json:"name"`
+	Password         string      `json:"x_password"`
+	VLAN             interface{} `json:"vlan,omitempty"` // sometimes string or int
+	TunnelType       int         `json:"tunnel_type,omitempty"`
+	TunnelMediumType int         `json:"tunnel_medium_type,omitempty"`
+	NetworkConfID    string      `json:"networkconf_id,omitempty"`
+}
+
+// RADIUSAccountsResponse contains the RADIUS accounts response
+type RADIUSAccountsResponse struct {
+	Meta CommonMeta      `json:"meta"`
+	Data []RADIUSAccount `json:"data"`
+}
+
+// ListRADIUSAccounts will list the RADIUS accounts
+// site - the site to query
+func (c *Client) ListRADIUSAccounts(ctx context.Context, site string) (*RADIUSAccountsResponse, error) {
+	var resp RADIUSAccountsResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/account", nil, &resp)
+	return &resp, err
+}
+
+// CreateRADIUSAccount will create a new RADIUS account
+// site - the site to modify
+// account - the RADIUS account to create
+func (c *Client) CreateRADIUSAccount(ctx context.Context, site string, account *RADIUSAccount) (*RADIUSAccountsResponse, error) {
+	if account.Name == "" {
+		return nil, fmt.Errorf("must specify the RADIUS account name")
+	}
+	if account.VLAN != nil && account.TunnelType == 0 {
+		account.TunnelType = RADIUSTunnelTypeVLAN
+		account.TunnelMediumType = RADIUSTunnelMediumTypeIEEE802
+	}
+	data, _ := json.Marshal(account)
+
+	var resp RADIUSAccountsResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "rest/account", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// CreateRADIUSAccounts will create the RADIUS accounts in bulk, stopping at the first failure.
+// The accounts created so far are returned along with any error.
+// site - the site to modify
+// accounts - the RADIUS accounts to create
+func (c *Client) CreateRADIUSAccounts(ctx context.Context, site string, accounts []RADIUSAccount) ([]RADIUSAccount, error) {
+	created := make([]RADIUSAccount, 0, len(accounts))
+	for i := range accounts {
+		resp, err := c.CreateRADIUSAccount(ctx, site, &accounts[i])
+		if err != nil {
+			return created, errors.Wrap(err, fmt.Sprintf("unable to create RADIUS account %s", accounts[i].Name))
+		}
+		created = append(created, resp.Data...)
+	}
+	return created, nil
+}
+
+// UpdateRADIUSAccount will update an existing RADIUS account
+// site - the site to modify
+// account - the RADIUS account to update, the ID must be set
+func (c *Client) UpdateRADIUSAccount(ctx context.Context, site string, account *RADIUSAccount) (*RADIUSAccountsResponse, error) {
+	if account.ID == "" {
+		return nil, fmt.Errorf("must specify the RADIUS account ID")
+	}
+	data, _ := json.Marshal(account)
+
+	extPath := fmt.Sprintf("rest/account/%s", strings.TrimSpace(account.ID))
+
+	var resp RADIUSAccountsResponse
+	err := c.doSiteRequest(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// DeleteRADIUSAccount will delete an existing RADIUS account
+// site - the site to modify
+// accountID - the ID of the RADIUS account
+func (c *Client) DeleteRADIUSAccount(ctx context.Context, site string, accountID string) (*GenericResponse, error) {
+	extPath := fmt.Sprintf("rest/account/%s", strings.TrimSpace(accountID))
+
+	var resp GenericResponse
+	err := c.doSiteRequest(ctx, http.MethodDelete, site, extPath, nil, &resp)
+	return &resp, err
+}
@@ -0,0 +1,255 @@
+package unifi
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// ReportAggregation defines how multiple report values are combined into one
+type ReportAggregation string
+
+// The supported report aggregations
+const (
+	ReportAggregationSum  ReportAggregation = "sum"
+	ReportAggregationAvg  ReportAggregation = "avg"
+	ReportAggregationMin  ReportAggregation = "min"
+	ReportAggregationMax  ReportAggregation = "max"
+	ReportAggregationLast ReportAggregation = "last"
+)
+
+// IsValid returns true if it's a valid report aggregation.
+// there are only a few valid types
+func (a ReportAggregation) IsValid() bool {
+	switch a {
+	case ReportAggregationSum, ReportAggregationAvg, ReportAggregationMin, ReportAggregationMax, ReportAggregationLast:
+		return true
+	default:
+		return false
+	}
+}
+
+// aggregate combines the values, values must not be empty
+func (a ReportAggregation) aggregate(values []float64) float64 {
+	result := values[0]
+	switch a {
+	case ReportAggregationSum, ReportAggregationAvg:
+		for _, v := range values[1:] {
+			result += v
+		}
+		if a == ReportAggregationAvg {
+			result /= float64(len(values))
+		}
+	case ReportAggregationMin:
+		for _, v := range values[1:] {
+			result = math.Min(result, v)
+		}
+	case ReportAggregationMax:
+		for _, v := range values[1:] {
+			result = math.Max(result, v)
+		}
+	case ReportAggregationLast:
+		result = values[len(values)-1]
+	}
+	return result
+}
+
+// Time returns the data point time
+func (r SiteReport) Time() time.Time {
+	ms, _ := r.Float(ReportAttributeTime)
+	return time.Unix(0, int64(ms)*int64(time.Millisecond)).UTC()
+}
+
+// TimeIn returns the data point time in the location
+func (r SiteReport) TimeIn(loc *time.Location) time.Time {
+	return r.Time().In(loc)
+}
+
+// OID returns the id of the object the data point is for, e.g. the site id or the device/client mac
+func (r SiteReport) OID() string {
+	oid, _ := r["oid"].(string)
+	return oid
+}
+
+// Float returns the numeric value of the attribute and whether it was present
+func (r SiteReport) Float(attribute ReportAttribute) (float64, bool) {
+	switch v := r[string(attribute)].(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// Points returns the data points sorted in time order
+func (r *SiteReportsResponse) Points() []SiteReport {
+	points := make([]SiteReport, len(r.Data))
+	copy(points, r.Data)
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].Time().Before(points[j].Time())
+	})
+	return points
+}
+
+// Each calls fn for every data point in time order until fn returns false
+func (r *SiteReportsResponse) Each(fn func(SiteReport) bool) {
+	for _, point := range r.Points() {
+		if !fn(point) {
+			return
+		}
+	}
+}
+
+// Sum returns the sum of the attribute over all data points
+func (r *SiteReportsResponse) Sum(attribute ReportAttribute) float64 {
+	var sum float64
+	for _, point := range r.Data {
+		if v, ok := point.Float(attribute); ok {
+			sum += v
+		}
+	}
+	return sum
+}
+
+// Avg returns the average of the attribute over the data points it is present in
+func (r *SiteReportsResponse) Avg(attribute ReportAttribute) float64 {
+	var sum float64
+	var count int
+	for _, point := range r.Data {
+		if v, ok := point.Float(attribute); ok {
+			sum += v
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
+
+// Resample groups the data points into buckets of the given size per object and combines each numeric
+// attribute with the aggregation, e.g. hourly points with a 24h bucket and ReportAggregationSum for daily totals.
+// The returned points are in time order, their time is the start of the bucket.
+// Buckets are aligned in the response Location when set, e.g. days start at local midnight, otherwise in UTC.
+// bucket - the bucket size
+// aggregation - how values within a bucket are combined
+func (r *SiteReportsResponse) Resample(bucket time.Duration, aggregation ReportAggregation) ([]SiteReport, error) {
+	if bucket <= 0 {
+		return nil, fmt.Errorf("invalid bucket specified: %s", bucket)
+	}
+	if !aggregation.IsValid() {
+		return nil, fmt.Errorf("invalid aggregation specified: %s", aggregation)
+	}
+
+	type bucketKey struct {
+		oid  string
+		time int64
+	}
+	keys := make([]bucketKey, 0)
+	buckets := make(map[bucketKey][]SiteReport)
+	for _, point := range r.Points() {
+		key := bucketKey{oid: point.OID(), time: alignTime(point.Time(), bucket, r.Location).UnixNano()}
+		if _, ok := buckets[key]; !ok {
+			keys = append(keys, key)
+		}
+		buckets[key] = append(buckets[key], point)
+	}
+
+	// sort by bucket start, the alignment may differ between points around daylight saving changes
+	sort.SliceStable(keys, func(i, j int) bool { return keys[i].time < keys[j].time })
+
+	resampled := make([]SiteReport, 0, len(keys))
+	for _, key := range keys {
+		points := buckets[key]
+		values := make(map[string][]float64)
+		out := SiteReport{}
+		for _, point := range points {
+			for attr, v := range point {
+				if f, ok := point.Float(ReportAttribute(attr)); ok {
+					values[attr] = append(values[attr], f)
+				} else {
+					// keep non numeric values, e.g. the oid or mac, as is
+					out[attr] = v
+				}
+			}
+		}
+		for attr, v := range values {
+			out[attr] = aggregation.aggregate(v)
+		}
+		out[string(ReportAttributeTime)] = float64(key.time / int64(time.Millisecond))
+		resampled = append(resampled, out)
+	}
+	return resampled, nil
+}
+
+// ReportTable is a single report attribute aligned by time across multiple objects, e.g. several access points
+type ReportTable struct {
+	Attribute ReportAttribute
+	Times     []time.Time // the row times in ascending order, in the response Location when set
+	OIDs      []string    // the column object ids in ascending order
+	Values    [][]float64 // Values[row][column], NaN where the object has no data point at that time
+}
+
+// Column returns the values for the object id, or nil if it is not part of the table
+func (t *ReportTable) Column(oid string) []float64 {
+	for i, o := range t.OIDs {
+		if o != oid {
+			continue
+		}
+		column := make([]float64, len(t.Values))
+		for row := range t.Values {
+			column[row] = t.Values[row][i]
+		}
+		return column
+	}
+	return nil
+}
+
+// Table aligns the attribute of every object in the response into a single time indexed table
+// attribute - the attribute to tabulate
+func (r *SiteReportsResponse) Table(attribute ReportAttribute) *ReportTable {
+	rowIndex := make(map[int64]int)
+	columnIndex := make(map[string]int)
+	table := &ReportTable{Attribute: attribute}
+	for _, point := range r.Data {
+		t := point.Time()
+		if r.Location != nil {
+			t = t.In(r.Location)
+		}
+		if _, ok := rowIndex[t.UnixNano()]; !ok {
+			rowIndex[t.UnixNano()] = 0
+			table.Times = append(table.Times, t)
+		}
+		if _, ok := columnIndex[point.OID()]; !ok {
+			columnIndex[point.OID()] = 0
+			table.OIDs = append(table.OIDs, point.OID())
+		}
+	}
+	sort.Slice(table.Times, func(i, j int) bool { return table.Times[i].Before(table.Times[j]) })
+	sort.Strings(table.OIDs)
+	for i, t := range table.Times {
+		rowIndex[t.UnixNano()] = i
+	}
+	for i, oid := range table.OIDs {
+		columnIndex[oid] = i
+	}
+
+	table.Values = make([][]float64, len(table.Times))
+	for row := range table.Values {
+		table.Values[row] = make([]float64, len(table.OIDs))
+		for column := range table.Values[row] {
+			table.Values[row][column] = math.NaN()
+		}
+	}
+	for _, point := range r.Data {
+		if v, ok := point.Float(attribute); ok {
+			table.Values[rowIndex[point.Time().UnixNano()]][columnIndex[point.OID()]] = v
+		}
+	}
+	return table
+}
@@ -0,0 +1,120 @@
+package unifi
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testReport returns a data point of the object at the time
+func testReport(oid string, t time.Time, bytes float64) SiteReport {
+	return SiteReport{
+		"oid":                       oid,
+		string(ReportAttributeTime): float64(t.UnixNano() / int64(time.Millisecond)),
+		"bytes":                     bytes,
+	}
+}
+
+func TestResample(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	resp := &SiteReportsResponse{Data: []SiteReport{
+		testReport("ap1", day.Add(25*time.Hour), 5),
+		testReport("ap1", day.Add(time.Hour), 1),
+		testReport("ap2", day.Add(2*time.Hour), 10),
+		testReport("ap1", day.Add(2*time.Hour), 3),
+	}}
+
+	tests := []struct {
+		aggregation ReportAggregation
+		want        []float64 // the bytes of the resampled points, in time order
+	}{
+		{ReportAggregationSum, []float64{4, 10, 5}},
+		{ReportAggregationAvg, []float64{2, 10, 5}},
+		{ReportAggregationMin, []float64{1, 10, 5}},
+		{ReportAggregationMax, []float64{3, 10, 5}},
+		{ReportAggregationLast, []float64{3, 10, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.aggregation), func(t *testing.T) {
+			points, err := resp.Resample(24*time.Hour, tt.aggregation)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []float64
+			for _, p := range points {
+				v, _ := p.Float("bytes")
+				got = append(got, v)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("bytes = %v, want %v", got, tt.want)
+			}
+
+			wantTimes := []time.Time{day, day, day.Add(24 * time.Hour)}
+			wantOIDs := []string{"ap1", "ap2", "ap1"}
+			for i, p := range points {
+				if !p.Time().Equal(wantTimes[i]) || p.OID() != wantOIDs[i] {
+					t.Errorf("point %d = %s at %s, want %s at %s", i, p.OID(), p.Time(), wantOIDs[i], wantTimes[i])
+				}
+			}
+		})
+	}
+}
+
+func TestResampleLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	// 23:00 UTC is already the next day in the location
+	resp := &SiteReportsResponse{Location: loc, Data: []SiteReport{
+		testReport("site", time.Date(2024, 3, 1, 21, 0, 0, 0, time.UTC), 1),
+		testReport("site", time.Date(2024, 3, 1, 23, 0, 0, 0, time.UTC), 2),
+	}}
+	points, err := resp.Resample(24*time.Hour, ReportAggregationSum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []time.Time{time.Date(2024, 3, 1, 0, 0, 0, 0, loc), time.Date(2024, 3, 2, 0, 0, 0, 0, loc)}
+	if len(points) != len(want) {
+		t.Fatalf("got %d points, want %d", len(points), len(want))
+	}
+	for i, p := range points {
+		if !p.Time().Equal(want[i]) {
+			t.Errorf("point %d starts at %s, want %s", i, p.TimeIn(loc), want[i])
+		}
+	}
+}
+
+func TestResampleInvalid(t *testing.T) {
+	resp := &SiteReportsResponse{}
+	if _, err := resp.Resample(0, ReportAggregationSum); err == nil {
+		t.Error("expected an error for an empty bucket")
+	}
+	if _, err := resp.Resample(time.Hour, "median"); err == nil {
+		t.Error("expected an error for an unknown aggregation")
+	}
+}
+
+func TestReportTable(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	resp := &SiteReportsResponse{Data: []SiteReport{
+		testReport("ap2", start.Add(time.Hour), 20),
+		testReport("ap1", start, 1),
+		testReport("ap1", start.Add(time.Hour), 2),
+	}}
+	table := resp.Table("bytes")
+
+	if !reflect.DeepEqual(table.OIDs, []string{"ap1", "ap2"}) {
+		t.Errorf("OIDs = %v", table.OIDs)
+	}
+	if len(table.Times) != 2 || !table.Times[0].Equal(start) || !table.Times[1].Equal(start.Add(time.Hour)) {
+		t.Errorf("Times = %v", table.Times)
+	}
+	if got := table.Column("ap1"); !reflect.DeepEqual(got, []float64{1, 2}) {
+		t.Errorf("ap1 = %v", got)
+	}
+	if got := table.Column("ap2"); len(got) != 2 || !math.IsNaN(got[0]) || got[1] != 20 {
+		t.Errorf("ap2 = %v, want [NaN 20]", got)
+	}
+	if got := table.Column("ap3"); got != nil {
+		t.Errorf("ap3 = %v, want nil", got)
+	}
+}
@@ -0,0 +1,344 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultReportChunkSize returns the largest time range requested at once for the interval when chunking
+func DefaultReportChunkSize(interval ReportInterval) time.Duration {
+	switch interval {
+	case ReportInterval5Min:
+		return 12 * time.Hour
+	case ReportIntervalHourly:
+		return 7 * 24 * time.Hour
+	default:
+		return 90 * 24 * time.Hour
+	}
+}
+
+// ReportQuery describes a stat/report request, build it with NewReportQuery and run it with Client.Report
+type ReportQuery struct {
+	site       string
+	interval   ReportInterval
+	reportType ReportType
+	attributes []ReportAttribute
+	macs       []string
+	start      time.Time
+	end        time.Time
+	location   *time.Location
+
+	allowUnknownAttributes bool
+
+	chunked     bool
+	chunkSize   time.Duration
+	concurrency int
+}
+
+// NewReportQuery returns a query for the hourly site report of the site over the last day
+// site - the site interested in stats
+func NewReportQuery(site string) *ReportQuery {
+	return &ReportQuery{
+		site:       site,
+		interval:   ReportIntervalHourly,
+		reportType: ReportTypeSite,
+	}
+}
+
+// Interval sets the report interval
+func (q *ReportQuery) Interval(interval ReportInterval) *ReportQuery {
+	q.interval = interval
+	return q
+}
+
+// Type sets the report type
+func (q *ReportQuery) Type(reportType ReportType) *ReportQuery {
+	q.reportType = reportType
+	return q
+}
+
+// Attributes sets the attributes to return, the default attributes of the report type are used when empty
+func (q *ReportQuery) Attributes(attributes ...ReportAttribute) *ReportQuery {
+	q.attributes = attributes
+	return q
+}
+
+// AllowUnknownAttributes sends attributes missing from the attribute catalog of the report type as they are,
+// e.g. attributes of newer controllers, Client.Report logs a warning for them instead of failing the query
+func (q *ReportQuery) AllowUnknownAttributes() *ReportQuery {
+	q.allowUnknownAttributes = true
+	return q
+}
+
+// MACs limits the report to the given device or client macs
+func (q *ReportQuery) MACs(macs ...string) *ReportQuery {
+	q.macs = macs
+	return q
+}
+
+// Between sets the [start, end) time range of the report, leave both zero for the interval default
+func (q *ReportQuery) Between(start time.Time, end time.Time) *ReportQuery {
+	q.start = start
+	q.end = end
+	return q
+}
+
+// In sets the location used to align the time range to the interval buckets, e.g. daily reports start at
+// midnight in the location, and to convert the returned times, see SiteReportsResponse.Location.
+// Use the timezone of the controller to match the buckets it reports.
+func (q *ReportQuery) In(loc *time.Location) *ReportQuery {
+	q.location = loc
+	return q
+}
+
+// Chunked splits the time range into chunks of at most size which are requested separately and merged,
+// use this for ranges the controller would otherwise truncate or reject.
+// size - the chunk size, set to 0 for DefaultReportChunkSize of the interval
+func (q *ReportQuery) Chunked(size time.Duration) *ReportQuery {
+	q.chunked = true
+	q.chunkSize = size
+	return q
+}
+
+// Concurrency sets how many chunks are requested at the same time, defaults to 1
+func (q *ReportQuery) Concurrency(n int) *ReportQuery {
+	q.concurrency = n
+	return q
+}
+
+// Site returns the site the query is for
+func (q *ReportQuery) Site() string {
+	return q.site
+}
+
+// timeRange returns the requested time range or the default range for the interval,
+// aligned to the interval buckets when a location is set
+func (q *ReportQuery) timeRange() (time.Time, time.Time) {
+	start, end := q.start, q.end
+	if start.IsZero() && end.IsZero() {
+		end = time.Now().UTC()
+		switch q.interval {
+		case ReportInterval5Min:
+			// set default to last 1h
+			start = end.Add(-1 * time.Hour)
+		case ReportIntervalDaily:
+			// set default to last 7 days
+			start = end.Add(-7 * 24 * time.Hour)
+		default:
+			// set default to last 1 day
+			start = end.Add(-24 * time.Hour)
+		}
+	}
+	if bucket := q.interval.Duration(); q.location != nil && bucket > 0 {
+		start = alignTime(start, bucket, q.location)
+		if aligned := alignTime(end, bucket, q.location); aligned.Before(end) {
+			end = aligned.Add(bucket)
+		}
+	}
+	return start, end
+}
+
+// defaultAttributes returns the attributes requested when none are specified for the report type
+func (q *ReportQuery) defaultAttributes() []ReportAttribute {
+	switch q.reportType {
+	case ReportTypeSpeedTest:
+		return SpeedTestReportAttributes
+	case ReportTypeGateway:
+		return GatewayReportAttributes
+	case ReportTypeSwitch:
+		return SwitchReportAttributes
+	default:
+		return AllReportAttributes
+	}
+}
+
+// Validate checks the query can be sent to the controller
+func (q *ReportQuery) Validate() error {
+	start, end := q.timeRange()
+	if !start.Before(end) {
+		return fmt.Errorf("invalid end time, must occur after start time")
+	}
+	if !q.reportType.IsValid() {
+		return fmt.Errorf("invalid reportType specified: %s", q.reportType)
+	}
+	if q.reportType != ReportTypeSpeedTest && !q.interval.IsValid() {
+		return fmt.Errorf("invalid interval specified: %s", q.interval)
+	}
+	if q.chunked && q.chunkSize < 0 {
+		return fmt.Errorf("invalid chunk size specified: %s", q.chunkSize)
+	}
+	if len(q.attributes) > 0 && !q.allowUnknownAttributes {
+		return q.reportType.ValidateAttributes(q.attributes...)
+	}
+	return nil
+}
+
+// unknownAttributes returns the attributes the report type is not known to support
+func (q *ReportQuery) unknownAttributes() []ReportAttribute {
+	var unknown []ReportAttribute
+	for _, attr := range q.attributes {
+		if !q.reportType.Supports(attr) {
+			unknown = append(unknown, attr)
+		}
+	}
+	return unknown
+}
+
+// chunks splits the query into queries covering consecutive parts of the time range
+func (q *ReportQuery) chunks() []*ReportQuery {
+	start, end := q.timeRange()
+	size := q.chunkSize
+	if size == 0 {
+		size = DefaultReportChunkSize(q.interval)
+	}
+	chunks := make([]*ReportQuery, 0, int(end.Sub(start)/size)+1)
+	for chunkStart := start; chunkStart.Before(end); chunkStart = chunkStart.Add(size) {
+		chunkEnd := chunkStart.Add(size)
+		if chunkEnd.After(end) {
+			chunkEnd = end
+		}
+		chunk := *q
+		chunk.chunked = false
+		chunk.location = nil // already aligned
+		chunk.start = chunkStart
+		chunk.end = chunkEnd
+		chunks = append(chunks, &chunk)
+	}
+	return chunks
+}
+
+// path returns the report endpoint for the query
+func (q *ReportQuery) path() string {
+	interval := q.interval
+	// only archive is supported for speedtest, so override.
+	if q.reportType == ReportTypeSpeedTest {
+		interval = ReportIntervalArchive
+	}
+	return fmt.Sprintf("stat/report/%s.%s", interval, q.reportType)
+}
+
+// payload returns the request body for the query
+func (q *ReportQuery) payload() map[string]interface{} {
+	start, end := q.timeRange()
+	attributes := q.attributes
+	if len(attributes) == 0 {
+		attributes = q.defaultAttributes()
+	}
+	payload := map[string]interface{}{
+		"attributes": attributes,
+		"start":      start.UTC().UnixNano() / int64(time.Millisecond),
+		"end":        end.UTC().UnixNano() / int64(time.Millisecond),
+	}
+	if len(q.macs) > 0 {
+		macs := make([]string, 0, len(q.macs))
+		for _, mac := range q.macs {
+			macs = append(macs, strings.ToLower(mac))
+		}
+		payload["macs"] = macs
+	}
+	return payload
+}
+
+// Report runs the report query
+// query - the report query, see NewReportQuery
+func (c *Client) Report(ctx context.Context, query *ReportQuery) (*SiteReportsResponse, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	if unknown := query.unknownAttributes(); len(unknown) > 0 {
+		c.log().Warnw("requesting report attributes not known to be supported", "type", query.reportType, "attributes", unknown)
+	}
+	if query.chunked {
+		resp, err := c.reportChunks(ctx, query.chunks(), query.concurrency)
+		if resp != nil {
+			resp.Location = query.location
+		}
+		return resp, err
+	}
+	data, _ := json.Marshal(query.payload())
+
+	resp := SiteReportsResponse{Location: query.location}
+	err := c.doSiteRequest(ctx, http.MethodPost, query.site, query.path(), bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// reportChunks runs the chunk queries and merges the results in time order
+func (c *Client) reportChunks(ctx context.Context, chunks []*ReportQuery, concurrency int) (*SiteReportsResponse, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]*SiteReportsResponse, len(chunks))
+	// the first failure cancels the other chunks, their cancellation errors must not hide its cause
+	var firstErr error
+	var failOnce sync.Once
+	sem := make(chan struct{}, concurrency)
+	wg := &sync.WaitGroup{}
+dispatch:
+	for i, chunk := range chunks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break dispatch
+		}
+		wg.Add(1)
+		go func(i int, chunk *ReportQuery) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			resp, err := c.Report(ctx, chunk)
+			if err != nil {
+				if !errors.Is(err, context.Canceled) || ctx.Err() == nil {
+					failOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+				return
+			}
+			results[i] = resp
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	merged := &SiteReportsResponse{}
+	type pointKey struct {
+		oid  string
+		time int64
+	}
+	seen := make(map[pointKey]struct{})
+	for i, resp := range results {
+		if i == 0 {
+			merged.Meta = resp.Meta
+		}
+		for _, point := range resp.Data {
+			// chunk boundaries may be returned by both neighbouring chunks
+			key := pointKey{oid: point.OID(), time: point.Time().UnixNano()}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			merged.Data = append(merged.Data, point)
+		}
+	}
+	sort.SliceStable(merged.Data, func(i, j int) bool {
+		return merged.Data[i].Time().Before(merged.Data[j].Time())
+	})
+	return merged, nil
+}
@@ -0,0 +1,57 @@
+package unifi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReportChunksReturnsTheFailingChunkError(t *testing.T) {
+	end := time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)
+	start := end.Add(-2 * time.Hour)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Start int64 `json:"start"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		if payload.Start == start.UnixNano()/int64(time.Millisecond) {
+			// the first chunk only returns once the failing chunk cancelled it
+			<-r.Context().Done()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"meta":{"rc":"error","msg":"api.err.InvalidArgs"},"data":[]}`))
+	}))
+	defer srv.Close()
+	c, err := NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetUniFiOS(false)
+
+	query := NewReportQuery("default").Between(start, end).Chunked(time.Hour).Concurrency(2)
+	_, err = c.Report(context.Background(), query)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the error of the failing chunk, got %v", err)
+	}
+}
+
+func TestReportQueryAllowUnknownAttributes(t *testing.T) {
+	unknown := ReportAttribute("new-attribute")
+	query := NewReportQuery("default").Attributes(ReportAttributeNumberSTA, unknown)
+	if err := query.Validate(); err == nil {
+		t.Error("expected the unknown attribute to be rejected")
+	}
+	if err := query.AllowUnknownAttributes().Validate(); err != nil {
+		t.Errorf("expected the unknown attribute to be allowed, got %v", err)
+	}
+}
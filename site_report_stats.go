@@ -0,0 +1,195 @@
+package unifi
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ReportStatBase contains the fields common to every report data point
+type ReportStatBase struct {
+	OID    string `json:"oid"`
+	Origin string `json:"o"`    // the report type that produced this point, e.g. `site`, `ap`, `user`
+	Time   int64  `json:"time"` // epoch milliseconds
+}
+
+// Timestamp returns the data point time
+func (b ReportStatBase) Timestamp() time.Time {
+	return time.Unix(0, b.Time*int64(time.Millisecond)).UTC()
+}
+
+// TimestampIn returns the data point time in the location
+func (b ReportStatBase) TimestampIn(loc *time.Location) time.Time {
+	return b.Timestamp().In(loc)
+}
+
+// SiteStat is a single data point from the site report type
+type SiteStat struct {
+	ReportStatBase
+
+	Site          string  `json:"site"`
+	Bytes         float64 `json:"bytes"`
+	WANTXBytes    float64 `json:"wan-tx_bytes"`
+	WANRXBytes    float64 `json:"wan-rx_bytes"`
+	WAN2TXBytes   float64 `json:"wan2-tx_bytes"`
+	WAN2RXBytes   float64 `json:"wan2-rx_bytes"`
+	WLANBytes     float64 `json:"wlan_bytes"`
+	WLANRXBytes   float64 `json:"wlan-rx_bytes"`
+	WLANTXBytes   float64 `json:"wlan-tx_bytes"`
+	NumberSTA     float64 `json:"num_sta"`
+	LANNumberSTA  float64 `json:"lan-num_sta"`
+	WLANNumberSTA float64 `json:"wlan-num_sta"`
+	RXBytes       float64 `json:"rx_bytes"`
+	TXBytes       float64 `json:"tx_bytes"`
+	IPSEvents     float64 `json:"ips_events"`
+}
+
+// APStat is a single data point from the ap report type
+type APStat struct {
+	ReportStatBase
+
+	AccessPoint  string  `json:"ap"` // the access point mac
+	Bytes        float64 `json:"bytes"`
+	NumberSTA    float64 `json:"num_sta"`
+	RXBytes      float64 `json:"rx_bytes"`
+	TXBytes      float64 `json:"tx_bytes"`
+	Satisfaction float64 `json:"satisfaction"`
+	NGRXBytes    float64 `json:"ng-rx_bytes"`
+	NGTXBytes    float64 `json:"ng-tx_bytes"`
+	NGNumberSTA  float64 `json:"ng-num_sta"`
+	NARXBytes    float64 `json:"na-rx_bytes"`
+	NATXBytes    float64 `json:"na-tx_bytes"`
+	NANumberSTA  float64 `json:"na-num_sta"`
+	RXBytes6E    float64 `json:"6e-rx_bytes"`
+	TXBytes6E    float64 `json:"6e-tx_bytes"`
+	NumberSTA6E  float64 `json:"6e-num_sta"`
+}
+
+// UserStat is a single data point from the user report type
+type UserStat struct {
+	ReportStatBase
+
+	User         string  `json:"user"` // the client mac
+	Bytes        float64 `json:"bytes"`
+	RXBytes      float64 `json:"rx_bytes"`
+	TXBytes      float64 `json:"tx_bytes"`
+	Satisfaction float64 `json:"satisfaction"`
+}
+
+// SpeedTestStat is a single data point from the speedtest report type
+type SpeedTestStat struct {
+	ReportStatBase
+
+	ID           string  `json:"_id"`
+	XPutDownload float64 `json:"xput_download"` // Mbps
+	XPutUpload   float64 `json:"xput_upload"`   // Mbps
+	Latency      float64 `json:"latency"`       // milliseconds
+}
+
+// GatewayStat is a single data point from the gw report type
+type GatewayStat struct {
+	ReportStatBase
+
+	Gateway      string  `json:"gw"` // the gateway mac
+	CPU          float64 `json:"cpu"`
+	Memory       float64 `json:"mem"`
+	LoadAvg5     float64 `json:"loadavg_5"`
+	LANRXBytes   float64 `json:"lan-rx_bytes"`
+	LANTXBytes   float64 `json:"lan-tx_bytes"`
+	LANRXPackets float64 `json:"lan-rx_packets"`
+	LANTXPackets float64 `json:"lan-tx_packets"`
+	LANRXDropped float64 `json:"lan-rx_dropped"`
+	LANTXDropped float64 `json:"lan-tx_dropped"`
+	WANRXBytes   float64 `json:"wan-rx_bytes"`
+	WANTXBytes   float64 `json:"wan-tx_bytes"`
+	WANRXPackets float64 `json:"wan-rx_packets"`
+	WANTXPackets float64 `json:"wan-tx_packets"`
+	WANRXDropped float64 `json:"wan-rx_dropped"`
+	WANTXDropped float64 `json:"wan-tx_dropped"`
+	WANRXErrors  float64 `json:"wan-rx_errors"`
+	WANTXErrors  float64 `json:"wan-tx_errors"`
+}
+
+// SwitchStat is a single data point from the sw report type
+type SwitchStat struct {
+	ReportStatBase
+
+	Switch      string  `json:"sw"` // the switch mac
+	Bytes       float64 `json:"bytes"`
+	RXBytes     float64 `json:"rx_bytes"`
+	TXBytes     float64 `json:"tx_bytes"`
+	RXPackets   float64 `json:"rx_packets"`
+	TXPackets   float64 `json:"tx_packets"`
+	RXErrors    float64 `json:"rx_errors"`
+	TXErrors    float64 `json:"tx_errors"`
+	RXDropped   float64 `json:"rx_dropped"`
+	TXDropped   float64 `json:"tx_dropped"`
+	RXMulticast float64 `json:"rx_multicast"`
+	RXBroadcast float64 `json:"rx_broadcast"`
+}
+
+// Decode converts the raw report map into the provided typed value, e.g. *SiteStat
+func (r SiteReport) Decode(v interface{}) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// decodeReports converts the raw report maps into the provided typed slice pointer
+func decodeReports(reports []SiteReport, v interface{}) error {
+	data, err := json.Marshal(reports)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(data, v)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("unable to decode report into %T", v))
+	}
+	return nil
+}
+
+// SiteStats decodes the response data as site report data points
+func (r *SiteReportsResponse) SiteStats() ([]SiteStat, error) {
+	stats := make([]SiteStat, 0, len(r.Data))
+	err := decodeReports(r.Data, &stats)
+	return stats, err
+}
+
+// APStats decodes the response data as ap report data points
+func (r *SiteReportsResponse) APStats() ([]APStat, error) {
+	stats := make([]APStat, 0, len(r.Data))
+	err := decodeReports(r.Data, &stats)
+	return stats, err
+}
+
+// UserStats decodes the response data as user report data points
+func (r *SiteReportsResponse) UserStats() ([]UserStat, error) {
+	stats := make([]UserStat, 0, len(r.Data))
+	err := decodeReports(r.Data, &stats)
+	return stats, err
+}
+
+// SpeedTestStats decodes the response data as speedtest report data points
+func (r *SiteReportsResponse) SpeedTestStats() ([]SpeedTestStat, error) {
+	stats := make([]SpeedTestStat, 0, len(r.Data))
+	err := decodeReports(r.Data, &stats)
+	return stats, err
+}
+
+// GatewayStats decodes the response data as gw report data points
+func (r *SiteReportsResponse) GatewayStats() ([]GatewayStat, error) {
+	stats := make([]GatewayStat, 0, len(r.Data))
+	err := decodeReports(r.Data, &stats)
+	return stats, err
+}
+
+// SwitchStats decodes the response data as sw report data points
+func (r *SiteReportsResponse) SwitchStats() ([]SwitchStat, error) {
+	stats := make([]SwitchStat, 0, len(r.Data))
+	err := decodeReports(r.Data, &stats)
+	return stats, err
+}
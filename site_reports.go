@@ -1,10 +1,10 @@
 package unifi
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
+	"strings"
 	"time"
 )
 
@@ -28,6 +28,9 @@ type SiteReport map[string]interface{}
 type SiteReportsResponse struct {
 	Meta CommonMeta   `json:"meta"`
 	Data []SiteReport `json:"data"`
+
+	// Location is the location the report was requested in, see ReportQuery.In
+	Location *time.Location `json:"-"`
 }
 
 // ReportInterval is a defined report interval
@@ -53,6 +56,31 @@ func (r ReportInterval) IsValid() bool {
 	}
 }
 
+// Duration returns the bucket size of the interval, archive has no fixed bucket size and returns 0
+func (r ReportInterval) Duration() time.Duration {
+	switch r {
+	case ReportInterval5Min:
+		return 5 * time.Minute
+	case ReportIntervalHourly:
+		return time.Hour
+	case ReportIntervalDaily:
+		return 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// alignTime truncates the time to a multiple of the bucket size as observed in the location,
+// e.g. a 24h bucket is aligned to midnight in the location instead of midnight UTC
+func alignTime(t time.Time, bucket time.Duration, loc *time.Location) time.Time {
+	if loc == nil {
+		return t.Truncate(bucket)
+	}
+	_, offset := t.In(loc).Zone()
+	shift := time.Duration(offset) * time.Second
+	return t.Add(shift).Truncate(bucket).Add(-shift).In(loc)
+}
+
 // ReportType defines the report type
 type ReportType string
 
@@ -62,13 +90,15 @@ const (
 	ReportTypeUser      ReportType = "user"
 	ReportTypeAP        ReportType = "ap"
 	ReportTypeSpeedTest ReportType = "speedtest"
+	ReportTypeGateway   ReportType = "gw"
+	ReportTypeSwitch    ReportType = "sw"
 )
 
 // IsValid returns true if it's a valid report type.
 // there are only a few valid types
 func (r ReportType) IsValid() bool {
 	switch r {
-	case ReportTypeSite, ReportTypeUser, ReportTypeAP, ReportTypeSpeedTest:
+	case ReportTypeSite, ReportTypeUser, ReportTypeAP, ReportTypeSpeedTest, ReportTypeGateway, ReportTypeSwitch:
 		return true
 	default:
 		return false
@@ -93,6 +123,73 @@ const (
 	ReportAttributeSpeedTestDownload ReportAttribute = "xput_download"
 	ReportAttributeSpeedTestUpload   ReportAttribute = "xput_upload"
 	ReportAttributeSpeedTestLatency  ReportAttribute = "latency"
+	ReportAttributeWLANRXBytes       ReportAttribute = "wlan-rx_bytes"
+	ReportAttributeWLANTXBytes       ReportAttribute = "wlan-tx_bytes"
+	ReportAttributeSatisfaction      ReportAttribute = "satisfaction"
+	ReportAttributeIPSEvents         ReportAttribute = "ips_events"
+
+	// secondary wan
+	ReportAttributeWAN2TXBytes   ReportAttribute = "wan2-tx_bytes"
+	ReportAttributeWAN2RXBytes   ReportAttribute = "wan2-rx_bytes"
+	ReportAttributeWAN2TXPackets ReportAttribute = "wan2-tx_packets"
+	ReportAttributeWAN2RXPackets ReportAttribute = "wan2-rx_packets"
+	ReportAttributeWAN2TXDropped ReportAttribute = "wan2-tx_dropped"
+	ReportAttributeWAN2RXDropped ReportAttribute = "wan2-rx_dropped"
+
+	// per radio, `ng` for 2.4GHz, `na` for 5GHz and `6e` for 6GHz
+	ReportAttributeNGRXBytes   ReportAttribute = "ng-rx_bytes"
+	ReportAttributeNGTXBytes   ReportAttribute = "ng-tx_bytes"
+	ReportAttributeNGNumberSTA ReportAttribute = "ng-num_sta"
+	ReportAttributeNARXBytes   ReportAttribute = "na-rx_bytes"
+	ReportAttributeNATXBytes   ReportAttribute = "na-tx_bytes"
+	ReportAttributeNANumberSTA ReportAttribute = "na-num_sta"
+	ReportAttribute6ERXBytes   ReportAttribute = "6e-rx_bytes"
+	ReportAttribute6ETXBytes   ReportAttribute = "6e-tx_bytes"
+	ReportAttribute6ENumberSTA ReportAttribute = "6e-num_sta"
+
+	// per radio airtime and retries of the ap report, the channel utilization is the percent of airtime in use
+	ReportAttributeNGCUTotal   ReportAttribute = "ng-cu_total"
+	ReportAttributeNGCUSelfRX  ReportAttribute = "ng-cu_self_rx"
+	ReportAttributeNGCUSelfTX  ReportAttribute = "ng-cu_self_tx"
+	ReportAttributeNGTXPackets ReportAttribute = "ng-tx_packets"
+	ReportAttributeNGTXRetries ReportAttribute = "ng-tx_retries"
+	ReportAttributeNACUTotal   ReportAttribute = "na-cu_total"
+	ReportAttributeNACUSelfRX  ReportAttribute = "na-cu_self_rx"
+	ReportAttributeNACUSelfTX  ReportAttribute = "na-cu_self_tx"
+	ReportAttributeNATXPackets ReportAttribute = "na-tx_packets"
+	ReportAttributeNATXRetries ReportAttribute = "na-tx_retries"
+	ReportAttribute6ECUTotal   ReportAttribute = "6e-cu_total"
+	ReportAttribute6ECUSelfRX  ReportAttribute = "6e-cu_self_rx"
+	ReportAttribute6ECUSelfTX  ReportAttribute = "6e-cu_self_tx"
+	ReportAttribute6ETXPackets ReportAttribute = "6e-tx_packets"
+	ReportAttribute6ETXRetries ReportAttribute = "6e-tx_retries"
+
+	// gateway
+	ReportAttributeCPU          ReportAttribute = "cpu"
+	ReportAttributeMemory       ReportAttribute = "mem"
+	ReportAttributeLoadAvg5     ReportAttribute = "loadavg_5"
+	ReportAttributeLANRXBytes   ReportAttribute = "lan-rx_bytes"
+	ReportAttributeLANTXBytes   ReportAttribute = "lan-tx_bytes"
+	ReportAttributeLANRXPackets ReportAttribute = "lan-rx_packets"
+	ReportAttributeLANTXPackets ReportAttribute = "lan-tx_packets"
+	ReportAttributeLANRXDropped ReportAttribute = "lan-rx_dropped"
+	ReportAttributeLANTXDropped ReportAttribute = "lan-tx_dropped"
+	ReportAttributeWANRXPackets ReportAttribute = "wan-rx_packets"
+	ReportAttributeWANTXPackets ReportAttribute = "wan-tx_packets"
+	ReportAttributeWANRXDropped ReportAttribute = "wan-rx_dropped"
+	ReportAttributeWANTXDropped ReportAttribute = "wan-tx_dropped"
+	ReportAttributeWANRXErrors  ReportAttribute = "wan-rx_errors"
+	ReportAttributeWANTXErrors  ReportAttribute = "wan-tx_errors"
+
+	// switch
+	ReportAttributeRXPackets   ReportAttribute = "rx_packets"
+	ReportAttributeTXPackets   ReportAttribute = "tx_packets"
+	ReportAttributeRXErrors    ReportAttribute = "rx_errors"
+	ReportAttributeTXErrors    ReportAttribute = "tx_errors"
+	ReportAttributeRXDropped   ReportAttribute = "rx_dropped"
+	ReportAttributeTXDropped   ReportAttribute = "tx_dropped"
+	ReportAttributeRXMulticast ReportAttribute = "rx_multicast"
+	ReportAttributeRXBroadcast ReportAttribute = "rx_broadcast"
 )
 
 // AllReportAttributes contains all the normal report attributes
@@ -118,31 +215,177 @@ var SpeedTestReportAttributes = []ReportAttribute{
 	ReportAttributeTime,
 }
 
+// GatewayReportAttributes contains all the report attributes for the gateway report
+var GatewayReportAttributes = []ReportAttribute{
+	ReportAttributeCPU,
+	ReportAttributeMemory,
+	ReportAttributeLoadAvg5,
+	ReportAttributeLANRXBytes,
+	ReportAttributeLANTXBytes,
+	ReportAttributeLANRXPackets,
+	ReportAttributeLANTXPackets,
+	ReportAttributeLANRXDropped,
+	ReportAttributeLANTXDropped,
+	ReportAttributeWANRXBytes,
+	ReportAttributeWANTXBytes,
+	ReportAttributeWANRXPackets,
+	ReportAttributeWANTXPackets,
+	ReportAttributeWANRXDropped,
+	ReportAttributeWANTXDropped,
+	ReportAttributeWANRXErrors,
+	ReportAttributeWANTXErrors,
+	ReportAttributeTime,
+}
+
+// SwitchReportAttributes contains all the report attributes for the switch report
+var SwitchReportAttributes = []ReportAttribute{
+	ReportAttributeBytes,
+	ReportAttributeRXBytes,
+	ReportAttributeTXBytes,
+	ReportAttributeRXPackets,
+	ReportAttributeTXPackets,
+	ReportAttributeRXErrors,
+	ReportAttributeTXErrors,
+	ReportAttributeRXDropped,
+	ReportAttributeTXDropped,
+	ReportAttributeRXMulticast,
+	ReportAttributeRXBroadcast,
+	ReportAttributeTime,
+}
+
+// reportAttributeCatalog contains the attributes each report type is known to support
+var reportAttributeCatalog = map[ReportType][]ReportAttribute{
+	ReportTypeSite: {
+		ReportAttributeBytes,
+		ReportAttributeWANTXBytes,
+		ReportAttributeWANRXBytes,
+		ReportAttributeWAN2TXBytes,
+		ReportAttributeWAN2RXBytes,
+		ReportAttributeWLANBytes,
+		ReportAttributeWLANRXBytes,
+		ReportAttributeWLANTXBytes,
+		ReportAttributeNumberSTA,
+		ReportAttributeLANNumberSTA,
+		ReportAttributeWLANNumberSTA,
+		ReportAttributeRXBytes,
+		ReportAttributeTXBytes,
+		ReportAttributeIPSEvents,
+		ReportAttributeTime,
+	},
+	ReportTypeAP: {
+		ReportAttributeBytes,
+		ReportAttributeRXBytes,
+		ReportAttributeTXBytes,
+		ReportAttributeNumberSTA,
+		ReportAttributeSatisfaction,
+		ReportAttributeNGRXBytes,
+		ReportAttributeNGTXBytes,
+		ReportAttributeNGNumberSTA,
+		ReportAttributeNARXBytes,
+		ReportAttributeNATXBytes,
+		ReportAttributeNANumberSTA,
+		ReportAttribute6ERXBytes,
+		ReportAttribute6ETXBytes,
+		ReportAttribute6ENumberSTA,
+		ReportAttributeNGCUTotal,
+		ReportAttributeNGCUSelfRX,
+		ReportAttributeNGCUSelfTX,
+		ReportAttributeNGTXPackets,
+		ReportAttributeNGTXRetries,
+		ReportAttributeNACUTotal,
+		ReportAttributeNACUSelfRX,
+		ReportAttributeNACUSelfTX,
+		ReportAttributeNATXPackets,
+		ReportAttributeNATXRetries,
+		ReportAttribute6ECUTotal,
+		ReportAttribute6ECUSelfRX,
+		ReportAttribute6ECUSelfTX,
+		ReportAttribute6ETXPackets,
+		ReportAttribute6ETXRetries,
+		ReportAttributeTime,
+	},
+	ReportTypeUser: {
+		ReportAttributeBytes,
+		ReportAttributeRXBytes,
+		ReportAttributeTXBytes,
+		ReportAttributeSatisfaction,
+		ReportAttributeTime,
+	},
+	ReportTypeSpeedTest: SpeedTestReportAttributes,
+	ReportTypeGateway: append([]ReportAttribute{
+		ReportAttributeWAN2TXBytes,
+		ReportAttributeWAN2RXBytes,
+		ReportAttributeWAN2TXPackets,
+		ReportAttributeWAN2RXPackets,
+		ReportAttributeWAN2TXDropped,
+		ReportAttributeWAN2RXDropped,
+		ReportAttributeIPSEvents,
+	}, GatewayReportAttributes...),
+	ReportTypeSwitch: SwitchReportAttributes,
+}
+
+// Attributes returns the attributes known to be supported by the report type
+func (r ReportType) Attributes() []ReportAttribute {
+	attributes := reportAttributeCatalog[r]
+	out := make([]ReportAttribute, len(attributes))
+	copy(out, attributes)
+	return out
+}
+
+// Supports returns true if the attribute is known to be supported by the report type
+func (r ReportType) Supports(attribute ReportAttribute) bool {
+	for _, attr := range reportAttributeCatalog[r] {
+		if attr == attribute {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateAttributes checks the attributes can be requested from the report type
+// the returned error lists the valid attributes for the report type
+func (r ReportType) ValidateAttributes(attributes ...ReportAttribute) error {
+	if !r.IsValid() {
+		return fmt.Errorf("invalid reportType specified: %s", r)
+	}
+	for _, attr := range attributes {
+		if !attr.IsValid() {
+			return fmt.Errorf("invalid report attribute specified: %s", attr)
+		}
+		if !r.Supports(attr) {
+			valid := make([]string, 0, len(reportAttributeCatalog[r]))
+			for _, v := range reportAttributeCatalog[r] {
+				valid = append(valid, string(v))
+			}
+			return fmt.Errorf("report attribute %s is not supported by the %s report, valid attributes are: %s", attr, r, strings.Join(valid, ", "))
+		}
+	}
+	return nil
+}
+
 // IsValid returns true if it's a valid report attribute.
-// there are only a few valid types
+// valid attributes are supported by at least one report type
 func (r ReportAttribute) IsValid() bool {
-	switch r {
-	case ReportAttributeBytes, ReportAttributeWANTXBytes, ReportAttributeWANRXBytes, ReportAttributeWLANBytes:
-		fallthrough
-	case ReportAttributeNumberSTA, ReportAttributeLANNumberSTA, ReportAttributeWLANNumberSTA, ReportAttributeTime:
-		fallthrough
-	case ReportAttributeRXBytes, ReportAttributeTXBytes, ReportAttributeSpeedTestDownload:
-		fallthrough
-	case ReportAttributeSpeedTestUpload, ReportAttributeSpeedTestLatency:
-		return true
-	default:
-		return false
+	for reportType := range reportAttributeCatalog {
+		if reportType.Supports(r) {
+			return true
+		}
 	}
+	return false
 }
 
 // MarshalJSON implements json.Marshaler
 func (r ReportAttribute) MarshalJSON() ([]byte, error) {
-	return []byte(string(r)), nil
+	return json.Marshal(string(r))
 }
 
 // UnmarshalJSON implements json.Unmarshaler
 func (r *ReportAttribute) UnmarshalJSON(data []byte) error {
-	*r = ReportAttribute(bytes.NewBuffer(data).String())
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*r = ReportAttribute(s)
 	return nil
 }
 
@@ -152,65 +395,16 @@ func (r *ReportAttribute) UnmarshalJSON(data []byte) error {
 // endTime - end time of the report, set to 0 and startTime to 0 for default behavior
 // interval - the report interval requested
 // reportType - the report type requested
-// attributes - attributes to return, see AllReportAttributes for default behavior
+// attributes - attributes to return, see AllReportAttributes, GatewayReportAttributes and SwitchReportAttributes for default behavior
 // filterMacs - optional list of macs to filter stats.
-func (c *Client) SiteReport(site string, startTime time.Time, endTime time.Time, interval ReportInterval, reportType ReportType, attributes []ReportAttribute, filterMacs ...string) (*SiteReportsResponse, error) {
-	if startTime.IsZero() && endTime.IsZero() {
-		endTime := time.Now().UTC()
-		switch interval {
-		case ReportInterval5Min:
-			// set default to last 1h
-			startTime = endTime.Add(-1 * time.Hour)
-		case ReportIntervalHourly:
-			// set default to last 1 day
-			startTime = endTime.Add(-24 * time.Hour)
-		case ReportIntervalDaily:
-			// set default to last 7 days
-			startTime = endTime.Add(7 * 24 * time.Hour)
-		}
-	}
-
-	if !startTime.Before(endTime) || startTime == endTime {
-		return nil, fmt.Errorf("invalid end time, must occur after start time")
-	}
-
-	if !reportType.IsValid() {
-		return nil, fmt.Errorf("invalid reportType specified: %s", reportType)
-	}
-	// only archive is supported for speedtest, so override.
-	if reportType == ReportTypeSpeedTest {
-		interval = ReportIntervalArchive
-	}
-
-	if !interval.IsValid() {
-		return nil, fmt.Errorf("invalid interval specified: %s", interval)
-	}
-
-	if len(attributes) == 0 {
-		attributes = AllReportAttributes
-		if reportType == ReportTypeSpeedTest {
-			attributes = SpeedTestReportAttributes
-		}
-	} else {
-		for _, attr := range attributes {
-			if !attr.IsValid() {
-				return nil, fmt.Errorf("invalid report attribute specified: %s", attr)
-			}
-		}
-	}
-
-	payload := map[string]interface{}{
-		"attributes": attributes,
-		"start":      startTime.UTC().Unix() * 1000,
-		"end":        startTime.UTC().Unix() * 1000,
-	}
-	if len(filterMacs) > 0 {
-		payload["macs"] = filterMacs
-	}
-
-	data, _ := json.Marshal(payload)
-
-	var resp SiteReportsResponse
-	err := c.doSiteRequest(http.MethodGet, site, fmt.Sprintf("stat/report/%s.%s", interval, reportType), bytes.NewReader(data), &resp)
-	return &resp, err
+//
+// Deprecated: use Report with NewReportQuery.
+func (c *Client) SiteReport(ctx context.Context, site string, startTime time.Time, endTime time.Time, interval ReportInterval, reportType ReportType, attributes []ReportAttribute, filterMacs ...string) (*SiteReportsResponse, error) {
+	query := NewReportQuery(site).
+		Interval(interval).
+		Type(reportType).
+		Attributes(attributes...).
+		MACs(filterMacs...).
+		Between(startTime, endTime)
+	return c.Report(ctx, query)
 }
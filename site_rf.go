@@ -0,0 +1,227 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// APGroup defines a group of access points, used to scope WLANs and radio settings (v2 api)
+type APGroup struct {
+	ID           string   `json:"_id,omitempty"`
+	Name         string   `json:"name"`
+	DeviceMACs   []string `json:"device_macs"`
+	AttrNoDelete bool     `json:"attr_no_delete,omitempty"`
+	AttrHiddenID string   `json:"attr_hidden_id,omitempty"`
+}
+
+// ListAPGroups will list the access point groups
+// site - the site to query
+func (c *Client) ListAPGroups(ctx context.Context, site string) ([]APGroup, error) {
+	var groups []APGroup
+	err := c.doSiteV2Request(ctx, http.MethodGet, site, "apgroups", nil, &groups)
+	return groups, err
+}
+
+// CreateAPGroup will create a new access point group
+// site - the site to modify
+// group - the access point group to create
+func (c *Client) CreateAPGroup(ctx context.Context, site string, group *APGroup) (*APGroup, error) {
+	if group.Name == "" {
+		return nil, fmt.Errorf("must specify the AP group name")
+	}
+	normalizeAPGroup(group)
+	data, _ := json.Marshal(group)
+
+	var created APGroup
+	err := c.doSiteV2Request(ctx, http.MethodPost, site, "apgroups", bytes.NewReader(data), &created)
+	return &created, err
+}
+
+// UpdateAPGroup will update an existing access point group
+// site - the site to modify
+// group - the access point group to update, the ID must be set
+func (c *Client) UpdateAPGroup(ctx context.Context, site string, group *APGroup) (*APGroup, error) {
+	if group.ID == "" {
+		return nil, fmt.Errorf("must specify the AP group ID")
+	}
+	normalizeAPGroup(group)
+	data, _ := json.Marshal(group)
+
+	extPath := fmt.Sprintf("apgroups/%s", strings.TrimSpace(group.ID))
+
+	var updated APGroup
+	err := c.doSiteV2Request(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &updated)
+	return &updated, err
+}
+
+// DeleteAPGroup will delete an existing access point group
+// site - the site to modify
+// groupID - the ID of the access point group
+func (c *Client) DeleteAPGroup(ctx context.Context, site string, groupID string) error {
+	extPath := fmt.Sprintf("apgroups/%s", strings.TrimSpace(groupID))
+	return c.doSiteV2Request(ctx, http.MethodDelete, site, extPath, nil, nil)
+}
+
+// normalizeAPGroup lower cases the device macs, the controller expects them that way
+func normalizeAPGroup(group *APGroup) {
+	macs := make([]string, 0, len(group.DeviceMACs))
+	for _, mac := range group.DeviceMACs {
+		macs = append(macs, strings.ToLower(mac))
+	}
+	group.DeviceMACs = macs
+}
+
+// The radio bands as reported in the device radio table
+const (
+	RadioBand2G = "ng"
+	RadioBand5G = "na"
+	RadioBand6G = "6e"
+)
+
+// RadioProfile defines the radio settings of a single band, applied to the radio table of access points.
+// The controller does not store radio profiles on their own, they are kept by the caller and pushed
+// to every access point, see ApplyRadioProfiles and ApplyRadioProfilesToGroup.
+type RadioProfile struct {
+	Radio          string      // the band, e.g. RadioBand5G
+	ChannelWidth   int         // MHz, 20, 40, 80, 160 or 320, 0 to keep the current width
+	Channel        interface{} // `auto` or the channel number, nil to keep the current channel
+	TXPowerMode    string      // auto, high, medium, low or custom, empty to keep the current mode
+	TXPower        int         // dBm, custom mode only
+	MinRSSIEnabled *bool       // nil to keep the current setting
+	MinRSSI        int         // dBm, e.g. -75, only applied when MinRSSIEnabled is true
+}
+
+// validate checks the profile values
+func (p RadioProfile) validate() error {
+	switch p.Radio {
+	case RadioBand2G, RadioBand5G, RadioBand6G:
+	default:
+		return fmt.Errorf("invalid radio specified: %s", p.Radio)
+	}
+	switch p.ChannelWidth {
+	case 0, 20, 40, 80, 160, 320:
+	default:
+		return fmt.Errorf("invalid channel width specified: %d", p.ChannelWidth)
+	}
+	switch p.TXPowerMode {
+	case "", "auto", "high", "medium", "low", "custom":
+	default:
+		return fmt.Errorf("invalid tx power mode specified: %s", p.TXPowerMode)
+	}
+	return nil
+}
+
+// apply sets the profile values on the raw radio table entry, the fields the profile does not set are kept
+func (p RadioProfile) apply(radio map[string]interface{}) {
+	if p.ChannelWidth > 0 {
+		radio["ht"] = p.ChannelWidth
+	}
+	if p.Channel != nil {
+		radio["channel"] = p.Channel
+	}
+	if p.TXPowerMode != "" {
+		radio["tx_power_mode"] = p.TXPowerMode
+		if p.TXPowerMode == "custom" {
+			radio["tx_power"] = p.TXPower
+		}
+	}
+	if p.MinRSSIEnabled != nil {
+		radio["min_rssi_enabled"] = *p.MinRSSIEnabled
+		if *p.MinRSSIEnabled {
+			radio["min_rssi"] = p.MinRSSI
+		}
+	}
+}
+
+// ApplyRadioProfiles will apply the radio profiles to an access point, radios without a profile are kept as is.
+// The profiles are merged into the radio table stored by the controller, so settings not covered by a profile are kept.
+// site - the site to modify
+// mac - the access point mac
+// profiles - the radio profiles, at most one per band
+func (c *Client) ApplyRadioProfiles(ctx context.Context, site string, mac string, profiles ...RadioProfile) (*DevicesResponse, error) {
+	byRadio := make(map[string]RadioProfile, len(profiles))
+	for _, p := range profiles {
+		if err := p.validate(); err != nil {
+			return nil, err
+		}
+		byRadio[p.Radio] = p
+	}
+
+	device, err := c.rawRadioTable(ctx, site, mac)
+	if err != nil {
+		return nil, err
+	}
+	if device.Type != DeviceTypeAccessPoint {
+		return nil, fmt.Errorf("device %s is not an access point", mac)
+	}
+	for _, radio := range device.RadioTable {
+		band, _ := radio["radio"].(string)
+		if p, ok := byRadio[band]; ok {
+			p.apply(radio)
+		}
+	}
+
+	payload := map[string]interface{}{
+		"radio_table": device.RadioTable,
+	}
+	return c.updateDevice(ctx, site, device.ID, payload)
+}
+
+// rawRadioDevice is an access point with the radio table as stored by the controller
+type rawRadioDevice struct {
+	ID         string                   `json:"_id"`
+	MAC        string                   `json:"mac"`
+	Type       DeviceType               `json:"type"`
+	RadioTable []map[string]interface{} `json:"radio_table"`
+}
+
+// rawRadioTable returns the device with the radio table including every field the controller stores
+func (c *Client) rawRadioTable(ctx context.Context, site string, mac string) (*rawRadioDevice, error) {
+	var resp struct {
+		Meta CommonMeta       `json:"meta"`
+		Data []rawRadioDevice `json:"data"`
+	}
+	payload := map[string]interface{}{
+		"macs": []string{strings.ToLower(mac)},
+	}
+	data, _ := json.Marshal(payload)
+	if err := c.doSiteRequest(ctx, http.MethodPost, site, "stat/device", bytes.NewReader(data), &resp); err != nil {
+		return nil, err
+	}
+	for i := range resp.Data {
+		if strings.EqualFold(resp.Data[i].MAC, mac) {
+			return &resp.Data[i], nil
+		}
+	}
+	return nil, ErrDeviceNotFound
+}
+
+// ApplyRadioProfilesToGroup will apply the radio profiles to every access point of the group, stopping at the first failure
+// site - the site to modify
+// groupID - the ID of the access point group
+// profiles - the radio profiles, at most one per band
+func (c *Client) ApplyRadioProfilesToGroup(ctx context.Context, site string, groupID string, profiles ...RadioProfile) error {
+	groups, err := c.ListAPGroups(ctx, site)
+	if err != nil {
+		return err
+	}
+	for _, group := range groups {
+		if group.ID != groupID {
+			continue
+		}
+		for _, mac := range group.DeviceMACs {
+			_, err = c.ApplyRadioProfiles(ctx, site, mac, profiles...)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("unable to apply radio profiles to %s", mac))
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("no AP group with ID %s", groupID)
+}
@@ -0,0 +1,65 @@
+package unifi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestApplyRadioProfilesMergesRadioTable(t *testing.T) {
+	var put map[string][]map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/s/default/stat/device":
+			_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"ap1","mac":"aa:bb:cc:dd:ee:ff","type":"uap","radio_table":[
+				{"name":"wifi0","radio":"ng","channel":"auto","ht":20,"min_rssi_enabled":true,"min_rssi":-80,"sens_level_enabled":true},
+				{"name":"wifi1","radio":"na","channel":36,"ht":80,"tx_power_mode":"auto","vwire_enabled":false}]}]}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/s/default/rest/device/ap1":
+			_ = json.NewDecoder(r.Body).Decode(&put)
+			_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	c, err := NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetUniFiOS(false)
+
+	_, err = c.ApplyRadioProfiles(context.Background(), "default", "AA:BB:CC:DD:EE:FF",
+		RadioProfile{Radio: RadioBand2G, ChannelWidth: 40},
+		RadioProfile{Radio: RadioBand5G, TXPowerMode: "custom", TXPower: 17})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	radios := put["radio_table"]
+	if len(radios) != 2 {
+		t.Fatalf("expected 2 radios, got %v", radios)
+	}
+	tests := []struct {
+		radio int
+		key   string
+		want  interface{}
+	}{
+		{0, "ht", float64(40)},
+		{0, "channel", "auto"},
+		{0, "min_rssi_enabled", true},
+		{0, "min_rssi", float64(-80)},
+		{0, "sens_level_enabled", true},
+		{1, "ht", float64(80)},
+		{1, "tx_power_mode", "custom"},
+		{1, "tx_power", float64(17)},
+		{1, "vwire_enabled", false},
+	}
+	for _, tt := range tests {
+		if got := radios[tt.radio][tt.key]; got != tt.want {
+			t.Errorf("radio %d %s = %v, want %v", tt.radio, tt.key, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// KnownRogueAP defines a neighboring access point that was marked as known
+type KnownRogueAP struct {
+	ID     string `json:"_id,omitempty"`
+	SiteID string `json:"site_id,omitempty"`
+	BSSID  string `json:"mac"`
+	ESSID  string `json:"essid,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
+
+// KnownRogueAPsResponse contains the known rogue access points response
+type KnownRogueAPsResponse struct {
+	Meta CommonMeta     `json:"meta"`
+	Data []KnownRogueAP `json:"data"`
+}
+
+// ListRogueAPs will list the rogue/neighboring access points seen by the site access points
+// site - site to query
+// withinHours - only list access points seen within the last hours, defaults to 24 hours
+func (c *Client) ListRogueAPs(ctx context.Context, site string, withinHours int) (*SiteRougeAccessPointResponse, error) {
+	if withinHours <= 0 {
+		withinHours = 24
+	}
+
+	payload := map[string]interface{}{
+		"within": withinHours,
+	}
+	data, _ := json.Marshal(payload)
+
+	var resp SiteRougeAccessPointResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "stat/rogueap", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// ListKnownRogueAPs will list the neighboring access points marked as known
+// site - site to query
+func (c *Client) ListKnownRogueAPs(ctx context.Context, site string) (*KnownRogueAPsResponse, error) {
+	var resp KnownRogueAPsResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/rogueknown", nil, &resp)
+	return &resp, err
+}
+
+// MarkRogueAPKnown will mark a neighboring access point as known so it is no longer flagged as rogue
+// site - site to modify
+// ap - the access point to mark as known, the BSSID must be set
+func (c *Client) MarkRogueAPKnown(ctx context.Context, site string, ap *KnownRogueAP) (*KnownRogueAPsResponse, error) {
+	if ap.BSSID == "" {
+		return nil, fmt.Errorf("must specify the access point BSSID")
+	}
+	ap.BSSID = strings.ToLower(ap.BSSID)
+	data, _ := json.Marshal(ap)
+
+	var resp KnownRogueAPsResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "rest/rogueknown", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// DeleteKnownRogueAP will remove an access point from the known access points
+// site - site to modify
+// knownID - the ID of the known access point entry
+func (c *Client) DeleteKnownRogueAP(ctx context.Context, site string, knownID string) (*GenericResponse, error) {
+	extPath := fmt.Sprintf("rest/rogueknown/%s", strings.TrimSpace(knownID))
+
+	var resp GenericResponse
+	err := c.doSiteRequest(ctx, http.MethodDelete, site, extPath, nil, &resp)
+	return &resp, err
+}
@@ -1,8 +1,7 @@
 package unifi
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"net/http"
 )
 
@@ -43,25 +42,18 @@ type SiteRougeAccessPointResponse struct {
 // SiteRougeAccessPoints will list rouge/neighboring access points
 // site - site to query
 // withinHours - search within the last defined hours, defaults to 24 hours
-func (c *Client) SiteRougeAccessPoints(site string, seenWithinHours int) (*SiteRougeAccessPointResponse, error) {
-	if seenWithinHours < 0 {
-		seenWithinHours = 24
-	}
-
-	payload := map[string]interface{}{
-		"within": seenWithinHours,
-	}
-	data, _ := json.Marshal(payload)
-
-	var resp SiteRougeAccessPointResponse
-	err := c.doSiteRequest(http.MethodGet, site, "stat/rogueap", bytes.NewReader(data), &resp)
-	return &resp, err
+//
+// Deprecated: use ListRogueAPs.
+func (c *Client) SiteRougeAccessPoints(ctx context.Context, site string, seenWithinHours int) (*SiteRougeAccessPointResponse, error) {
+	return c.ListRogueAPs(ctx, site, seenWithinHours)
 }
 
 // SiteRougeKnownAccessPoints will list known rouge access points
 // site - site to query
-func (c *Client) SiteRougeKnownAccessPoints(site string) (*SiteRougeAccessPointResponse, error) {
+//
+// Deprecated: use ListKnownRogueAPs.
+func (c *Client) SiteRougeKnownAccessPoints(ctx context.Context, site string) (*SiteRougeAccessPointResponse, error) {
 	var resp SiteRougeAccessPointResponse
-	err := c.doSiteRequest(http.MethodGet, site, "rest/rougeknown", nil, &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/rogueknown", nil, &resp)
 	return &resp, err
 }
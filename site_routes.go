@@ -1,7 +1,12 @@
 package unifi
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 )
 
 // SiteRouteNH defines the site route information
@@ -21,9 +26,9 @@ type SiteActiveRoutesResponse struct {
 
 // SiteActiveRoutes lists active routes for the site
 // site - the site to query
-func (c *Client) SiteActiveRoutes(site string) (*SiteActiveRoutesResponse, error) {
+func (c *Client) SiteActiveRoutes(ctx context.Context, site string) (*SiteActiveRoutesResponse, error) {
 	var resp SiteActiveRoutesResponse
-	err := c.doSiteRequest(http.MethodGet, site, "stat/routing", nil, &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "stat/routing", nil, &resp)
 	return &resp, err
 }
 
@@ -38,8 +43,119 @@ type SiteUserDefinedRoutesResponse struct {
 
 // SiteUserDefinedRoutes queries the user defines routes
 // site - the site to query
-func (c *Client) SiteUserDefinedRoutes(site string) (*SiteUserDefinedRoutesResponse, error) {
+func (c *Client) SiteUserDefinedRoutes(ctx context.Context, site string) (*SiteUserDefinedRoutesResponse, error) {
 	var resp SiteUserDefinedRoutesResponse
-	err := c.doSiteRequest(http.MethodGet, site, "rest/routing", nil, &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/routing", nil, &resp)
+	return &resp, err
+}
+
+// StaticRouteType defines how a static route forwards traffic
+type StaticRouteType string
+
+// The supported static route types
+const (
+	StaticRouteTypeNextHop   StaticRouteType = "nexthop-route"
+	StaticRouteTypeInterface StaticRouteType = "interface-route"
+	StaticRouteTypeBlackhole StaticRouteType = "blackhole"
+)
+
+// IsValid returns true if it's a valid static route type.
+// there are only a few valid types
+func (t StaticRouteType) IsValid() bool {
+	switch t {
+	case StaticRouteTypeNextHop, StaticRouteTypeInterface, StaticRouteTypeBlackhole:
+		return true
+	default:
+		return false
+	}
+}
+
+// StaticRoute defines a user defined static route
+type StaticRoute struct {
+	ID        string          `json:"_id,omitempty"`
+	SiteID    string          `json:"site_id,omitempty"`
+	Name      string          `json:"name"`
+	Enabled   bool            `json:"enabled"`
+	Type      string          `json:"type"` // always `static-route`
+	RouteType StaticRouteType `json:"static-route_type"`
+	Network   string          `json:"static-route_network"`             // the destination CIDR, e.g. `10.0.0.0/8`
+	NextHop   string          `json:"static-route_nexthop,omitempty"`   // the next hop IP, nexthop-route only
+	Interface string          `json:"static-route_interface,omitempty"` // wan, wan2 or a network ID, interface-route only
+	Distance  interface{}     `json:"static-route_distance,omitempty"`  // sometimes string or int
+}
+
+// StaticRoutesResponse contains the static routes response
+type StaticRoutesResponse struct {
+	Meta CommonMeta    `json:"meta"`
+	Data []StaticRoute `json:"data"`
+}
+
+// validateStaticRoute checks the route type specific fields
+func validateStaticRoute(route *StaticRoute) error {
+	if !route.RouteType.IsValid() {
+		return fmt.Errorf("invalid static route type specified: %s", route.RouteType)
+	}
+	if route.Network == "" {
+		return fmt.Errorf("must specify the static route network")
+	}
+	if route.RouteType == StaticRouteTypeNextHop && route.NextHop == "" {
+		return fmt.Errorf("must specify the static route next hop")
+	}
+	if route.RouteType == StaticRouteTypeInterface && route.Interface == "" {
+		return fmt.Errorf("must specify the static route interface")
+	}
+	route.Type = "static-route"
+	return nil
+}
+
+// ListStaticRoutes will list the user defined static routes
+// site - the site to query
+func (c *Client) ListStaticRoutes(ctx context.Context, site string) (*StaticRoutesResponse, error) {
+	var resp StaticRoutesResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/routing", nil, &resp)
+	return &resp, err
+}
+
+// CreateStaticRoute will create a new static route
+// site - the site to modify
+// route - the static route to create
+func (c *Client) CreateStaticRoute(ctx context.Context, site string, route *StaticRoute) (*StaticRoutesResponse, error) {
+	if err := validateStaticRoute(route); err != nil {
+		return nil, err
+	}
+	data, _ := json.Marshal(route)
+
+	var resp StaticRoutesResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "rest/routing", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// UpdateStaticRoute will update an existing static route
+// site - the site to modify
+// route - the static route to update, the ID must be set
+func (c *Client) UpdateStaticRoute(ctx context.Context, site string, route *StaticRoute) (*StaticRoutesResponse, error) {
+	if route.ID == "" {
+		return nil, fmt.Errorf("must specify the static route ID")
+	}
+	if err := validateStaticRoute(route); err != nil {
+		return nil, err
+	}
+	data, _ := json.Marshal(route)
+
+	extPath := fmt.Sprintf("rest/routing/%s", strings.TrimSpace(route.ID))
+
+	var resp StaticRoutesResponse
+	err := c.doSiteRequest(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// DeleteStaticRoute will delete an existing static route
+// site - the site to modify
+// routeID - the ID of the static route
+func (c *Client) DeleteStaticRoute(ctx context.Context, site string, routeID string) (*GenericResponse, error) {
+	extPath := fmt.Sprintf("rest/routing/%s", strings.TrimSpace(routeID))
+
+	var resp GenericResponse
+	err := c.doSiteRequest(ctx, http.MethodDelete, site, extPath, nil, &resp)
 	return &resp, err
 }
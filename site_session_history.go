@@ -0,0 +1,177 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Session is a single client association session
+type Session struct {
+	ID           string `json:"_id"`
+	UserID       string `json:"user_id"`
+	MAC          string `json:"mac"`
+	Hostname     string `json:"hostname"`
+	Name         string `json:"name"`
+	IP           string `json:"ip"`
+	APMAC        string `json:"ap_mac"`
+	ESSID        string `json:"essid"`
+	IsGuest      bool   `json:"is_guest"`
+	IsWired      bool   `json:"is_wired"`
+	AssocTime    int64  `json:"assoc_time"`    // epoch seconds
+	DisassocTime int64  `json:"disassoc_time"` // epoch seconds, 0 while the session is active
+	Duration     int64  `json:"duration"`      // seconds
+	RXBytes      int64  `json:"rx_bytes"`
+	TXBytes      int64  `json:"tx_bytes"`
+	RoamCount    int    `json:"roam_count"`
+}
+
+// AssociatedAt returns the time the session started
+func (s Session) AssociatedAt() time.Time {
+	return time.Unix(s.AssocTime, 0).UTC()
+}
+
+// DisassociatedAt returns the time the session ended, zero while the session is active
+func (s Session) DisassociatedAt() time.Time {
+	if s.DisassocTime == 0 {
+		return time.Time{}
+	}
+	return time.Unix(s.DisassocTime, 0).UTC()
+}
+
+// SessionDuration returns the length of the session
+func (s Session) SessionDuration() time.Duration {
+	return time.Duration(s.Duration) * time.Second
+}
+
+// Bytes returns the total bytes sent and received during the session
+func (s Session) Bytes() int64 {
+	return s.RXBytes + s.TXBytes
+}
+
+// SessionsResponse contains the stat/session response data
+type SessionsResponse struct {
+	Meta CommonMeta `json:"meta"`
+	Data []Session  `json:"data"`
+}
+
+// Authorization is a single guest authorization
+type Authorization struct {
+	ID           string `json:"_id"`
+	MAC          string `json:"mac"`
+	IP           string `json:"ip"`
+	Hostname     string `json:"hostname"`
+	APMAC        string `json:"ap_mac"`
+	AuthorizedBy string `json:"authorized_by"` // e.g. `voucher`, `password`, `api` or the admin
+	VoucherID    string `json:"voucher_id"`
+	VoucherCode  string `json:"voucher_code"`
+	Start        int64  `json:"start"` // epoch seconds
+	End          int64  `json:"end"`   // epoch seconds
+	Duration     int64  `json:"duration"`
+	RXBytes      int64  `json:"rx_bytes"`
+	TXBytes      int64  `json:"tx_bytes"`
+	Bytes        int64  `json:"bytes"`
+}
+
+// StartTime returns the time the authorization started
+func (a Authorization) StartTime() time.Time {
+	return time.Unix(a.Start, 0).UTC()
+}
+
+// EndTime returns the time the authorization expires or expired
+func (a Authorization) EndTime() time.Time {
+	return time.Unix(a.End, 0).UTC()
+}
+
+// AuthorizationsResponse contains the stat/authorization response data
+type AuthorizationsResponse struct {
+	Meta CommonMeta      `json:"meta"`
+	Data []Authorization `json:"data"`
+}
+
+// HistoryFilter limits the session and authorization history
+type HistoryFilter struct {
+	Start time.Time   // set Start and End to 0 for the last hour
+	End   time.Time   // set Start and End to 0 for the last hour
+	MAC   string      // optional client mac
+	Type  SessionType // sessions only, defaults to all
+}
+
+// timeRange returns the filter time range or the last hour
+func (f HistoryFilter) timeRange() (time.Time, time.Time, error) {
+	start, end := f.Start, f.End
+	if start.IsZero() && end.IsZero() {
+		end = time.Now().UTC()
+		start = end.Add(-1 * time.Hour)
+	}
+	if !start.Before(end) {
+		return start, end, fmt.Errorf("invalid end time, must occur after start time")
+	}
+	return start, end, nil
+}
+
+// ListSessionHistory lists the client sessions that started within the time range
+// site - the site to query
+// filter - the time range, client and session type to list
+func (c *Client) ListSessionHistory(ctx context.Context, site string, filter HistoryFilter) (*SessionsResponse, error) {
+	start, end, err := filter.timeRange()
+	if err != nil {
+		return nil, err
+	}
+	if filter.Type == "" {
+		filter.Type = SessionTypeAll
+	}
+	if !filter.Type.IsValid() {
+		return nil, fmt.Errorf("invalid session type specified: %s", filter.Type)
+	}
+
+	payload := map[string]interface{}{
+		"type":  string(filter.Type),
+		"start": start.Unix(),
+		"end":   end.Unix(),
+	}
+	if filter.MAC != "" {
+		payload["mac"] = strings.ToLower(filter.MAC)
+	}
+	data, _ := json.Marshal(payload)
+
+	var resp SessionsResponse
+	err = c.doSiteRequest(ctx, http.MethodPost, site, "stat/session", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// ListAuthorizationHistory lists the guest authorizations that started within the time range
+// site - the site to query
+// filter - the time range and client to list, the session type is ignored
+func (c *Client) ListAuthorizationHistory(ctx context.Context, site string, filter HistoryFilter) (*AuthorizationsResponse, error) {
+	start, end, err := filter.timeRange()
+	if err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"start": start.Unix(),
+		"end":   end.Unix(),
+	}
+	data, _ := json.Marshal(payload)
+
+	var resp AuthorizationsResponse
+	err = c.doSiteRequest(ctx, http.MethodPost, site, "stat/authorization", bytes.NewReader(data), &resp)
+	if err != nil || filter.MAC == "" {
+		return &resp, err
+	}
+
+	// the controller does not filter authorizations by mac
+	authorizations := resp.Data[:0]
+	for _, a := range resp.Data {
+		if strings.EqualFold(a.MAC, filter.MAC) {
+			authorizations = append(authorizations, a)
+		}
+	}
+	resp.Data = authorizations
+	return &resp, nil
+}
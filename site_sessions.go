@@ -2,6 +2,7 @@ package unifi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -56,9 +57,11 @@ func (o SiteSessionOrder) IsValid() bool {
 // startTime - start time to query, set to 0 and endTime to 0 to get default last 1 hour behavior
 // endTime - end time to query, set to 0 and startTime to 0 to get default last 1 hour behavior
 // mac - mac to filter on, set to `""` for no filtering.
-func (c *Client) ListLoginSessions(site string, sessionType SessionType, startTime time.Time, endTime time.Time, mac string) (*GenericResponse, error) {
+//
+// Deprecated: use ListSessionHistory.
+func (c *Client) ListLoginSessions(ctx context.Context, site string, sessionType SessionType, startTime time.Time, endTime time.Time, mac string) (*GenericResponse, error) {
 	if startTime.IsZero() && endTime.IsZero() {
-		endTime := time.Now().UTC()
+		endTime = time.Now().UTC()
 		startTime = endTime.Add(-1 * time.Hour)
 	}
 	if !startTime.Before(endTime) {
@@ -81,7 +84,7 @@ func (c *Client) ListLoginSessions(site string, sessionType SessionType, startTi
 	data, _ := json.Marshal(payload)
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodGet, site, "stat/session", bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "stat/session", bytes.NewReader(data), &resp)
 	return &resp, err
 }
 
@@ -91,7 +94,7 @@ func (c *Client) ListLoginSessions(site string, sessionType SessionType, startTi
 // order - how to order the session events
 // offset - offset current request, default to 0 if zero-value
 // limit - limit the number of returned sessions, default to 100 if zero-value
-func (c *Client) ListLatestSessions(site string, mac string, order SiteSessionOrder, offset int, limit int) (*GenericResponse, error) {
+func (c *Client) ListLatestSessions(ctx context.Context, site string, mac string, order SiteSessionOrder, offset int, limit int) (*GenericResponse, error) {
 	if mac == "" {
 		return nil, fmt.Errorf("must specifiy a client device MAC")
 	}
@@ -121,7 +124,7 @@ func (c *Client) ListLatestSessions(site string, mac string, order SiteSessionOr
 	data, _ := json.Marshal(payload)
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodGet, site, "stat/session", bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "stat/session", bytes.NewReader(data), &resp)
 	return &resp, err
 }
 
@@ -129,9 +132,11 @@ func (c *Client) ListLatestSessions(site string, mac string, order SiteSessionOr
 // site - site to query
 // startTime - start time to query, set to 0 and endTime to 0 to get default last 1 hour behavior
 // endTime - end time to query, set to 0 and startTime to 0 to get default last 1 hour behavior
-func (c *Client) ListAuthorizations(site string, startTime time.Time, endTime time.Time) (*GenericResponse, error) {
+//
+// Deprecated: use ListAuthorizationHistory.
+func (c *Client) ListAuthorizations(ctx context.Context, site string, startTime time.Time, endTime time.Time) (*GenericResponse, error) {
 	if startTime.IsZero() && endTime.IsZero() {
-		endTime := time.Now().UTC()
+		endTime = time.Now().UTC()
 		startTime = endTime.Add(-1 * time.Hour)
 	}
 	if !startTime.Before(endTime) {
@@ -146,7 +151,7 @@ func (c *Client) ListAuthorizations(site string, startTime time.Time, endTime ti
 	data, _ := json.Marshal(payload)
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodGet, site, "stat/authorization", bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "stat/authorization", bytes.NewReader(data), &resp)
 	return &resp, err
 }
 
@@ -158,7 +163,7 @@ func (c *Client) ListAuthorizations(site string, startTime time.Time, endTime ti
 //
 // note: withinHours filters clients that were connected within the period
 //       the returned stats per client are all-time totals, irrespective of withinHours
-func (c *Client) ListAllUsers(site string, withinHours int, offset int, limit int) (*GenericResponse, error) {
+func (c *Client) ListAllUsers(ctx context.Context, site string, withinHours int, offset int, limit int) (*GenericResponse, error) {
 	if withinHours <= 0 {
 		withinHours = 24
 	}
@@ -182,6 +187,6 @@ func (c *Client) ListAllUsers(site string, withinHours int, offset int, limit in
 	data, _ := json.Marshal(payload)
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodGet, site, "stat/alluser", bytes.NewReader(data), &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "stat/alluser", bytes.NewReader(data), &resp)
 	return &resp, err
 }
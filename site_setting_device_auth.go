@@ -0,0 +1,140 @@
+package unifi
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// SSHKey defines a public key accepted by the SSH server of the devices, part of the mgmt settings
+type SSHKey struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"` // the key type, e.g. `ssh-ed25519`
+	Key         string `json:"key"`  // the base64 key, without type and comment
+	Comment     string `json:"comment,omitempty"`
+	Date        string `json:"date,omitempty"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+// ParseSSHKey returns the SSHKey of an authorized_keys line, e.g. `ssh-ed25519 AAAA... user@host`
+// name - the name of the key shown in the controller
+// authorizedKey - the public key in authorized_keys format, leading options are skipped
+func ParseSSHKey(name string, authorizedKey string) (SSHKey, error) {
+	fields := strings.Fields(authorizedKey)
+	for i := 0; i+1 < len(fields); i++ {
+		blob, err := base64.StdEncoding.DecodeString(fields[i+1])
+		if err != nil || sshKeyType(blob) != fields[i] {
+			continue
+		}
+		return SSHKey{
+			Name:        name,
+			Type:        fields[i],
+			Key:         base64.StdEncoding.EncodeToString(blob),
+			Comment:     strings.Join(fields[i+2:], " "),
+			Fingerprint: sshFingerprintMD5(blob),
+		}, nil
+	}
+	return SSHKey{}, fmt.Errorf("invalid ssh key specified: %s", authorizedKey)
+}
+
+// sshKeyType returns the key type encoded at the start of the public key blob, empty if the blob is malformed
+func sshKeyType(blob []byte) string {
+	if len(blob) < 4 {
+		return ""
+	}
+	n := binary.BigEndian.Uint32(blob)
+	if n == 0 || uint64(n) > uint64(len(blob)-4) {
+		return ""
+	}
+	return string(blob[4 : 4+n])
+}
+
+// sshFingerprintMD5 returns the legacy colon separated MD5 fingerprint of the public key blob, as shown by the controller
+func sshFingerprintMD5(blob []byte) string {
+	sum := md5.Sum(blob)
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// DeviceSSHCredentials defines the SSH credentials of the devices of a site
+type DeviceSSHCredentials struct {
+	Username             string
+	Password             string
+	Keys                 []SSHKey // replaces the keys when not nil, an empty slice removes all keys
+	PasswordAuthDisabled bool     // only accept the keys
+}
+
+// SetDeviceSSHCredentials will replace the SSH credentials of the devices of the site, the devices pick up the
+// credentials with their next provision. The other mgmt settings are left unchanged.
+// site - the site to modify
+// creds - the new credentials
+func (c *Client) SetDeviceSSHCredentials(ctx context.Context, site string, creds DeviceSSHCredentials) (*MgmtSettings, error) {
+	if creds.Username == "" {
+		return nil, fmt.Errorf("must specify the ssh username")
+	}
+	if creds.Password == "" && !creds.PasswordAuthDisabled {
+		return nil, fmt.Errorf("must specify the ssh password")
+	}
+	if creds.PasswordAuthDisabled && len(creds.Keys) == 0 {
+		return nil, fmt.Errorf("must specify an ssh key when password authentication is disabled")
+	}
+
+	current, err := c.GetMgmtSettings(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	settings := map[string]interface{}{
+		"key":                         "mgmt",
+		"x_ssh_username":              creds.Username,
+		"x_ssh_auth_password_enabled": !creds.PasswordAuthDisabled,
+	}
+	if creds.Password != "" {
+		settings["x_ssh_password"] = creds.Password
+	}
+	if creds.Keys != nil {
+		settings["x_ssh_keys"] = creds.Keys
+	}
+
+	var updated MgmtSettings
+	err = c.UpdateSetting(ctx, site, "mgmt", current.ID, settings, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// SetInformHostOverride will make the devices inform the host instead of the address the controller detected,
+// e.g. the public DNS name of a hosted controller. An empty host removes the override.
+// site - the site to modify, these are controller wide settings usually stored on the `default` site
+// host - the host name or IP address devices inform
+func (c *Client) SetInformHostOverride(ctx context.Context, site string, host string) error {
+	host = strings.TrimSpace(host)
+	if host != "" {
+		identity, err := c.GetSuperIdentitySettings(ctx, site)
+		if err != nil {
+			return err
+		}
+		err = c.UpdateSetting(ctx, site, "super_identity", identity.ID, map[string]interface{}{
+			"key":      "super_identity",
+			"hostname": host,
+		}, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	mgmt, err := c.GetSuperMgmtSettings(ctx, site)
+	if err != nil {
+		return err
+	}
+	return c.UpdateSetting(ctx, site, "super_mgmt", mgmt.ID, map[string]interface{}{
+		"key":                  "super_mgmt",
+		"override_inform_host": host != "",
+	}, nil)
+}
@@ -0,0 +1,109 @@
+package unifi
+
+import (
+	"context"
+)
+
+// GuestAccessAuth defines the guest portal authentication mode
+type GuestAccessAuth string
+
+// The supported guest portal authentication modes
+const (
+	GuestAccessAuthNone     GuestAccessAuth = "none"
+	GuestAccessAuthHotspot  GuestAccessAuth = "hotspot" // voucher, password and/or payment
+	GuestAccessAuthFacebook GuestAccessAuth = "facebook_wifi"
+	GuestAccessAuthCustom   GuestAccessAuth = "custom" // external portal server
+)
+
+// GuestAccessSettings contains the hotspot portal settings, the guest_access setting section
+type GuestAccessSettings struct {
+	ID     string          `json:"_id,omitempty"`
+	SiteID string          `json:"site_id,omitempty"`
+	Key    string          `json:"key,omitempty"`
+	Auth   GuestAccessAuth `json:"auth"`
+
+	PortalEnabled     bool        `json:"portal_enabled"`
+	PortalUseHostname bool        `json:"portal_use_hostname"`
+	PortalHostname    string      `json:"portal_hostname,omitempty"`
+	CustomIP          string      `json:"custom_ip,omitempty"` // external portal server, custom auth only
+	TemplateEngine    string      `json:"template_engine,omitempty"`
+	Expire            interface{} `json:"expire,omitempty"` // minutes, sometimes string or int
+	ExpireNumber      int         `json:"expire_number,omitempty"`
+	ExpireUnit        int         `json:"expire_unit,omitempty"` // minutes per unit, e.g. 60 or 1440
+
+	// redirect after authorization
+	RedirectEnabled bool   `json:"redirect_enabled"`
+	RedirectURL     string `json:"redirect_url,omitempty"`
+	RedirectHTTPS   bool   `json:"redirect_https"`
+	RedirectToHTTPS bool   `json:"redirect_to_https"`
+
+	// customization
+	PortalCustomized                   bool     `json:"portal_customized"`
+	PortalCustomizedTitle              string   `json:"portal_customized_title,omitempty"`
+	PortalCustomizedWelcomeTextEnabled bool     `json:"portal_customized_welcome_text_enabled"`
+	PortalCustomizedWelcomeText        string   `json:"portal_customized_welcome_text,omitempty"`
+	PortalCustomizedSuccessText        string   `json:"portal_customized_success_text,omitempty"`
+	PortalCustomizedTOSEnabled         bool     `json:"portal_customized_tos_enabled"`
+	PortalCustomizedTOS                string   `json:"portal_customized_tos,omitempty"`
+	PortalCustomizedBgColor            string   `json:"portal_customized_bg_color,omitempty"`
+	PortalCustomizedBoxColor           string   `json:"portal_customized_box_color,omitempty"`
+	PortalCustomizedBoxOpacity         int      `json:"portal_customized_box_opacity,omitempty"`
+	PortalCustomizedButtonColor        string   `json:"portal_customized_button_color,omitempty"`
+	PortalCustomizedButtonTextColor    string   `json:"portal_customized_button_text_color,omitempty"`
+	PortalCustomizedLinkColor          string   `json:"portal_customized_link_color,omitempty"`
+	PortalCustomizedTextColor          string   `json:"portal_customized_text_color,omitempty"`
+	PortalCustomizedLanguages          []string `json:"portal_customized_languages,omitempty"`
+	PortalCustomizedLogoEnabled        bool     `json:"portal_customized_logo_enabled"`
+	PortalCustomizedLogoFileID         string   `json:"portal_customized_logo_file_id,omitempty"`
+	PortalCustomizedBgImageEnabled     bool     `json:"portal_customized_bg_image_enabled"`
+	PortalCustomizedBgImageFileID      string   `json:"portal_customized_bg_image_file_id,omitempty"`
+	PortalCustomizedUnsplashAuthorName string   `json:"portal_customized_unsplash_author_name,omitempty"`
+
+	// voucher and password authentication
+	VoucherEnabled    bool   `json:"voucher_enabled"`
+	VoucherCustomized bool   `json:"voucher_customized"`
+	PasswordEnabled   bool   `json:"password_enabled"`
+	Password          string `json:"x_password,omitempty"`
+
+	// payment
+	PaymentEnabled      bool   `json:"payment_enabled"`
+	Gateway             string `json:"gateway,omitempty"` // paypal, stripe, authorize, quickpay, merchantwarrior, ippay
+	PaypalUseSandbox    bool   `json:"paypal_use_sandbox"`
+	PaypalUsername      string `json:"x_paypal_username,omitempty"`
+	PaypalPassword      string `json:"x_paypal_password,omitempty"`
+	PaypalSignature     string `json:"x_paypal_signature,omitempty"`
+	StripeAPIKey        string `json:"x_stripe_api_key,omitempty"`
+	AuthorizeLoginID    string `json:"x_authorize_loginid,omitempty"`
+	AuthorizeTransKey   string `json:"x_authorize_transactionkey,omitempty"`
+	AuthorizeUseSandbox bool   `json:"authorize_use_sandbox"`
+
+	// pre-authorization access
+	AllowedSubnets    []string `json:"allowed_subnet,omitempty"`
+	RestrictedSubnet1 string   `json:"restricted_subnet_1,omitempty"`
+	RestrictedSubnet2 string   `json:"restricted_subnet_2,omitempty"`
+	RestrictedSubnet3 string   `json:"restricted_subnet_3,omitempty"`
+}
+
+// GetGuestAccessSettings returns the hotspot portal settings
+// site - the site to query
+func (c *Client) GetGuestAccessSettings(ctx context.Context, site string) (*GuestAccessSettings, error) {
+	var settings GuestAccessSettings
+	err := c.GetSetting(ctx, site, "guest_access", &settings)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateGuestAccessSettings will update the hotspot portal settings
+// site - the site to modify
+// settings - the settings to apply, the ID must be set, see GetGuestAccessSettings
+func (c *Client) UpdateGuestAccessSettings(ctx context.Context, site string, settings *GuestAccessSettings) (*GuestAccessSettings, error) {
+	settings.Key = "guest_access"
+	var updated GuestAccessSettings
+	err := c.UpdateSetting(ctx, site, "guest_access", settings.ID, settings, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
@@ -0,0 +1,79 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LookupCountryCode returns the country code supported by the controller for the country
+// site - the site to query
+// country - the ISO 3166-1 alpha-2 code, e.g. `US`, the numeric code, e.g. `840`, or the country name
+func (c *Client) LookupCountryCode(ctx context.Context, site string, country string) (*SiteCountryCode, error) {
+	country = strings.TrimSpace(country)
+	resp, err := c.SiteCountryCodes(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	for i := range resp.Data {
+		cc := &resp.Data[i]
+		if strings.EqualFold(cc.Key, country) || strings.EqualFold(cc.Name, country) || fmt.Sprint(cc.Code) == country {
+			return cc, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid country specified: %s", country)
+}
+
+// SetCountry will set the regulatory country of the site, it decides the channels and transmit power available
+// to the radios, so it must be set before the RF configuration is applied
+// site - the site to modify
+// country - the ISO 3166-1 alpha-2 code, e.g. `US`, the numeric code, e.g. `840`, or the country name
+func (c *Client) SetCountry(ctx context.Context, site string, country string) (*CountrySettings, error) {
+	cc, err := c.LookupCountryCode(ctx, site, country)
+	if err != nil {
+		return nil, err
+	}
+	current, err := c.GetCountrySettings(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated CountrySettings
+	err = c.UpdateSetting(ctx, site, "country", current.ID, map[string]interface{}{
+		"key":  "country",
+		"code": cc.Code,
+	}, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// SetTimezone will set the timezone of the site, used for schedules and the device clocks.
+// The timezone is validated against the timezone database of the host.
+// site - the site to modify
+// timezone - the IANA timezone, e.g. `America/Chicago`
+func (c *Client) SetTimezone(ctx context.Context, site string, timezone string) (*LocaleSettings, error) {
+	timezone = strings.TrimSpace(timezone)
+	if timezone == "" || strings.EqualFold(timezone, "local") {
+		return nil, fmt.Errorf("invalid timezone specified: %s", timezone)
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, fmt.Errorf("invalid timezone specified: %s", timezone)
+	}
+	current, err := c.GetLocaleSettings(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated LocaleSettings
+	err = c.UpdateSetting(ctx, site, "locale", current.ID, map[string]interface{}{
+		"key":      "locale",
+		"timezone": timezone,
+	}, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
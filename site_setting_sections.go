@@ -0,0 +1,385 @@
+package unifi
+
+import (
+	"context"
+)
+
+// MgmtSettings contains the site management settings, the mgmt setting section
+type MgmtSettings struct {
+	ID     string `json:"_id,omitempty"`
+	SiteID string `json:"site_id,omitempty"`
+	Key    string `json:"key,omitempty"`
+
+	AdvancedFeatureEnabled bool     `json:"advanced_feature_enabled"`
+	AlertEnabled           bool     `json:"alert_enabled"`
+	AutoUpgrade            bool     `json:"auto_upgrade"`
+	AutoUpgradeHour        int      `json:"auto_upgrade_hour"`
+	LEDEnabled             bool     `json:"led_enabled"`
+	OutdoorModeEnabled     bool     `json:"outdoor_mode_enabled"`
+	BootSound              bool     `json:"boot_sound"`
+	UnifiIDPEnabled        bool     `json:"unifi_idp_enabled"`
+	SSHEnabled             bool     `json:"x_ssh_enabled"`
+	SSHAuthPasswordEnabled bool     `json:"x_ssh_auth_password_enabled"`
+	SSHBindWildcard        bool     `json:"x_ssh_bind_wildcard"`
+	SSHUsername            string   `json:"x_ssh_username,omitempty"`
+	SSHPassword            string   `json:"x_ssh_password,omitempty"`
+	SSHKeys                []SSHKey `json:"x_ssh_keys,omitempty"`
+}
+
+// GetMgmtSettings returns the site management settings
+// site - the site to query
+func (c *Client) GetMgmtSettings(ctx context.Context, site string) (*MgmtSettings, error) {
+	var settings MgmtSettings
+	err := c.GetSetting(ctx, site, "mgmt", &settings)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateMgmtSettings will update the site management settings
+// site - the site to modify
+// settings - the settings to apply, the ID must be set, see GetMgmtSettings
+func (c *Client) UpdateMgmtSettings(ctx context.Context, site string, settings *MgmtSettings) (*MgmtSettings, error) {
+	settings.Key = "mgmt"
+	var updated MgmtSettings
+	err := c.UpdateSetting(ctx, site, "mgmt", settings.ID, settings, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// CountrySettings contains the site country settings, the country setting section
+type CountrySettings struct {
+	ID     string `json:"_id,omitempty"`
+	SiteID string `json:"site_id,omitempty"`
+	Key    string `json:"key,omitempty"`
+
+	Code interface{} `json:"code"` // the numeric country code from SiteCountryCodes, sometimes string or int
+}
+
+// GetCountrySettings returns the site country settings
+// site - the site to query
+func (c *Client) GetCountrySettings(ctx context.Context, site string) (*CountrySettings, error) {
+	var settings CountrySettings
+	err := c.GetSetting(ctx, site, "country", &settings)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateCountrySettings will update the site country settings
+// site - the site to modify
+// settings - the settings to apply, the ID must be set, see GetCountrySettings
+func (c *Client) UpdateCountrySettings(ctx context.Context, site string, settings *CountrySettings) (*CountrySettings, error) {
+	settings.Key = "country"
+	var updated CountrySettings
+	err := c.UpdateSetting(ctx, site, "country", settings.ID, settings, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// LocaleSettings contains the site locale settings, the locale setting section
+type LocaleSettings struct {
+	ID     string `json:"_id,omitempty"`
+	SiteID string `json:"site_id,omitempty"`
+	Key    string `json:"key,omitempty"`
+
+	Timezone string `json:"timezone"` // IANA timezone, e.g. `America/Chicago`
+}
+
+// GetLocaleSettings returns the site locale settings
+// site - the site to query
+func (c *Client) GetLocaleSettings(ctx context.Context, site string) (*LocaleSettings, error) {
+	var settings LocaleSettings
+	err := c.GetSetting(ctx, site, "locale", &settings)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateLocaleSettings will update the site locale settings
+// site - the site to modify
+// settings - the settings to apply, the ID must be set, see GetLocaleSettings
+func (c *Client) UpdateLocaleSettings(ctx context.Context, site string, settings *LocaleSettings) (*LocaleSettings, error) {
+	settings.Key = "locale"
+	var updated LocaleSettings
+	err := c.UpdateSetting(ctx, site, "locale", settings.ID, settings, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// SNMPSettings contains the site SNMP settings, the snmp setting section
+type SNMPSettings struct {
+	ID     string `json:"_id,omitempty"`
+	SiteID string `json:"site_id,omitempty"`
+	Key    string `json:"key,omitempty"`
+
+	Enabled   bool   `json:"enabled"`
+	Community string `json:"community,omitempty"`
+	EnabledV3 bool   `json:"enabledV3"`
+	Username  string `json:"username,omitempty"`
+	Password  string `json:"x_password,omitempty"`
+}
+
+// GetSNMPSettings returns the site SNMP settings
+// site - the site to query
+func (c *Client) GetSNMPSettings(ctx context.Context, site string) (*SNMPSettings, error) {
+	var settings SNMPSettings
+	err := c.GetSetting(ctx, site, "snmp", &settings)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateSNMPSettings will update the site SNMP settings
+// site - the site to modify
+// settings - the settings to apply, the ID must be set, see GetSNMPSettings
+func (c *Client) UpdateSNMPSettings(ctx context.Context, site string, settings *SNMPSettings) (*SNMPSettings, error) {
+	settings.Key = "snmp"
+	var updated SNMPSettings
+	err := c.UpdateSetting(ctx, site, "snmp", settings.ID, settings, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// RsyslogdSettings contains the remote logging settings of the devices, the rsyslogd setting section
+type RsyslogdSettings struct {
+	ID     string `json:"_id,omitempty"`
+	SiteID string `json:"site_id,omitempty"`
+	Key    string `json:"key,omitempty"`
+
+	Enabled           bool     `json:"enabled"`
+	IP                string   `json:"ip,omitempty"`   // the syslog server
+	Port              int      `json:"port,omitempty"` // defaults to 514
+	Debug             bool     `json:"debug"`          // include debug level messages
+	Contents          []string `json:"contents,omitempty"`
+	ThisController    bool     `json:"this_controller"` // log to the controller instead of IP
+	NetconsoleEnabled bool     `json:"netconsole_enabled"`
+	NetconsoleHost    string   `json:"netconsole_host,omitempty"`
+	NetconsolePort    int      `json:"netconsole_port,omitempty"` // defaults to 514
+}
+
+// GetRsyslogdSettings returns the remote logging settings
+// site - the site to query
+func (c *Client) GetRsyslogdSettings(ctx context.Context, site string) (*RsyslogdSettings, error) {
+	var settings RsyslogdSettings
+	err := c.GetSetting(ctx, site, "rsyslogd", &settings)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateRsyslogdSettings will update the remote logging settings
+// site - the site to modify
+// settings - the settings to apply, the ID must be set, see GetRsyslogdSettings
+func (c *Client) UpdateRsyslogdSettings(ctx context.Context, site string, settings *RsyslogdSettings) (*RsyslogdSettings, error) {
+	settings.Key = "rsyslogd"
+	var updated RsyslogdSettings
+	err := c.UpdateSetting(ctx, site, "rsyslogd", settings.ID, settings, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// NTPSettings contains the site NTP settings, the ntp setting section
+type NTPSettings struct {
+	ID     string `json:"_id,omitempty"`
+	SiteID string `json:"site_id,omitempty"`
+	Key    string `json:"key,omitempty"`
+
+	SettingPreference string `json:"setting_preference,omitempty"` // auto or manual
+	NTPServer1        string `json:"ntp_server_1"`
+	NTPServer2        string `json:"ntp_server_2"`
+	NTPServer3        string `json:"ntp_server_3"`
+	NTPServer4        string `json:"ntp_server_4"`
+}
+
+// GetNTPSettings returns the site NTP settings
+// site - the site to query
+func (c *Client) GetNTPSettings(ctx context.Context, site string) (*NTPSettings, error) {
+	var settings NTPSettings
+	err := c.GetSetting(ctx, site, "ntp", &settings)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateNTPSettings will update the site NTP settings
+// site - the site to modify
+// settings - the settings to apply, the ID must be set, see GetNTPSettings
+func (c *Client) UpdateNTPSettings(ctx context.Context, site string, settings *NTPSettings) (*NTPSettings, error) {
+	settings.Key = "ntp"
+	var updated NTPSettings
+	err := c.UpdateSetting(ctx, site, "ntp", settings.ID, settings, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// ConnectivitySettings contains the site uplink connectivity monitor and wireless mesh settings, the connectivity setting section
+type ConnectivitySettings struct {
+	ID     string `json:"_id,omitempty"`
+	SiteID string `json:"site_id,omitempty"`
+	Key    string `json:"key,omitempty"`
+
+	Enabled    bool   `json:"enabled"`
+	UplinkType string `json:"uplink_type,omitempty"` // gateway or custom
+	UplinkHost string `json:"uplink_host,omitempty"` // custom uplink host to monitor
+	MeshESSID  string `json:"x_mesh_essid,omitempty"`
+	MeshPSK    string `json:"x_mesh_psk,omitempty"`
+}
+
+// GetConnectivitySettings returns the site uplink connectivity monitor and wireless mesh settings
+// site - the site to query
+func (c *Client) GetConnectivitySettings(ctx context.Context, site string) (*ConnectivitySettings, error) {
+	var settings ConnectivitySettings
+	err := c.GetSetting(ctx, site, "connectivity", &settings)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateConnectivitySettings will update the site uplink connectivity monitor and wireless mesh settings
+// site - the site to modify
+// settings - the settings to apply, the ID must be set, see GetConnectivitySettings
+func (c *Client) UpdateConnectivitySettings(ctx context.Context, site string, settings *ConnectivitySettings) (*ConnectivitySettings, error) {
+	settings.Key = "connectivity"
+	var updated ConnectivitySettings
+	err := c.UpdateSetting(ctx, site, "connectivity", settings.ID, settings, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// SuperMgmtSettings contains the controller wide management settings, usually stored on the `default` site, the super_mgmt setting section
+type SuperMgmtSettings struct {
+	ID     string `json:"_id,omitempty"`
+	SiteID string `json:"site_id,omitempty"`
+	Key    string `json:"key,omitempty"`
+
+	AutoBackupEnabled                  bool        `json:"autobackup_enabled"`
+	AutoBackupCronExpr                 string      `json:"autobackup_cron_expr,omitempty"`
+	AutoBackupTimezone                 string      `json:"autobackup_timezone,omitempty"`
+	AutoBackupDays                     int         `json:"autobackup_days"`
+	AutoBackupMaxFiles                 int         `json:"autobackup_max_files"`
+	OverrideInformHost                 bool        `json:"override_inform_host"`
+	DiscoverableEnabled                bool        `json:"discoverable"`
+	AnalyticsDisapproved               bool        `json:"analytics_disapproved"`
+	LiveChat                           string      `json:"live_chat,omitempty"`
+	LiveUpdates                        string      `json:"live_updates,omitempty"`
+	StoreEnabled                       string      `json:"store_enabled,omitempty"`
+	ContactInfoCompanyName             string      `json:"contact_info_company_name,omitempty"`
+	ContactInfoFullName                string      `json:"contact_info_full_name,omitempty"`
+	ContactInfoPhoneNumber             string      `json:"contact_info_phone_number,omitempty"`
+	ContactInfoEmail                   string      `json:"contact_info_email,omitempty"`
+	DefaultSiteDeviceAuthPasswordAlert interface{} `json:"default_site_device_auth_password_alert,omitempty"` // sometimes bool or string
+}
+
+// GetSuperMgmtSettings returns the controller wide management settings, usually stored on the `default` site
+// site - the site to query
+func (c *Client) GetSuperMgmtSettings(ctx context.Context, site string) (*SuperMgmtSettings, error) {
+	var settings SuperMgmtSettings
+	err := c.GetSetting(ctx, site, "super_mgmt", &settings)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateSuperMgmtSettings will update the controller wide management settings, usually stored on the `default` site
+// site - the site to modify
+// settings - the settings to apply, the ID must be set, see GetSuperMgmtSettings
+func (c *Client) UpdateSuperMgmtSettings(ctx context.Context, site string, settings *SuperMgmtSettings) (*SuperMgmtSettings, error) {
+	settings.Key = "super_mgmt"
+	var updated SuperMgmtSettings
+	err := c.UpdateSetting(ctx, site, "super_mgmt", settings.ID, settings, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// SuperIdentitySettings contains the controller identity, usually stored on the `default` site, the super_identity setting section
+type SuperIdentitySettings struct {
+	ID     string `json:"_id,omitempty"`
+	SiteID string `json:"site_id,omitempty"`
+	Key    string `json:"key,omitempty"`
+
+	Name     string `json:"name,omitempty"`
+	Hostname string `json:"hostname,omitempty"` // the inform host used when the super_mgmt override_inform_host is set
+}
+
+// GetSuperIdentitySettings returns the controller identity, usually stored on the `default` site
+// site - the site to query
+func (c *Client) GetSuperIdentitySettings(ctx context.Context, site string) (*SuperIdentitySettings, error) {
+	var settings SuperIdentitySettings
+	err := c.GetSetting(ctx, site, "super_identity", &settings)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateSuperIdentitySettings will update the controller identity, usually stored on the `default` site
+// site - the site to modify
+// settings - the settings to apply, the ID must be set, see GetSuperIdentitySettings
+func (c *Client) UpdateSuperIdentitySettings(ctx context.Context, site string, settings *SuperIdentitySettings) (*SuperIdentitySettings, error) {
+	settings.Key = "super_identity"
+	var updated SuperIdentitySettings
+	err := c.UpdateSetting(ctx, site, "super_identity", settings.ID, settings, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// AutoSpeedtestSettings contains the scheduled WAN speed test settings, the auto_speedtest setting section
+type AutoSpeedtestSettings struct {
+	ID     string `json:"_id,omitempty"`
+	SiteID string `json:"site_id,omitempty"`
+	Key    string `json:"key,omitempty"`
+
+	Enabled  bool   `json:"enabled"`
+	CronExpr string `json:"cron_expr,omitempty"` // the schedule, e.g. `0 */4 * * *`
+}
+
+// GetAutoSpeedtestSettings returns the scheduled WAN speed test settings
+// site - the site to query
+func (c *Client) GetAutoSpeedtestSettings(ctx context.Context, site string) (*AutoSpeedtestSettings, error) {
+	var settings AutoSpeedtestSettings
+	err := c.GetSetting(ctx, site, "auto_speedtest", &settings)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateAutoSpeedtestSettings will update the scheduled WAN speed test settings
+// site - the site to modify
+// settings - the settings to apply, the ID must be set, see GetAutoSpeedtestSettings
+func (c *Client) UpdateAutoSpeedtestSettings(ctx context.Context, site string, settings *AutoSpeedtestSettings) (*AutoSpeedtestSettings, error) {
+	settings.Key = "auto_speedtest"
+	var updated AutoSpeedtestSettings
+	err := c.UpdateSetting(ctx, site, "auto_speedtest", settings.ID, settings, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
@@ -0,0 +1,68 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// snmpV3MinPasswordLength is the minimum SNMPv3 password length, shorter passwords are rejected by the devices
+const snmpV3MinPasswordLength = 8
+
+// EnableSNMPv2c will enable SNMPv2c with the read-only community on the devices of the site, SNMPv3 is left unchanged
+// site - the site to modify
+// community - the read-only community
+func (c *Client) EnableSNMPv2c(ctx context.Context, site string, community string) (*SNMPSettings, error) {
+	community = strings.TrimSpace(community)
+	if community == "" {
+		return nil, fmt.Errorf("must specify the snmp community")
+	}
+	return c.updateSNMPSettings(ctx, site, map[string]interface{}{
+		"enabled":   true,
+		"community": community,
+	})
+}
+
+// EnableSNMPv3 will enable SNMPv3 with the user on the devices of the site, SNMPv2c is left unchanged
+// site - the site to modify
+// username - the SNMPv3 user
+// password - the SNMPv3 authentication and privacy password, at least 8 characters
+func (c *Client) EnableSNMPv3(ctx context.Context, site string, username string, password string) (*SNMPSettings, error) {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return nil, fmt.Errorf("must specify the snmp username")
+	}
+	if len(password) < snmpV3MinPasswordLength {
+		return nil, fmt.Errorf("snmp password must be at least %d characters", snmpV3MinPasswordLength)
+	}
+	return c.updateSNMPSettings(ctx, site, map[string]interface{}{
+		"enabledV3":  true,
+		"username":   username,
+		"x_password": password,
+	})
+}
+
+// DisableSNMP will disable SNMPv2c and SNMPv3 on the devices of the site, the community and user are kept
+// site - the site to modify
+func (c *Client) DisableSNMP(ctx context.Context, site string) (*SNMPSettings, error) {
+	return c.updateSNMPSettings(ctx, site, map[string]interface{}{
+		"enabled":   false,
+		"enabledV3": false,
+	})
+}
+
+// updateSNMPSettings applies the fields to the snmp setting section of the site
+func (c *Client) updateSNMPSettings(ctx context.Context, site string, settings map[string]interface{}) (*SNMPSettings, error) {
+	current, err := c.GetSNMPSettings(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	settings["key"] = "snmp"
+
+	var updated SNMPSettings
+	err = c.UpdateSetting(ctx, site, "snmp", current.ID, settings, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
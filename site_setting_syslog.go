@@ -0,0 +1,85 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net"
+)
+
+// DefaultSyslogPort is the port of the syslog and netconsole servers when none is configured
+const DefaultSyslogPort = 514
+
+// EnableRemoteSyslog will make the devices of the site send their logs to the syslog server, netconsole is left unchanged
+// site - the site to modify
+// ip - the IP address of the syslog server, the devices do not resolve host names
+// port - the syslog port, DefaultSyslogPort when 0
+// debug - include debug level messages
+func (c *Client) EnableRemoteSyslog(ctx context.Context, site string, ip string, port int, debug bool) (*RsyslogdSettings, error) {
+	port, err := syslogTarget(ip, port)
+	if err != nil {
+		return nil, err
+	}
+	return c.updateRsyslogdSettings(ctx, site, map[string]interface{}{
+		"enabled":         true,
+		"this_controller": false,
+		"ip":              ip,
+		"port":            port,
+		"debug":           debug,
+	})
+}
+
+// EnableNetconsole will make the devices of the site send their kernel console to the netconsole server, which
+// captures the messages of crashing devices that syslog misses. Remote syslog is left unchanged.
+// site - the site to modify
+// ip - the IP address of the netconsole server
+// port - the netconsole port, DefaultSyslogPort when 0
+func (c *Client) EnableNetconsole(ctx context.Context, site string, ip string, port int) (*RsyslogdSettings, error) {
+	port, err := syslogTarget(ip, port)
+	if err != nil {
+		return nil, err
+	}
+	return c.updateRsyslogdSettings(ctx, site, map[string]interface{}{
+		"netconsole_enabled": true,
+		"netconsole_host":    ip,
+		"netconsole_port":    port,
+	})
+}
+
+// DisableRemoteLogging will disable remote syslog and netconsole for the devices of the site, the servers are kept
+// site - the site to modify
+func (c *Client) DisableRemoteLogging(ctx context.Context, site string) (*RsyslogdSettings, error) {
+	return c.updateRsyslogdSettings(ctx, site, map[string]interface{}{
+		"enabled":            false,
+		"netconsole_enabled": false,
+	})
+}
+
+// syslogTarget validates the server address and returns the port with the default applied
+func syslogTarget(ip string, port int) (int, error) {
+	if net.ParseIP(ip) == nil {
+		return 0, fmt.Errorf("invalid syslog server specified: %s", ip)
+	}
+	if port == 0 {
+		port = DefaultSyslogPort
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid syslog port specified: %d", port)
+	}
+	return port, nil
+}
+
+// updateRsyslogdSettings applies the fields to the rsyslogd setting section of the site
+func (c *Client) updateRsyslogdSettings(ctx context.Context, site string, settings map[string]interface{}) (*RsyslogdSettings, error) {
+	current, err := c.GetRsyslogdSettings(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	settings["key"] = "rsyslogd"
+
+	var updated RsyslogdSettings
+	err = c.UpdateSetting(ctx, site, "rsyslogd", current.ID, settings, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
@@ -0,0 +1,81 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SettingKey returns the setting section key, e.g. `mgmt`
+func (s SiteDetailedSettings) SettingKey() string {
+	key, _ := s["key"].(string)
+	return key
+}
+
+// ListSettingKeys returns the keys of all setting sections of the site
+// site - the site to query
+func (c *Client) ListSettingKeys(ctx context.Context, site string) ([]string, error) {
+	resp, err := c.SiteDetailedSettings(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(resp.Data))
+	for _, s := range resp.Data {
+		keys = append(keys, s.SettingKey())
+	}
+	return keys, nil
+}
+
+// GetSetting decodes a rest/setting section into ret, this supports sections without a typed accessor.
+// site - the site to query
+// key - the setting section key, e.g. `mgmt`
+// ret - pointer to decode the section into, e.g. *MgmtSettings or *map[string]interface{}
+func (c *Client) GetSetting(ctx context.Context, site string, key string, ret interface{}) error {
+	var resp struct {
+		Meta CommonMeta        `json:"meta"`
+		Data []json.RawMessage `json:"data"`
+	}
+	err := c.doSiteRequest(ctx, http.MethodGet, site, path.Join("rest/setting", key), nil, &resp)
+	if err != nil {
+		return err
+	}
+	if len(resp.Data) == 0 {
+		return fmt.Errorf("controller did not return the %s settings", key)
+	}
+	if err := json.Unmarshal(resp.Data[0], ret); err != nil {
+		return errors.Wrap(err, ErrJSONDecode.Error())
+	}
+	return nil
+}
+
+// UpdateSetting updates a rest/setting section and decodes the updated section into ret.
+// site - the site to modify
+// key - the setting section key, e.g. `mgmt`
+// id - the _id of the setting section
+// settings - the settings to apply, fields not included are left unchanged
+// ret - optional pointer to decode the updated section into
+func (c *Client) UpdateSetting(ctx context.Context, site string, key string, id string, settings interface{}, ret interface{}) error {
+	if strings.TrimSpace(id) == "" {
+		return fmt.Errorf("must specify the %s settings ID", key)
+	}
+	data, _ := json.Marshal(settings)
+
+	var resp struct {
+		Meta CommonMeta        `json:"meta"`
+		Data []json.RawMessage `json:"data"`
+	}
+	err := c.doSiteRequest(ctx, http.MethodPut, site, path.Join("rest/setting", key, strings.TrimSpace(id)), bytes.NewReader(data), &resp)
+	if err != nil || ret == nil || len(resp.Data) == 0 {
+		return err
+	}
+	if err := json.Unmarshal(resp.Data[0], ret); err != nil {
+		return errors.Wrap(err, ErrJSONDecode.Error())
+	}
+	return nil
+}
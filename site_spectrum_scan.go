@@ -0,0 +1,67 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// SpectrumScanChannel is the scan result for a single channel
+type SpectrumScanChannel struct {
+	Channel          int      `json:"channel"`
+	Frequency        int      `json:"freq"` // MHz
+	Width            int      `json:"width"`
+	Utilization      float64  `json:"utilization"`  // percent
+	Interference     float64  `json:"interference"` // percent
+	InterferenceType []string `json:"interference_type"`
+	Radio            string   `json:"radio"` // `ng` for 2.4GHz, `na` for 5GHz
+}
+
+// SpectrumScanResult contains the spectrum scan state and results of an access point
+type SpectrumScanResult struct {
+	MAC               string                `json:"mac"`
+	Scanning          bool                  `json:"spectrum_scanning"`
+	SpectrumTable     []SpectrumScanChannel `json:"spectrum_table"`
+	SpectrumTableTime int64                 `json:"spectrum_table_time"` // epoch milliseconds of the last completed scan
+}
+
+// SpectrumScanResponse contains the stat/spectrum-scan response
+type SpectrumScanResponse struct {
+	Meta CommonMeta           `json:"meta"`
+	Data []SpectrumScanResult `json:"data"`
+}
+
+// StartSpectrumScan will trigger a RF scan on an access point, clients are disconnected during the scan.
+// site - site this device currently registered to
+// apMAC - the access point mac
+func (c *Client) StartSpectrumScan(ctx context.Context, site string, apMAC string) (*GenericResponse, error) {
+	payload := map[string]interface{}{
+		"cmd": "spectrum-scan",
+		"mac": strings.ToLower(apMAC),
+	}
+	data, _ := json.Marshal(payload)
+
+	var resp GenericResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// SpectrumScanState returns the RF scan state and the results of the last completed scan
+// site - site this device currently registered to
+// apMAC - the access point mac
+func (c *Client) SpectrumScanState(ctx context.Context, site string, apMAC string) (*SpectrumScanResult, error) {
+	extPath := fmt.Sprintf("stat/spectrum-scan/%s", strings.ToLower(strings.TrimSpace(apMAC)))
+
+	var resp SpectrumScanResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, extPath, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, ErrDeviceNotFound
+	}
+	return &resp.Data[0], nil
+}
@@ -0,0 +1,80 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SpeedTestStatusData contains the gateway speed test state
+type SpeedTestStatusData struct {
+	Latency        float64 `json:"latency"` // milliseconds
+	RunDate        int64   `json:"rundate"` // epoch seconds of the last run
+	Runtime        int     `json:"runtime"` // seconds
+	StatusDownload int     `json:"status_download"`
+	StatusPing     int     `json:"status_ping"`
+	StatusSummary  int     `json:"status_summary"`
+	StatusUpload   int     `json:"status_upload"`
+	XPutDownload   float64 `json:"xput_download"` // Mbps
+	XPutUpload     float64 `json:"xput_upload"`   // Mbps
+	ServerHost     string  `json:"server_host,omitempty"`
+}
+
+// LastRun returns the time of the last speed test run
+func (d SpeedTestStatusData) LastRun() time.Time {
+	return time.Unix(d.RunDate, 0).UTC()
+}
+
+// SpeedTestStatusResponse contains the speedtest-status response
+type SpeedTestStatusResponse struct {
+	Meta CommonMeta            `json:"meta"`
+	Data []SpeedTestStatusData `json:"data"`
+}
+
+// RunSpeedTest will start a WAN speed test on the gateway, poll the result with SpeedTestStatus or WaitSpeedTest.
+// site - site to run the speed test on
+func (c *Client) RunSpeedTest(ctx context.Context, site string) (*GenericResponse, error) {
+	data := []byte(`{"cmd": "speedtest"}`)
+
+	var resp GenericResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// SpeedTestStatus will get the current state of a speed test.
+// site - site to query
+func (c *Client) SpeedTestStatus(ctx context.Context, site string) (*SpeedTestStatusResponse, error) {
+	data := []byte(`{"cmd": "speedtest-status"}`)
+
+	var resp SpeedTestStatusResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/devmgr", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// WaitSpeedTest polls the speed test status until a run started at or after since has finished.
+// site - site to query
+// since - the time the speed test was started, see RunSpeedTest
+// interval - the poll interval, defaults to 5 seconds
+func (c *Client) WaitSpeedTest(ctx context.Context, site string, since time.Time, interval time.Duration) (*SpeedTestStatusData, error) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	for {
+		if err := sleepContext(ctx, interval); err != nil {
+			return nil, err
+		}
+		resp, err := c.SpeedTestStatus(ctx, site)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Data) == 0 {
+			return nil, fmt.Errorf("controller did not return the speed test status")
+		}
+		status := resp.Data[0]
+		if status.RunDate >= since.Unix() && status.Runtime > 0 {
+			return &status, nil
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package unifi
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SwitchPortSample is a snapshot of the counters of a single switch port
+type SwitchPortSample struct {
+	Time      time.Time
+	PortIdx   int
+	Name      string
+	Up        bool
+	Speed     int // Mbps
+	RXBytes   int64
+	TXBytes   int64
+	RXPackets int64
+	TXPackets int64
+	RXErrors  int64
+	TXErrors  int64
+	RXDropped int64
+	TXDropped int64
+}
+
+// SwitchPortRate is the traffic of a single switch port between two samples
+type SwitchPortRate struct {
+	Time        time.Time // the time of the later sample
+	PortIdx     int
+	RXBps       float64 // bits per second
+	TXBps       float64 // bits per second
+	Utilization float64 // percent of the link speed used by the busier direction
+	RXErrors    int64
+	TXErrors    int64
+	RXDropped   int64
+	TXDropped   int64
+}
+
+// SwitchPortSamples returns a snapshot of the port counters of the switch
+// site - the site to query
+// mac - the switch mac
+func (c *Client) SwitchPortSamples(ctx context.Context, site string, mac string) ([]SwitchPortSample, error) {
+	device, err := c.DeviceStats(ctx, site, mac)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	samples := make([]SwitchPortSample, 0, len(device.PortTable))
+	for _, port := range device.PortTable {
+		samples = append(samples, SwitchPortSample{
+			Time:      now,
+			PortIdx:   port.PortIdx,
+			Name:      port.Name,
+			Up:        port.Up,
+			Speed:     port.Speed,
+			RXBytes:   port.RXBytes,
+			TXBytes:   port.TXBytes,
+			RXPackets: port.RXPackets,
+			TXPackets: port.TXPackets,
+			RXErrors:  port.RXErrors,
+			TXErrors:  port.TXErrors,
+			RXDropped: port.RXDropped,
+			TXDropped: port.TXDropped,
+		})
+	}
+	return samples, nil
+}
+
+// SwitchPortHistory keeps the port counter samples of a switch over time.
+// Controllers do not archive per port counters, so the history is built by recording samples periodically,
+// e.g. every minute with RecordSwitchPorts.
+type SwitchPortHistory struct {
+	MAC        string
+	MaxSamples int // the samples kept per port, 0 keeps every sample
+
+	mu      sync.Mutex
+	samples map[int][]SwitchPortSample
+}
+
+// NewSwitchPortHistory returns an empty port history for the switch
+// mac - the switch mac
+// maxSamples - the samples kept per port, 0 keeps every sample
+func NewSwitchPortHistory(mac string, maxSamples int) *SwitchPortHistory {
+	return &SwitchPortHistory{
+		MAC:        mac,
+		MaxSamples: maxSamples,
+		samples:    make(map[int][]SwitchPortSample),
+	}
+}
+
+// Add appends the samples to the history of their port
+func (h *SwitchPortHistory) Add(samples ...SwitchPortSample) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.samples == nil {
+		h.samples = make(map[int][]SwitchPortSample)
+	}
+	for _, s := range samples {
+		port := append(h.samples[s.PortIdx], s)
+		if h.MaxSamples > 0 && len(port) > h.MaxSamples {
+			port = port[len(port)-h.MaxSamples:]
+		}
+		h.samples[s.PortIdx] = port
+	}
+}
+
+// Ports returns the indexes of the ports with samples in ascending order
+func (h *SwitchPortHistory) Ports() []int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ports := make([]int, 0, len(h.samples))
+	for idx := range h.samples {
+		ports = append(ports, idx)
+	}
+	sort.Ints(ports)
+	return ports
+}
+
+// Samples returns the samples of the port in time order
+// portIdx - the port index
+func (h *SwitchPortHistory) Samples(portIdx int) []SwitchPortSample {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	samples := make([]SwitchPortSample, len(h.samples[portIdx]))
+	copy(samples, h.samples[portIdx])
+	return samples
+}
+
+// Rates returns the traffic of the port between consecutive samples.
+// Intervals where the counters went backwards, e.g. after a switch reboot, are skipped.
+// portIdx - the port index
+func (h *SwitchPortHistory) Rates(portIdx int) []SwitchPortRate {
+	samples := h.Samples(portIdx)
+	if len(samples) < 2 {
+		return nil
+	}
+	rates := make([]SwitchPortRate, 0, len(samples)-1)
+	for i := 1; i < len(samples); i++ {
+		prev, cur := samples[i-1], samples[i]
+		seconds := cur.Time.Sub(prev.Time).Seconds()
+		if seconds <= 0 || cur.RXBytes < prev.RXBytes || cur.TXBytes < prev.TXBytes {
+			continue
+		}
+		rate := SwitchPortRate{
+			Time:      cur.Time,
+			PortIdx:   portIdx,
+			RXBps:     float64(cur.RXBytes-prev.RXBytes) * 8 / seconds,
+			TXBps:     float64(cur.TXBytes-prev.TXBytes) * 8 / seconds,
+			RXErrors:  cur.RXErrors - prev.RXErrors,
+			TXErrors:  cur.TXErrors - prev.TXErrors,
+			RXDropped: cur.RXDropped - prev.RXDropped,
+			TXDropped: cur.TXDropped - prev.TXDropped,
+		}
+		if cur.Speed > 0 {
+			busiest := rate.RXBps
+			if rate.TXBps > busiest {
+				busiest = rate.TXBps
+			}
+			rate.Utilization = busiest / (float64(cur.Speed) * 1e6) * 100
+		}
+		rates = append(rates, rate)
+	}
+	return rates
+}
+
+// RecordSwitchPorts adds a snapshot of the port counters of the switch to the history
+// site - the site to query
+// history - the history to record to, the switch is taken from its MAC
+func (c *Client) RecordSwitchPorts(ctx context.Context, site string, history *SwitchPortHistory) error {
+	samples, err := c.SwitchPortSamples(ctx, site, history.MAC)
+	if err != nil {
+		return err
+	}
+	history.Add(samples...)
+	return nil
+}
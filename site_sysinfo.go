@@ -1,7 +1,11 @@
 package unifi
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 // SiteSysInfo defines the site system info
@@ -46,6 +50,7 @@ type SiteSysInfo struct {
 	UnsupportedDeviceCount                   int         `json:"unsupported_device_count"`
 	UpdateAvailable                          bool        `json:"update_available"`
 	UpdateDownloaded                         bool        `json:"update_downloaded"`
+	Uptime                                   int64       `json:"uptime"` // seconds
 	Version                                  string      `json:"version"`
 }
 
@@ -57,8 +62,63 @@ type SiteSysInfoResponse struct {
 
 // SiteSysInfo returns the site system info
 // site - the site to query
-func (c *Client) SiteSysInfo(site string) (*SiteSysInfoResponse, error) {
+func (c *Client) SiteSysInfo(ctx context.Context, site string) (*SiteSysInfoResponse, error) {
 	var resp SiteSysInfoResponse
-	err := c.doSiteRequest(http.MethodGet, site, "stat/sysinfo", nil, &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "stat/sysinfo", nil, &resp)
 	return &resp, err
 }
+
+// UpdatePending returns true if a controller or cloud key update is available
+func (s SiteSysInfo) UpdatePending() bool {
+	return s.UpdateAvailable || s.CloudKeyUpdateAvailable || s.PackageUpdateAvailable
+}
+
+// VersionAtLeast returns true if the controller version is greater or equal to the provided version, e.g. `5.12.0`
+func (s SiteSysInfo) VersionAtLeast(version string) bool {
+	return compareVersions(s.Version, version) >= 0
+}
+
+// SysInfo returns the controller system info. The system info is controller wide and read from the default site, see WithDefaultSite.
+func (c *Client) SysInfo(ctx context.Context) (*SiteSysInfo, error) {
+	resp, err := c.SiteSysInfo(ctx, c.defaultSite)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("controller did not return the system info")
+	}
+	return &resp.Data[0], nil
+}
+
+// compareVersions compares dotted numeric versions, returning -1, 0 or 1.
+// non-numeric suffixes such as `-beta` are ignored.
+func compareVersions(a string, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var av, bv int
+		if i < len(as) {
+			av = versionPart(as[i])
+		}
+		if i < len(bs) {
+			bv = versionPart(bs[i])
+		}
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionPart parses the leading digits of a version part
+func versionPart(s string) int {
+	end := 0
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+	v, _ := strconv.Atoi(s[:end])
+	return v
+}
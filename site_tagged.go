@@ -1,6 +1,7 @@
 package unifi
 
 import (
+	"context"
 	"net/http"
 )
 
@@ -15,8 +16,8 @@ type SiteTaggedMACResponse struct {
 
 // SiteTaggedMACs will query the site for tagged MACs
 // site - the site to query
-func (c *Client) SiteTaggedMACs(site string) (*SiteTaggedMACResponse, error) {
+func (c *Client) SiteTaggedMACs(ctx context.Context, site string) (*SiteTaggedMACResponse, error) {
 	var resp SiteTaggedMACResponse
-	err := c.doSiteRequest(http.MethodGet, site, "rest/tag", nil, &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/tag", nil, &resp)
 	return &resp, err
 }
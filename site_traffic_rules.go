@@ -0,0 +1,248 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// TrafficRuleAction defines the action of a traffic rule
+type TrafficRuleAction string
+
+// The supported traffic rule actions
+const (
+	TrafficRuleActionBlock TrafficRuleAction = "BLOCK"
+	TrafficRuleActionAllow TrafficRuleAction = "ALLOW"
+)
+
+// IsValid returns true if it's a valid traffic rule action.
+// there are only a few valid types
+func (a TrafficRuleAction) IsValid() bool {
+	switch a {
+	case TrafficRuleActionBlock, TrafficRuleActionAllow:
+		return true
+	default:
+		return false
+	}
+}
+
+// TrafficMatchingTarget defines what traffic a traffic rule or route matches
+type TrafficMatchingTarget string
+
+// The supported traffic matching targets
+const (
+	TrafficMatchingTargetInternet    TrafficMatchingTarget = "INTERNET"
+	TrafficMatchingTargetDomain      TrafficMatchingTarget = "DOMAIN"
+	TrafficMatchingTargetIP          TrafficMatchingTarget = "IP"
+	TrafficMatchingTargetRegion      TrafficMatchingTarget = "REGION"
+	TrafficMatchingTargetApp         TrafficMatchingTarget = "APP"
+	TrafficMatchingTargetAppCategory TrafficMatchingTarget = "APP_CATEGORY"
+)
+
+// IsValid returns true if it's a valid traffic matching target.
+// there are only a few valid types
+func (t TrafficMatchingTarget) IsValid() bool {
+	switch t {
+	case TrafficMatchingTargetInternet, TrafficMatchingTargetDomain, TrafficMatchingTargetIP,
+		TrafficMatchingTargetRegion, TrafficMatchingTargetApp, TrafficMatchingTargetAppCategory:
+		return true
+	default:
+		return false
+	}
+}
+
+// TrafficDomain defines a domain matched by a traffic rule or route
+type TrafficDomain struct {
+	Domain     string   `json:"domain"`
+	Ports      []int    `json:"ports"`
+	PortRanges []string `json:"port_ranges"`
+}
+
+// TrafficIPAddress defines an IP address or subnet matched by a traffic rule or route
+type TrafficIPAddress struct {
+	IPOrSubnet string   `json:"ip_or_subnet"`
+	IPVersion  string   `json:"ip_version"` // v4 or v6
+	Ports      []int    `json:"ports"`
+	PortRanges []string `json:"port_ranges"`
+}
+
+// TrafficIPRange defines an IP range matched by a traffic rule or route
+type TrafficIPRange struct {
+	Start     string `json:"ip_start"`
+	Stop      string `json:"ip_stop"`
+	IPVersion string `json:"ip_version"` // v4 or v6
+}
+
+// TrafficTargetDevice defines the clients or networks a traffic rule or route applies to
+type TrafficTargetDevice struct {
+	Type      string `json:"type"` // ALL_CLIENTS, CLIENT or NETWORK
+	ClientMAC string `json:"client_mac,omitempty"`
+	NetworkID string `json:"network_id,omitempty"`
+}
+
+// TrafficSchedule defines when a traffic rule is active
+type TrafficSchedule struct {
+	Mode           string   `json:"mode"` // ALWAYS, EVERY_DAY, EVERY_WEEK, ONE_TIME_ONLY or CUSTOM
+	RepeatOnDays   []string `json:"repeat_on_days"`
+	TimeAllDay     bool     `json:"time_all_day"`
+	TimeRangeStart string   `json:"time_range_start,omitempty"` // e.g. `09:00`
+	TimeRangeEnd   string   `json:"time_range_end,omitempty"`
+	Date           string   `json:"date,omitempty"`
+}
+
+// TrafficBandwidthLimit defines the bandwidth limit applied by a traffic rule
+type TrafficBandwidthLimit struct {
+	Enabled           bool `json:"enabled"`
+	DownloadLimitKbps int  `json:"download_limit_kbps"`
+	UploadLimitKbps   int  `json:"upload_limit_kbps"`
+}
+
+// TrafficRule defines an app, domain, IP or region based traffic rule
+type TrafficRule struct {
+	ID             string                 `json:"_id,omitempty"`
+	Description    string                 `json:"description"`
+	Enabled        bool                   `json:"enabled"`
+	Action         TrafficRuleAction      `json:"action"`
+	MatchingTarget TrafficMatchingTarget  `json:"matching_target"`
+	AppIDs         []int                  `json:"app_ids"`
+	AppCategoryIDs []int                  `json:"app_category_ids"`
+	Domains        []TrafficDomain        `json:"domains"`
+	IPAddresses    []TrafficIPAddress     `json:"ip_addresses"`
+	IPRanges       []TrafficIPRange       `json:"ip_ranges"`
+	Regions        []string               `json:"regions"` // ISO country codes
+	NetworkIDs     []string               `json:"network_ids"`
+	TargetDevices  []TrafficTargetDevice  `json:"target_devices"`
+	Schedule       TrafficSchedule        `json:"schedule"`
+	BandwidthLimit *TrafficBandwidthLimit `json:"bandwidth_limit,omitempty"`
+}
+
+// ListTrafficRules will list the traffic rules, this requires the v2 API of newer controllers
+// site - the site to query
+func (c *Client) ListTrafficRules(ctx context.Context, site string) ([]TrafficRule, error) {
+	var rules []TrafficRule
+	err := c.doSiteV2Request(ctx, http.MethodGet, site, "trafficrules", nil, &rules)
+	return rules, err
+}
+
+// CreateTrafficRule will create a new traffic rule
+// site - the site to modify
+// rule - the traffic rule to create
+func (c *Client) CreateTrafficRule(ctx context.Context, site string, rule *TrafficRule) (*TrafficRule, error) {
+	if err := validateTrafficRule(rule); err != nil {
+		return nil, err
+	}
+	data, _ := json.Marshal(rule)
+
+	var created TrafficRule
+	err := c.doSiteV2Request(ctx, http.MethodPost, site, "trafficrules", bytes.NewReader(data), &created)
+	return &created, err
+}
+
+// UpdateTrafficRule will update an existing traffic rule
+// site - the site to modify
+// rule - the traffic rule to update, the ID must be set
+func (c *Client) UpdateTrafficRule(ctx context.Context, site string, rule *TrafficRule) (*TrafficRule, error) {
+	if rule.ID == "" {
+		return nil, fmt.Errorf("must specify the traffic rule ID")
+	}
+	if err := validateTrafficRule(rule); err != nil {
+		return nil, err
+	}
+	data, _ := json.Marshal(rule)
+
+	extPath := fmt.Sprintf("trafficrules/%s", strings.TrimSpace(rule.ID))
+
+	var updated TrafficRule
+	err := c.doSiteV2Request(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &updated)
+	return &updated, err
+}
+
+// DeleteTrafficRule will delete an existing traffic rule
+// site - the site to modify
+// ruleID - the ID of the traffic rule
+func (c *Client) DeleteTrafficRule(ctx context.Context, site string, ruleID string) error {
+	extPath := fmt.Sprintf("trafficrules/%s", strings.TrimSpace(ruleID))
+	return c.doSiteV2Request(ctx, http.MethodDelete, site, extPath, nil, nil)
+}
+
+// validateTrafficRule checks the rule action and matching target
+func validateTrafficRule(rule *TrafficRule) error {
+	if !rule.Action.IsValid() {
+		return fmt.Errorf("invalid traffic rule action specified: %s", rule.Action)
+	}
+	if !rule.MatchingTarget.IsValid() {
+		return fmt.Errorf("invalid matching target specified: %s", rule.MatchingTarget)
+	}
+	if rule.Schedule.Mode == "" {
+		rule.Schedule.Mode = "ALWAYS"
+	}
+	return nil
+}
+
+// TrafficRoute defines a policy based route sending matching traffic through a specific WAN or VPN
+type TrafficRoute struct {
+	ID                string                `json:"_id,omitempty"`
+	Description       string                `json:"description"`
+	Enabled           bool                  `json:"enabled"`
+	MatchingTarget    TrafficMatchingTarget `json:"matching_target"`
+	Domains           []TrafficDomain       `json:"domains"`
+	IPAddresses       []TrafficIPAddress    `json:"ip_addresses"`
+	IPRanges          []TrafficIPRange      `json:"ip_ranges"`
+	Regions           []string              `json:"regions"`    // ISO country codes
+	NetworkID         string                `json:"network_id"` // the WAN or VPN client network to route through
+	NextHop           string                `json:"next_hop,omitempty"`
+	TargetDevices     []TrafficTargetDevice `json:"target_devices"`
+	KillSwitchEnabled bool                  `json:"kill_switch_enabled"`
+}
+
+// ListTrafficRoutes will list the traffic routes, this requires the v2 API of newer controllers
+// site - the site to query
+func (c *Client) ListTrafficRoutes(ctx context.Context, site string) ([]TrafficRoute, error) {
+	var routes []TrafficRoute
+	err := c.doSiteV2Request(ctx, http.MethodGet, site, "trafficroutes", nil, &routes)
+	return routes, err
+}
+
+// CreateTrafficRoute will create a new traffic route
+// site - the site to modify
+// route - the traffic route to create
+func (c *Client) CreateTrafficRoute(ctx context.Context, site string, route *TrafficRoute) (*TrafficRoute, error) {
+	if !route.MatchingTarget.IsValid() {
+		return nil, fmt.Errorf("invalid matching target specified: %s", route.MatchingTarget)
+	}
+	data, _ := json.Marshal(route)
+
+	var created TrafficRoute
+	err := c.doSiteV2Request(ctx, http.MethodPost, site, "trafficroutes", bytes.NewReader(data), &created)
+	return &created, err
+}
+
+// UpdateTrafficRoute will update an existing traffic route
+// site - the site to modify
+// route - the traffic route to update, the ID must be set
+func (c *Client) UpdateTrafficRoute(ctx context.Context, site string, route *TrafficRoute) (*TrafficRoute, error) {
+	if route.ID == "" {
+		return nil, fmt.Errorf("must specify the traffic route ID")
+	}
+	if !route.MatchingTarget.IsValid() {
+		return nil, fmt.Errorf("invalid matching target specified: %s", route.MatchingTarget)
+	}
+	data, _ := json.Marshal(route)
+
+	extPath := fmt.Sprintf("trafficroutes/%s", strings.TrimSpace(route.ID))
+
+	var updated TrafficRoute
+	err := c.doSiteV2Request(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &updated)
+	return &updated, err
+}
+
+// DeleteTrafficRoute will delete an existing traffic route
+// site - the site to modify
+// routeID - the ID of the traffic route
+func (c *Client) DeleteTrafficRoute(ctx context.Context, site string, routeID string) error {
+	extPath := fmt.Sprintf("trafficroutes/%s", strings.TrimSpace(routeID))
+	return c.doSiteV2Request(ctx, http.MethodDelete, site, extPath, nil, nil)
+}
@@ -2,27 +2,68 @@ package unifi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
+
+	"github.com/pkg/errors"
 )
 
+// UserGroup defines a user group, a bandwidth profile applied to its user/client devices
+type UserGroup struct {
+	ID             string `json:"_id,omitempty"`
+	SiteID         string `json:"site_id,omitempty"`
+	Name           string `json:"name"`
+	QOSRateMaxDown int    `json:"qos_rate_max_down"` // Kbps, -1 for unlimited
+	QOSRateMaxUp   int    `json:"qos_rate_max_up"`   // Kbps, -1 for unlimited
+	AttrNoDelete   bool   `json:"attr_no_delete,omitempty"`
+	AttrHiddenID   string `json:"attr_hidden_id,omitempty"`
+}
+
+// IsDefault returns true if it's the built-in default group
+func (g UserGroup) IsDefault() bool {
+	return g.AttrHiddenID == "Default"
+}
+
+// UserGroupsResponse contains the user groups response
+type UserGroupsResponse struct {
+	Meta CommonMeta  `json:"meta"`
+	Data []UserGroup `json:"data"`
+}
+
 // ListUserGroups will list all user groups
 // site - site to query
-func (c *Client) ListUserGroups(site string) (*GenericResponse, error) {
-	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodGet, site, "list/usergroup", nil, &resp)
+func (c *Client) ListUserGroups(ctx context.Context, site string) (*UserGroupsResponse, error) {
+	var resp UserGroupsResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/usergroup", nil, &resp)
 	return &resp, err
 }
 
+// GetUserGroupByName returns the user group with the name
+// site - site to query
+// name - the name of the user group
+func (c *Client) GetUserGroupByName(ctx context.Context, site string, name string) (*UserGroup, error) {
+	resp, err := c.ListUserGroups(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	for i := range resp.Data {
+		if resp.Data[i].Name == name {
+			return &resp.Data[i], nil
+		}
+	}
+	return nil, ErrUserGroupNotFound
+}
+
 // CreateUserGroup will create a user group
 // site - site to modify
 // siteID - siteID associated with site
 // name - name of the user group
 // downloadBandwidth - limit download bandwidth in Kbps (default -1 == unlimited)
 // uploadBandwidth - limit upload bandwidth in Kbps (default -1 == unlimited)
-func (c *Client) CreateUserGroup(site string, siteID string, name string, downloadBandwidth int, uploadBandwidth int) (*GenericResponse, error) {
+func (c *Client) CreateUserGroup(ctx context.Context, site string, siteID string, name string, downloadBandwidth int, uploadBandwidth int) (*UserGroupsResponse, error) {
 	if downloadBandwidth <= 0 {
 		downloadBandwidth = -1 // unlimited
 	}
@@ -37,8 +78,8 @@ func (c *Client) CreateUserGroup(site string, siteID string, name string, downlo
 	}
 	data, _ := json.Marshal(payload)
 
-	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPost, site, "rest/usergroup", bytes.NewReader(data), &resp)
+	var resp UserGroupsResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "rest/usergroup", bytes.NewReader(data), &resp)
 	return &resp, err
 }
 
@@ -49,7 +90,7 @@ func (c *Client) CreateUserGroup(site string, siteID string, name string, downlo
 // name - name of the user group
 // downloadBandwidth - limit download bandwidth in Kbps (default -1 == unlimited)
 // uploadBandwidth - limit upload bandwidth in Kbps (default -1 == unlimited)
-func (c *Client) UpdateUserGroup(site string, siteID string, groupID string, name string, downloadBandwidth int, uploadBandwidth int) (*GenericResponse, error) {
+func (c *Client) UpdateUserGroup(ctx context.Context, site string, siteID string, groupID string, name string, downloadBandwidth int, uploadBandwidth int) (*UserGroupsResponse, error) {
 	if downloadBandwidth <= 0 {
 		downloadBandwidth = -1 // unlimited
 	}
@@ -68,19 +109,19 @@ func (c *Client) UpdateUserGroup(site string, siteID string, groupID string, nam
 
 	extPath := fmt.Sprintf("rest/usergroup/%s", strings.TrimSpace(groupID))
 
-	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
+	var resp UserGroupsResponse
+	err := c.doSiteRequest(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
 	return &resp, err
 }
 
 // DeleteUserGroup will delete an existing user group
 // site - site to modify
 // groupID - groupID to modify
-func (c *Client) DeleteUserGroup(site string, groupID string) (*GenericResponse, error) {
+func (c *Client) DeleteUserGroup(ctx context.Context, site string, groupID string) (*GenericResponse, error) {
 	extPath := fmt.Sprintf("rest/usergroup/%s", strings.TrimSpace(groupID))
 
 	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodDelete, site, extPath, nil, &resp)
+	err := c.doSiteRequest(ctx, http.MethodDelete, site, extPath, nil, &resp)
 	return &resp, err
 }
 
@@ -88,15 +129,22 @@ func (c *Client) DeleteUserGroup(site string, groupID string) (*GenericResponse,
 // site - the site to modify
 // clientID - the ID of the user/client device to be modified
 // groupID - the ID of the group to assign the user/client device to.
-func (c *Client) AssignClientUserGroup(site string, clientID string, groupID string) (*GenericResponse, error) {
-	payload := map[string]interface{}{
-		"usergroup_id": groupID,
-	}
-	data, _ := json.Marshal(payload)
-
-	extPath := fmt.Sprintf("upd/user/%s", strings.TrimSpace(strings.ToLower(clientID)))
+//
+// Deprecated: use SetClientUserGroup.
+func (c *Client) AssignClientUserGroup(ctx context.Context, site string, clientID string, groupID string) (*GenericResponse, error) {
+	return c.SetClientUserGroup(ctx, site, clientID, groupID)
+}
 
-	var resp GenericResponse
-	err := c.doSiteRequest(http.MethodGet, site, extPath, bytes.NewReader(data), &resp)
-	return &resp, err
+// AssignClientsToUserGroup will assign multiple user/client devices to a user group
+// site - the site to modify
+// groupID - the ID of the user group
+// clientIDs - the IDs of the user/client devices to be modified
+func (c *Client) AssignClientsToUserGroup(ctx context.Context, site string, groupID string, clientIDs ...string) error {
+	for _, clientID := range clientIDs {
+		_, err := c.SetClientUserGroup(ctx, site, clientID, groupID)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("unable to assign client %s to user group %s", clientID, groupID))
+		}
+	}
+	return nil
 }
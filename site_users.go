@@ -0,0 +1,149 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// UserType defines the kind of user/client devices to list
+type UserType string
+
+// The supported user types
+const (
+	UserTypeAll   UserType = "all"
+	UserTypeUser  UserType = "user"  // non-guest devices only
+	UserTypeGuest UserType = "guest" // guest devices only
+)
+
+// IsValid returns true if it's a valid user type.
+// there are only a few valid types
+func (t UserType) IsValid() bool {
+	switch t {
+	case UserTypeAll, UserTypeUser, UserTypeGuest:
+		return true
+	default:
+		return false
+	}
+}
+
+// User defines a known user/client device, online or offline
+type User struct {
+	ID          string `json:"_id"`
+	SiteID      string `json:"site_id"`
+	MAC         string `json:"mac"`
+	HostName    string `json:"hostname"`
+	Name        string `json:"name"`
+	OUI         string `json:"oui"`
+	Note        string `json:"note"`
+	Noted       bool   `json:"noted"`
+	IsGuest     bool   `json:"is_guest"`
+	IsWired     bool   `json:"is_wired"`
+	Blocked     bool   `json:"blocked"`
+	FirstSeen   int64  `json:"first_seen"`
+	LastSeen    int64  `json:"last_seen"`
+	Duration    int64  `json:"duration"` // seconds connected within the queried history
+	RXBytes     int64  `json:"rx_bytes"`
+	TXBytes     int64  `json:"tx_bytes"`
+	UserGroupID string `json:"usergroup_id"`
+	UseFixedIP  bool   `json:"use_fixedip"`
+	NetworkID   string `json:"network_id"`
+	FixedIP     string `json:"fixed_ip"`
+
+	ClientFingerprint
+}
+
+// FirstSeenTime returns the first time the device was seen
+func (u User) FirstSeenTime() time.Time {
+	return time.Unix(u.FirstSeen, 0).UTC()
+}
+
+// LastSeenTime returns the last time the device was seen
+func (u User) LastSeenTime() time.Time {
+	return time.Unix(u.LastSeen, 0).UTC()
+}
+
+// DisplayName returns the configured name, falling back to the hostname and the mac
+func (u User) DisplayName() string {
+	if u.Name != "" {
+		return u.Name
+	}
+	if u.HostName != "" {
+		return u.HostName
+	}
+	return u.MAC
+}
+
+// UsersResponse contains the users response
+type UsersResponse struct {
+	Meta CommonMeta `json:"meta"`
+	Data []User     `json:"data"`
+}
+
+// UserFilter defines the filters applied when listing users
+type UserFilter struct {
+	WithinHours int      // only list devices seen in the last hours, defaults to 8760 (one year)
+	Type        UserType // defaults to all
+	Start       int      // pagination offset
+	Limit       int      // pagination limit, 0 for no limit
+}
+
+// ListUsers lists the known user/client devices, including offline ones
+// site - the site to query
+// filter - the user filters
+func (c *Client) ListUsers(ctx context.Context, site string, filter UserFilter) (*UsersResponse, error) {
+	if filter.WithinHours <= 0 {
+		filter.WithinHours = 8760
+	}
+	if filter.Type == "" {
+		filter.Type = UserTypeAll
+	}
+	if !filter.Type.IsValid() {
+		return nil, fmt.Errorf("invalid user type specified: %s", filter.Type)
+	}
+
+	payload := map[string]interface{}{
+		"type":   "all",
+		"conn":   "all",
+		"within": filter.WithinHours,
+	}
+	data, _ := json.Marshal(payload)
+
+	var resp UsersResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "stat/alluser", bytes.NewReader(data), &resp)
+	if err != nil {
+		return &resp, err
+	}
+
+	// the controller does not page or filter by guest status, apply it here
+	users := make([]User, 0, len(resp.Data))
+	for _, u := range resp.Data {
+		if (filter.Type == UserTypeUser && u.IsGuest) || (filter.Type == UserTypeGuest && !u.IsGuest) {
+			continue
+		}
+		users = append(users, u)
+	}
+	if filter.Start > 0 {
+		if filter.Start >= len(users) {
+			users = users[:0]
+		} else {
+			users = users[filter.Start:]
+		}
+	}
+	if filter.Limit > 0 && filter.Limit < len(users) {
+		users = users[:filter.Limit]
+	}
+	resp.Data = users
+	return &resp, nil
+}
+
+// ListConfiguredUsers lists the user/client devices with a configuration, e.g. a name, note, fixed IP or user group
+// site - the site to query
+func (c *Client) ListConfiguredUsers(ctx context.Context, site string) (*UsersResponse, error) {
+	var resp UsersResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/user", nil, &resp)
+	return &resp, err
+}
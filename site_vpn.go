@@ -0,0 +1,282 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// VPNType defines the kind of VPN of a site-vpn or remote-user-vpn network
+type VPNType string
+
+// The supported VPN types
+const (
+	VPNTypeIPsec           VPNType = "ipsec-vpn"
+	VPNTypeOpenVPN         VPNType = "openvpn-vpn"
+	VPNTypeL2TPServer      VPNType = "l2tp-server"
+	VPNTypeWireGuardServer VPNType = "wireguard-server"
+)
+
+// IsValid returns true if it's a valid VPN type.
+// there are only a few valid types
+func (t VPNType) IsValid() bool {
+	switch t {
+	case VPNTypeIPsec, VPNTypeOpenVPN, VPNTypeL2TPServer, VPNTypeWireGuardServer:
+		return true
+	default:
+		return false
+	}
+}
+
+// SiteVPN defines a site-to-site VPN, a rest/networkconf entry with the site-vpn purpose
+type SiteVPN struct {
+	ID                string         `json:"_id,omitempty"`
+	SiteID            string         `json:"site_id,omitempty"`
+	Name              string         `json:"name"`
+	Purpose           NetworkPurpose `json:"purpose"`
+	Enabled           bool           `json:"enabled"`
+	VPNType           VPNType        `json:"vpn_type"`
+	RemoteVPNSubnets  []string       `json:"remote_vpn_subnets"` // the subnets behind the peer
+	RemoteSiteID      string         `json:"remote_site_id,omitempty"`
+	RouteDistance     int            `json:"route_distance,omitempty"`
+	IPsecPeerIP       string         `json:"ipsec_peer_ip,omitempty"`
+	IPsecLocalIP      string         `json:"ipsec_local_ip,omitempty"`
+	IPsecInterface    string         `json:"ipsec_interface,omitempty"` // wan or wan2
+	IPsecPreSharedKey string         `json:"x_ipsec_pre_shared_key,omitempty"`
+	IPsecProfile      string         `json:"ipsec_profile,omitempty"`      // customized, azure_dynamic, ...
+	IPsecKeyExchange  string         `json:"ipsec_key_exchange,omitempty"` // ikev1 or ikev2
+	IPsecEncryption   string         `json:"ipsec_encryption,omitempty"`   // aes128, aes192, aes256, 3des
+	IPsecHash         string         `json:"ipsec_hash,omitempty"`         // sha1, md5, sha256, ...
+	IPsecIKEDHGroup   interface{}    `json:"ipsec_ike_dh_group,omitempty"` // sometimes string or int
+	IPsecESPDHGroup   interface{}    `json:"ipsec_esp_dh_group,omitempty"` // sometimes string or int
+	IPsecPFS          bool           `json:"ipsec_pfs"`
+	IPsecDynamicRoute bool           `json:"ipsec_dynamic_routing"`
+}
+
+// SiteVPNsResponse contains the site-to-site VPN response
+type SiteVPNsResponse struct {
+	Meta CommonMeta `json:"meta"`
+	Data []SiteVPN  `json:"data"`
+}
+
+// ListSiteVPNs will list the site-to-site VPNs
+// site - the site to query
+func (c *Client) ListSiteVPNs(ctx context.Context, site string) (*SiteVPNsResponse, error) {
+	var resp SiteVPNsResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/networkconf", nil, &resp)
+	if err != nil {
+		return &resp, err
+	}
+
+	vpns := make([]SiteVPN, 0, len(resp.Data))
+	for _, n := range resp.Data {
+		if n.Purpose == NetworkPurposeSiteVPN {
+			vpns = append(vpns, n)
+		}
+	}
+	resp.Data = vpns
+	return &resp, nil
+}
+
+// CreateSiteVPN will create a new site-to-site VPN
+// site - the site to modify
+// vpn - the site-to-site VPN to create
+func (c *Client) CreateSiteVPN(ctx context.Context, site string, vpn *SiteVPN) (*SiteVPNsResponse, error) {
+	if vpn.VPNType == "" {
+		vpn.VPNType = VPNTypeIPsec
+	}
+	if !vpn.VPNType.IsValid() {
+		return nil, fmt.Errorf("invalid vpn type specified: %s", vpn.VPNType)
+	}
+	if vpn.RemoteVPNSubnets == nil {
+		vpn.RemoteVPNSubnets = make([]string, 0)
+	}
+	vpn.Purpose = NetworkPurposeSiteVPN
+	data, _ := json.Marshal(vpn)
+
+	var resp SiteVPNsResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "rest/networkconf", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// UpdateSiteVPN will update an existing site-to-site VPN
+// site - the site to modify
+// vpn - the site-to-site VPN to update, the ID must be set
+func (c *Client) UpdateSiteVPN(ctx context.Context, site string, vpn *SiteVPN) (*SiteVPNsResponse, error) {
+	if vpn.ID == "" {
+		return nil, fmt.Errorf("must specify the network ID")
+	}
+	if !vpn.VPNType.IsValid() {
+		return nil, fmt.Errorf("invalid vpn type specified: %s", vpn.VPNType)
+	}
+	if vpn.RemoteVPNSubnets == nil {
+		vpn.RemoteVPNSubnets = make([]string, 0)
+	}
+	vpn.Purpose = NetworkPurposeSiteVPN
+	data, _ := json.Marshal(vpn)
+
+	extPath := fmt.Sprintf("rest/networkconf/%s", strings.TrimSpace(vpn.ID))
+
+	var resp SiteVPNsResponse
+	err := c.doSiteRequest(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// DeleteSiteVPN will delete an existing site-to-site VPN
+// site - the site to modify
+// vpnID - the ID of the site-to-site VPN network
+func (c *Client) DeleteSiteVPN(ctx context.Context, site string, vpnID string) (*GenericResponse, error) {
+	return c.DeleteNetwork(ctx, site, vpnID)
+}
+
+// VPNServer defines a remote user VPN server, a rest/networkconf entry with the remote-user-vpn purpose
+type VPNServer struct {
+	ID         string         `json:"_id,omitempty"`
+	SiteID     string         `json:"site_id,omitempty"`
+	Name       string         `json:"name"`
+	Purpose    NetworkPurpose `json:"purpose"`
+	Enabled    bool           `json:"enabled"`
+	VPNType    VPNType        `json:"vpn_type"`
+	IPSubnet   string         `json:"ip_subnet"` // the server address and client pool, e.g. `192.168.2.1/24`
+	DHCPDDNS1  string         `json:"dhcpd_dns_1,omitempty"`
+	DHCPDDNS2  string         `json:"dhcpd_dns_2,omitempty"`
+	ExposedTo  string         `json:"exposed_to_site_vpn,omitempty"`
+	RADIUSID   string         `json:"radiusprofile_id,omitempty"`
+	DomainName string         `json:"domain_name,omitempty"`
+
+	// l2tp
+	L2TPInterface        string `json:"l2tp_interface,omitempty"` // wan or wan2
+	L2TPLocalWANIP       string `json:"l2tp_local_wan_ip,omitempty"`
+	L2TPAllowWeakCiphers bool   `json:"l2tp_allow_weak_ciphers"`
+	IPsecPreSharedKey    string `json:"x_ipsec_pre_shared_key,omitempty"`
+	RequireMSCHAPv2      bool   `json:"require_mschapv2"`
+	MTU                  int    `json:"mtu,omitempty"`
+	MSSClamp             string `json:"mss_clamp,omitempty"`
+	MSSClampMSS          int    `json:"mss_clamp_mss,omitempty"`
+
+	// wireguard
+	WireGuardInterface  string `json:"wireguard_interface,omitempty"` // wan or wan2
+	WireGuardLocalWANIP string `json:"wireguard_local_wan_ip,omitempty"`
+	WireGuardPrivateKey string `json:"x_wireguard_private_key,omitempty"`
+	WireGuardPublicKey  string `json:"wireguard_public_key,omitempty"`
+	WireGuardLocalPort  int    `json:"local_port,omitempty"` // wireguard listen port, defaults to 51820
+}
+
+// VPNServersResponse contains the VPN server response
+type VPNServersResponse struct {
+	Meta CommonMeta  `json:"meta"`
+	Data []VPNServer `json:"data"`
+}
+
+// ListVPNServers will list the remote user VPN servers
+// site - the site to query
+func (c *Client) ListVPNServers(ctx context.Context, site string) (*VPNServersResponse, error) {
+	var resp VPNServersResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/networkconf", nil, &resp)
+	if err != nil {
+		return &resp, err
+	}
+
+	servers := make([]VPNServer, 0, len(resp.Data))
+	for _, n := range resp.Data {
+		if n.Purpose == NetworkPurposeRemoteUserVPN {
+			servers = append(servers, n)
+		}
+	}
+	resp.Data = servers
+	return &resp, nil
+}
+
+// validateVPNServer checks the VPN type and fills the defaults
+func validateVPNServer(server *VPNServer) error {
+	if server.VPNType != VPNTypeL2TPServer && server.VPNType != VPNTypeWireGuardServer {
+		return fmt.Errorf("invalid vpn server type specified: %s", server.VPNType)
+	}
+	if server.VPNType == VPNTypeWireGuardServer && server.WireGuardLocalPort == 0 {
+		server.WireGuardLocalPort = 51820
+	}
+	server.Purpose = NetworkPurposeRemoteUserVPN
+	return nil
+}
+
+// CreateVPNServer will create a new remote user VPN server
+// site - the site to modify
+// server - the VPN server to create, L2TP or WireGuard
+func (c *Client) CreateVPNServer(ctx context.Context, site string, server *VPNServer) (*VPNServersResponse, error) {
+	if err := validateVPNServer(server); err != nil {
+		return nil, err
+	}
+	data, _ := json.Marshal(server)
+
+	var resp VPNServersResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "rest/networkconf", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// UpdateVPNServer will update an existing remote user VPN server
+// site - the site to modify
+// server - the VPN server to update, the ID must be set
+func (c *Client) UpdateVPNServer(ctx context.Context, site string, server *VPNServer) (*VPNServersResponse, error) {
+	if server.ID == "" {
+		return nil, fmt.Errorf("must specify the network ID")
+	}
+	if err := validateVPNServer(server); err != nil {
+		return nil, err
+	}
+	data, _ := json.Marshal(server)
+
+	extPath := fmt.Sprintf("rest/networkconf/%s", strings.TrimSpace(server.ID))
+
+	var resp VPNServersResponse
+	err := c.doSiteRequest(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// DeleteVPNServer will delete an existing remote user VPN server
+// site - the site to modify
+// serverID - the ID of the VPN server network
+func (c *Client) DeleteVPNServer(ctx context.Context, site string, serverID string) (*GenericResponse, error) {
+	return c.DeleteNetwork(ctx, site, serverID)
+}
+
+// ListVPNUsers will list the L2TP VPN users, the RADIUS accounts with the L2TP tunnel type
+// site - the site to query
+func (c *Client) ListVPNUsers(ctx context.Context, site string) ([]RADIUSAccount, error) {
+	resp, err := c.ListRADIUSAccounts(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]RADIUSAccount, 0, len(resp.Data))
+	for _, a := range resp.Data {
+		if a.TunnelType == RADIUSTunnelTypeL2TP {
+			users = append(users, a)
+		}
+	}
+	return users, nil
+}
+
+// CreateVPNUser will create a L2TP VPN user
+// site - the site to modify
+// name - the user name
+// password - the user password
+func (c *Client) CreateVPNUser(ctx context.Context, site string, name string, password string) (*RADIUSAccountsResponse, error) {
+	if password == "" {
+		return nil, fmt.Errorf("must specify the VPN user password")
+	}
+	return c.CreateRADIUSAccount(ctx, site, &RADIUSAccount{
+		Name:             name,
+		Password:         password,
+		TunnelType:       RADIUSTunnelTypeL2TP,
+		TunnelMediumType: RADIUSTunnelMediumTypeIPv4,
+	})
+}
+
+// DeleteVPNUser will delete a L2TP VPN user
+// site - the site to modify
+// userID - the ID of the VPN user RADIUS account
+func (c *Client) DeleteVPNUser(ctx context.Context, site string, userID string) (*GenericResponse, error) {
+	return c.DeleteRADIUSAccount(ctx, site, userID)
+}
@@ -0,0 +1,237 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// WANType defines how a WAN interface obtains its address
+type WANType string
+
+// The supported WAN types
+const (
+	WANTypeDisabled WANType = "disabled"
+	WANTypeDHCP     WANType = "dhcp"
+	WANTypeStatic   WANType = "static"
+	WANTypePPPoE    WANType = "pppoe"
+)
+
+// IsValid returns true if it's a valid WAN type.
+// there are only a few valid types
+func (t WANType) IsValid() bool {
+	switch t {
+	case WANTypeDisabled, WANTypeDHCP, WANTypeStatic, WANTypePPPoE:
+		return true
+	default:
+		return false
+	}
+}
+
+// WANLoadBalanceType defines how traffic is distributed over multiple WAN interfaces
+type WANLoadBalanceType string
+
+// The supported WAN load balancing types
+const (
+	WANLoadBalanceFailoverOnly WANLoadBalanceType = "failover-only"
+	WANLoadBalanceWeighted     WANLoadBalanceType = "weighted"
+)
+
+// IsValid returns true if it's a valid WAN load balancing type.
+// there are only a few valid types
+func (t WANLoadBalanceType) IsValid() bool {
+	switch t {
+	case WANLoadBalanceFailoverOnly, WANLoadBalanceWeighted:
+		return true
+	default:
+		return false
+	}
+}
+
+// The WAN network groups
+const (
+	WANNetworkGroupWAN  = "WAN"
+	WANNetworkGroupWAN2 = "WAN2"
+)
+
+// WANProviderCapabilities defines the bandwidth provided by the ISP
+type WANProviderCapabilities struct {
+	DownloadKbps int `json:"download_kilobits_per_second"`
+	UploadKbps   int `json:"upload_kilobits_per_second"`
+}
+
+// WANNetwork defines a WAN network configuration, a rest/networkconf entry with the wan purpose
+type WANNetwork struct {
+	ID           string         `json:"_id,omitempty"`
+	SiteID       string         `json:"site_id,omitempty"`
+	Name         string         `json:"name"`
+	Purpose      NetworkPurpose `json:"purpose"`
+	Enabled      bool           `json:"enabled"`
+	NetworkGroup string         `json:"wan_networkgroup"` // WAN or WAN2
+	Type         WANType        `json:"wan_type"`
+
+	// static
+	IP      string `json:"wan_ip,omitempty"`
+	Netmask string `json:"wan_netmask,omitempty"`
+	Gateway string `json:"wan_gateway,omitempty"`
+	DNS1    string `json:"wan_dns1,omitempty"`
+	DNS2    string `json:"wan_dns2,omitempty"`
+
+	// pppoe
+	Username string `json:"wan_username,omitempty"`
+	Password string `json:"x_wan_password,omitempty"`
+
+	// vlan tagging
+	VLANEnabled bool        `json:"wan_vlan_enabled"`
+	VLAN        interface{} `json:"wan_vlan,omitempty"` // sometimes string or int
+	EgressQOS   interface{} `json:"wan_egress_qos,omitempty"`
+
+	// smart queues
+	SmartQEnabled  bool `json:"wan_smartq_enabled"`
+	SmartQUpRate   int  `json:"wan_smartq_up_rate,omitempty"`   // Kbps
+	SmartQDownRate int  `json:"wan_smartq_down_rate,omitempty"` // Kbps
+
+	// multi-wan
+	LoadBalanceType    WANLoadBalanceType `json:"wan_load_balance_type,omitempty"`
+	LoadBalanceWeight  int                `json:"wan_load_balance_weight,omitempty"` // 1-99, weighted only
+	FailoverPriority   int                `json:"wan_failover_priority,omitempty"`   // lower is preferred
+	ReportWANEvent     bool               `json:"report_wan_event"`
+	MACOverrideEnabled bool               `json:"mac_override_enabled"`
+	MACOverride        string             `json:"mac_override,omitempty"`
+
+	// ipv6
+	TypeV6         string `json:"wan_type_v6,omitempty"` // disabled, dhcpv6 or static
+	DHCPv6PDSize   int    `json:"wan_dhcpv6_pd_size,omitempty"`
+	IPv6           string `json:"wan_ipv6,omitempty"`
+	GatewayV6      string `json:"wan_gateway_v6,omitempty"`
+	PrefixLengthV6 int    `json:"wan_prefixlen,omitempty"`
+
+	ProviderCapabilities *WANProviderCapabilities `json:"wan_provider_capabilities,omitempty"`
+}
+
+// WANNetworksResponse contains the WAN network configuration response
+type WANNetworksResponse struct {
+	Meta CommonMeta   `json:"meta"`
+	Data []WANNetwork `json:"data"`
+}
+
+// ListWANs will list the WAN network configurations
+// site - the site to query
+func (c *Client) ListWANs(ctx context.Context, site string) (*WANNetworksResponse, error) {
+	var resp WANNetworksResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/networkconf", nil, &resp)
+	if err != nil {
+		return &resp, err
+	}
+
+	wans := make([]WANNetwork, 0, len(resp.Data))
+	for _, n := range resp.Data {
+		if n.Purpose == NetworkPurposeWAN {
+			wans = append(wans, n)
+		}
+	}
+	resp.Data = wans
+	return &resp, nil
+}
+
+// GetWAN returns the WAN network configuration for the network group
+// site - the site to query
+// networkGroup - the WAN network group, e.g. WANNetworkGroupWAN2
+func (c *Client) GetWAN(ctx context.Context, site string, networkGroup string) (*WANNetwork, error) {
+	resp, err := c.ListWANs(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	for i := range resp.Data {
+		if strings.EqualFold(resp.Data[i].NetworkGroup, networkGroup) {
+			return &resp.Data[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no wan network for %s", networkGroup)
+}
+
+// UpdateWAN will update an existing WAN network configuration
+// site - the site to modify
+// wan - the WAN network configuration to update, the ID must be set
+func (c *Client) UpdateWAN(ctx context.Context, site string, wan *WANNetwork) (*WANNetworksResponse, error) {
+	if wan.ID == "" {
+		return nil, fmt.Errorf("must specify the network ID")
+	}
+	if !wan.Type.IsValid() {
+		return nil, fmt.Errorf("invalid wan type specified: %s", wan.Type)
+	}
+	if wan.LoadBalanceType != "" && !wan.LoadBalanceType.IsValid() {
+		return nil, fmt.Errorf("invalid load balance type specified: %s", wan.LoadBalanceType)
+	}
+	wan.Purpose = NetworkPurposeWAN
+	data, _ := json.Marshal(wan)
+
+	extPath := fmt.Sprintf("rest/networkconf/%s", strings.TrimSpace(wan.ID))
+
+	var resp WANNetworksResponse
+	err := c.doSiteRequest(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// SetWANFailover will configure failover between the WAN networks, the first network group is preferred
+// site - the site to modify
+// networkGroups - the WAN network groups in order of preference, e.g. WANNetworkGroupWAN, WANNetworkGroupWAN2
+func (c *Client) SetWANFailover(ctx context.Context, site string, networkGroups ...string) error {
+	priorities := make(map[string]int, len(networkGroups))
+	for i, group := range networkGroups {
+		priorities[strings.ToUpper(group)] = i + 1
+	}
+	return c.updateWANLoadBalancing(ctx, site, func(wan *WANNetwork) bool {
+		priority, ok := priorities[strings.ToUpper(wan.NetworkGroup)]
+		if !ok {
+			return false
+		}
+		wan.LoadBalanceType = WANLoadBalanceFailoverOnly
+		wan.FailoverPriority = priority
+		return true
+	})
+}
+
+// SetWANLoadBalancing will distribute traffic over the WAN networks by weight
+// site - the site to modify
+// weights - the weight (1-99) per WAN network group, e.g. {"WAN": 80, "WAN2": 20}
+func (c *Client) SetWANLoadBalancing(ctx context.Context, site string, weights map[string]int) error {
+	normalized := make(map[string]int, len(weights))
+	for group, weight := range weights {
+		if weight < 1 || weight > 99 {
+			return fmt.Errorf("invalid load balance weight specified for %s: %d", group, weight)
+		}
+		normalized[strings.ToUpper(group)] = weight
+	}
+	return c.updateWANLoadBalancing(ctx, site, func(wan *WANNetwork) bool {
+		weight, ok := normalized[strings.ToUpper(wan.NetworkGroup)]
+		if !ok {
+			return false
+		}
+		wan.LoadBalanceType = WANLoadBalanceWeighted
+		wan.LoadBalanceWeight = weight
+		return true
+	})
+}
+
+// updateWANLoadBalancing applies the modification to every WAN network and saves the changed ones
+func (c *Client) updateWANLoadBalancing(ctx context.Context, site string, modify func(wan *WANNetwork) bool) error {
+	resp, err := c.ListWANs(ctx, site)
+	if err != nil {
+		return err
+	}
+	for i := range resp.Data {
+		wan := &resp.Data[i]
+		if !modify(wan) {
+			continue
+		}
+		_, err = c.UpdateWAN(ctx, site, wan)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
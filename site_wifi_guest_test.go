@@ -0,0 +1,45 @@
+package unifi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAuthorizeWiFiGuestRejectsInvalidMACs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer srv.Close()
+	c, err := NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetUniFiOS(false)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		mac    string
+		config *WifiGuestConfig
+	}{
+		{"empty", "", nil},
+		{"malformed", "aa:bb:cc:dd:ee", nil},
+		{"not hex", "zz:bb:cc:dd:ee:ff", nil},
+		{"malformed access point", "aa:bb:cc:dd:ee:ff", &WifiGuestConfig{AccessPointMac: "ap"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.AuthorizeWiFiGuest(ctx, "default", tt.mac, time.Hour, tt.config); err == nil {
+				t.Error("AuthorizeWiFiGuest() expected an error")
+			}
+			if tt.config == nil {
+				if _, err := c.UnAuthorizeWiFiGuest(ctx, "default", tt.mac); err == nil {
+					t.Error("UnAuthorizeWiFiGuest() expected an error")
+				}
+			}
+		})
+	}
+}
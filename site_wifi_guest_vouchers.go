@@ -0,0 +1,137 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Voucher defines a hotspot guest voucher
+type Voucher struct {
+	ID             string `json:"_id"`
+	SiteID         string `json:"site_id"`
+	AdminName      string `json:"admin_name"`
+	Code           string `json:"code"`
+	CreateTime     int64  `json:"create_time"` // epoch seconds
+	Duration       int    `json:"duration"`    // minutes the voucher is valid after activation
+	ForHotspot     bool   `json:"for_hotspot"`
+	Note           string `json:"note"`
+	Quota          int    `json:"quota"` // `0` for multi-use, `1` for single-use, <N> for multi-use <N> times
+	Used           int    `json:"used"`
+	Status         string `json:"status"`
+	StatusExpires  int64  `json:"status_expires"`
+	StartTime      int64  `json:"start_time"`
+	EndTime        int64  `json:"end_time"`
+	QOSOverwrite   bool   `json:"qos_overwrite"`
+	QOSRateMaxUp   int    `json:"qos_rate_max_up"`   // kbps
+	QOSRateMaxDown int    `json:"qos_rate_max_down"` // kbps
+	QOSUsageQuota  int    `json:"qos_usage_quota"`   // MB
+}
+
+// FormattedCode returns the voucher code the way it is printed by the controller, e.g. `12345-67890`
+func (v Voucher) FormattedCode() string {
+	if len(v.Code) != 10 {
+		return v.Code
+	}
+	return v.Code[:5] + "-" + v.Code[5:]
+}
+
+// Created returns the voucher creation time
+func (v Voucher) Created() time.Time {
+	return time.Unix(v.CreateTime, 0).UTC()
+}
+
+// VouchersResponse contains the typed stat/voucher response
+type VouchersResponse struct {
+	Meta CommonMeta `json:"meta"`
+	Data []Voucher  `json:"data"`
+}
+
+// ListVouchers will list the typed hotspot vouchers
+// site - the site to query
+// createTime - the create time of the vouchers, if zero-value, then it will return all
+func (c *Client) ListVouchers(ctx context.Context, site string, createTime time.Time) (*VouchersResponse, error) {
+	payload := map[string]interface{}{}
+	if !createTime.IsZero() {
+		payload["create_time"] = createTime.UTC().Unix()
+	}
+
+	data, _ := json.Marshal(payload)
+
+	var resp VouchersResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "stat/voucher", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// voucherCreateResponse contains the cmd/hotspot create-voucher response
+type voucherCreateResponse struct {
+	Meta CommonMeta `json:"meta"`
+	Data []struct {
+		CreateTime int64 `json:"create_time"`
+	} `json:"data"`
+}
+
+// CreateVouchers will generate a batch of vouchers and return them, ready to be printed
+// site - the site to create the vouchers
+// cfg - voucher creation config, use Count for bulk generation
+func (c *Client) CreateVouchers(ctx context.Context, site string, cfg VoucherConfig) ([]Voucher, error) {
+	count := uint(1)
+	if cfg.Count != nil {
+		count = *cfg.Count
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("must create at least one voucher")
+	}
+
+	payload := map[string]interface{}{
+		"cmd":    "create-voucher",
+		"expire": cfg.MinutesValid,
+		"n":      count,
+		"quota":  cfg.Quota,
+	}
+	if cfg.Note != nil {
+		payload["note"] = *cfg.Note
+	}
+	if cfg.UploadSpeedLimit != nil {
+		payload["up"] = *cfg.UploadSpeedLimit
+	}
+	if cfg.DownloadSpeedLimit != nil {
+		payload["down"] = *cfg.DownloadSpeedLimit
+	}
+	if cfg.DataTransferLimit != nil {
+		payload["bytes"] = *cfg.DataTransferLimit
+	}
+
+	data, _ := json.Marshal(payload)
+
+	var created voucherCreateResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "cmd/hotspot", bytes.NewReader(data), &created)
+	if err != nil {
+		return nil, err
+	}
+	if len(created.Data) == 0 {
+		return nil, fmt.Errorf("controller did not return the voucher create time")
+	}
+
+	vouchers, err := c.ListVouchers(ctx, site, time.Unix(created.Data[0].CreateTime, 0))
+	if err != nil {
+		return nil, err
+	}
+	return vouchers.Data, nil
+}
+
+// RevokeVouchers will revoke many vouchers
+// site - the site to modify
+// voucherIDs - the voucher _ids to revoke
+func (c *Client) RevokeVouchers(ctx context.Context, site string, voucherIDs ...string) error {
+	for _, id := range voucherIDs {
+		_, err := c.RevokeWifiGuestVoucher(ctx, site, id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
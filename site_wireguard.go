@@ -0,0 +1,188 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// WireGuardKeyPair is a WireGuard private and public key, base64 encoded
+type WireGuardKeyPair struct {
+	PrivateKey string
+	PublicKey  string
+}
+
+// GenerateWireGuardKeyPair generates a new WireGuard key pair
+func GenerateWireGuardKeyPair() (*WireGuardKeyPair, error) {
+	var private [32]byte
+	if _, err := rand.Read(private[:]); err != nil {
+		return nil, err
+	}
+	// clamp the private key as done by `wg genkey`
+	private[0] &= 248
+	private[31] = (private[31] & 127) | 64
+
+	key, err := ecdh.X25519().NewPrivateKey(private[:])
+	if err != nil {
+		return nil, err
+	}
+	return &WireGuardKeyPair{
+		PrivateKey: base64.StdEncoding.EncodeToString(private[:]),
+		PublicKey:  base64.StdEncoding.EncodeToString(key.PublicKey().Bytes()),
+	}, nil
+}
+
+// WireGuardPeer defines a client (peer) of a WireGuard VPN server
+type WireGuardPeer struct {
+	ID           string   `json:"_id,omitempty"`
+	SiteID       string   `json:"site_id,omitempty"`
+	NetworkID    string   `json:"network_id,omitempty"` // the WireGuard VPN server network
+	Name         string   `json:"name"`
+	InterfaceIP  string   `json:"interface_ip"` // the peer address within the server subnet
+	PublicKey    string   `json:"public_key"`
+	PresharedKey string   `json:"preshared_key,omitempty"`
+	AllowedIPs   []string `json:"allowed_ips"` // additional subnets routed to the peer
+
+	// PrivateKey is only known when the key pair was generated locally, it is never sent to the controller
+	PrivateKey string `json:"-"`
+}
+
+// ListWireGuardPeers will list the peers of a WireGuard VPN server
+// site - the site to query
+// serverID - the ID of the WireGuard VPN server network
+func (c *Client) ListWireGuardPeers(ctx context.Context, site string, serverID string) ([]WireGuardPeer, error) {
+	extPath := fmt.Sprintf("wireguard/%s/users", strings.TrimSpace(serverID))
+
+	var peers []WireGuardPeer
+	err := c.doSiteV2Request(ctx, http.MethodGet, site, extPath, nil, &peers)
+	return peers, err
+}
+
+// CreateWireGuardPeer will create a new WireGuard peer, a key pair is generated when no public key is set.
+// The generated private key is returned on the peer so a client configuration can be exported.
+// site - the site to modify
+// serverID - the ID of the WireGuard VPN server network
+// peer - the peer to create
+func (c *Client) CreateWireGuardPeer(ctx context.Context, site string, serverID string, peer *WireGuardPeer) (*WireGuardPeer, error) {
+	if peer.Name == "" || peer.InterfaceIP == "" {
+		return nil, fmt.Errorf("must specify the peer name and interface IP")
+	}
+	if peer.PublicKey == "" {
+		keys, err := GenerateWireGuardKeyPair()
+		if err != nil {
+			return nil, err
+		}
+		peer.PrivateKey = keys.PrivateKey
+		peer.PublicKey = keys.PublicKey
+	}
+	if peer.AllowedIPs == nil {
+		peer.AllowedIPs = make([]string, 0)
+	}
+	peer.NetworkID = serverID
+	data, _ := json.Marshal([]*WireGuardPeer{peer})
+
+	extPath := fmt.Sprintf("wireguard/%s/users/batch", strings.TrimSpace(serverID))
+
+	var created []WireGuardPeer
+	err := c.doSiteV2Request(ctx, http.MethodPost, site, extPath, bytes.NewReader(data), &created)
+	if err != nil {
+		return nil, err
+	}
+	if len(created) == 0 {
+		return nil, fmt.Errorf("no peer returned by the controller")
+	}
+	created[0].PrivateKey = peer.PrivateKey
+	return &created[0], nil
+}
+
+// UpdateWireGuardPeer will update an existing WireGuard peer
+// site - the site to modify
+// serverID - the ID of the WireGuard VPN server network
+// peer - the peer to update, the ID must be set
+func (c *Client) UpdateWireGuardPeer(ctx context.Context, site string, serverID string, peer *WireGuardPeer) (*WireGuardPeer, error) {
+	if peer.ID == "" {
+		return nil, fmt.Errorf("must specify the peer ID")
+	}
+	if peer.AllowedIPs == nil {
+		peer.AllowedIPs = make([]string, 0)
+	}
+	peer.NetworkID = serverID
+	data, _ := json.Marshal([]*WireGuardPeer{peer})
+
+	extPath := fmt.Sprintf("wireguard/%s/users/batch", strings.TrimSpace(serverID))
+
+	var updated []WireGuardPeer
+	err := c.doSiteV2Request(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &updated)
+	if err != nil {
+		return nil, err
+	}
+	if len(updated) == 0 {
+		return nil, fmt.Errorf("no peer returned by the controller")
+	}
+	return &updated[0], nil
+}
+
+// DeleteWireGuardPeers will delete WireGuard peers
+// site - the site to modify
+// serverID - the ID of the WireGuard VPN server network
+// peerIDs - the IDs of the peers
+func (c *Client) DeleteWireGuardPeers(ctx context.Context, site string, serverID string, peerIDs ...string) error {
+	if len(peerIDs) == 0 {
+		return nil
+	}
+	data, _ := json.Marshal(peerIDs)
+
+	extPath := fmt.Sprintf("wireguard/%s/users/batch_delete", strings.TrimSpace(serverID))
+	return c.doSiteV2Request(ctx, http.MethodPost, site, extPath, bytes.NewReader(data), nil)
+}
+
+// WireGuardClientConfig renders the wg-quick configuration for the peer, which can also be encoded into a QR code
+// for the mobile apps. The peer private key must be known, see CreateWireGuardPeer.
+// server - the WireGuard VPN server the peer belongs to
+// peer - the peer to render the configuration for
+// endpoint - the public address and port clients connect to, e.g. `vpn.example.com:51820`
+// allowedIPs - the subnets routed through the tunnel, defaults to all traffic
+func WireGuardClientConfig(server *VPNServer, peer *WireGuardPeer, endpoint string, allowedIPs ...string) (string, error) {
+	if peer.PrivateKey == "" {
+		return "", fmt.Errorf("the peer private key is unknown")
+	}
+	if server.WireGuardPublicKey == "" {
+		return "", fmt.Errorf("the server public key is unknown")
+	}
+	if len(allowedIPs) == 0 {
+		allowedIPs = []string{"0.0.0.0/0", "::/0"}
+	}
+
+	address := peer.InterfaceIP
+	if !strings.Contains(address, "/") {
+		address += "/32"
+	}
+
+	var b strings.Builder
+	b.WriteString("[Interface]\n")
+	fmt.Fprintf(&b, "PrivateKey = %s\n", peer.PrivateKey)
+	fmt.Fprintf(&b, "Address = %s\n", address)
+	dns := make([]string, 0, 2)
+	for _, d := range []string{server.DHCPDDNS1, server.DHCPDDNS2} {
+		if d != "" {
+			dns = append(dns, d)
+		}
+	}
+	if len(dns) > 0 {
+		fmt.Fprintf(&b, "DNS = %s\n", strings.Join(dns, ", "))
+	}
+	b.WriteString("\n[Peer]\n")
+	fmt.Fprintf(&b, "PublicKey = %s\n", server.WireGuardPublicKey)
+	if peer.PresharedKey != "" {
+		fmt.Fprintf(&b, "PresharedKey = %s\n", peer.PresharedKey)
+	}
+	fmt.Fprintf(&b, "AllowedIPs = %s\n", strings.Join(allowedIPs, ", "))
+	fmt.Fprintf(&b, "Endpoint = %s\n", endpoint)
+	return b.String(), nil
+}
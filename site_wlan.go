@@ -1,7 +1,12 @@
 package unifi
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 )
 
 // SiteWLANConfig is the WLAN configuration
@@ -15,9 +20,9 @@ type SiteWLANConfigResponse struct {
 
 // SiteWLANConfigs will query the site for WLAN configurations
 // site - the site to query
-func (c *Client) SiteWLANConfigs(site string) (*SiteWLANConfigResponse, error) {
+func (c *Client) SiteWLANConfigs(ctx context.Context, site string) (*SiteWLANConfigResponse, error) {
 	var resp SiteWLANConfigResponse
-	err := c.doSiteRequest(http.MethodGet, site, "rest/wlanconf", nil, &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/wlanconf", nil, &resp)
 	return &resp, err
 }
 
@@ -32,8 +37,150 @@ type SiteWLANGroupResponse struct {
 
 // SiteWLANGroups will query the site for WLAN groups
 // site - the site to query
-func (c *Client) SiteWLANGroups(site string) (*SiteWLANGroupResponse, error) {
+func (c *Client) SiteWLANGroups(ctx context.Context, site string) (*SiteWLANGroupResponse, error) {
 	var resp SiteWLANGroupResponse
-	err := c.doSiteRequest(http.MethodGet, site, "rest/wlangroup", nil, &resp)
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/wlangroup", nil, &resp)
+	return &resp, err
+}
+
+// WLANSecurity defines the WLAN security mode
+type WLANSecurity string
+
+// The supported WLAN security modes
+const (
+	WLANSecurityOpen          WLANSecurity = "open"
+	WLANSecurityWEP           WLANSecurity = "wep"
+	WLANSecurityWPAPSK        WLANSecurity = "wpapsk"
+	WLANSecurityWPAEnterprise WLANSecurity = "wpaeap"
+)
+
+// IsValid returns true if it's a valid WLAN security mode.
+// there are only a few valid types
+func (s WLANSecurity) IsValid() bool {
+	switch s {
+	case WLANSecurityOpen, WLANSecurityWEP, WLANSecurityWPAPSK, WLANSecurityWPAEnterprise:
+		return true
+	default:
+		return false
+	}
+}
+
+// WLANConf defines a typed WLAN configuration
+type WLANConf struct {
+	ID                      string       `json:"_id,omitempty"`
+	SiteID                  string       `json:"site_id,omitempty"`
+	Name                    string       `json:"name"` // the SSID
+	Enabled                 bool         `json:"enabled"`
+	Security                WLANSecurity `json:"security"`
+	WPAMode                 string       `json:"wpa_mode,omitempty"` // wpa1, wpa2, auto
+	WPAEncryption           string       `json:"wpa_enc,omitempty"`  // ccmp, tkip, auto
+	WPA3Support             bool         `json:"wpa3_support"`
+	WPA3Transition          bool         `json:"wpa3_transition"`
+	PMFMode                 string       `json:"pmf_mode,omitempty"` // disabled, optional, required
+	Passphrase              string       `json:"x_passphrase,omitempty"`
+	RADIUSProfileID         string       `json:"radiusprofile_id,omitempty"`
+	IsGuest                 bool         `json:"is_guest"`
+	HideSSID                bool         `json:"hide_ssid"`
+	L2Isolation             bool         `json:"l2_isolation"`
+	FastRoamingEnabled      bool         `json:"fast_roaming_enabled"`
+	UAPSDEnabled            bool         `json:"uapsd_enabled"`
+	MulticastEnhanceEnabled bool         `json:"mcastenhance_enabled"`
+
+	VLANEnabled   bool     `json:"vlan_enabled"`
+	VLAN          string   `json:"vlan,omitempty"`
+	NetworkConfID string   `json:"networkconf_id,omitempty"`
+	UserGroupID   string   `json:"usergroup_id,omitempty"`
+	WLANGroupID   string   `json:"wlangroup_id,omitempty"`
+	APGroupIDs    []string `json:"ap_group_ids,omitempty"`
+
+	// band steering
+	WLANBand  string   `json:"wlan_band,omitempty"` // both, 2g, 5g
+	WLANBands []string `json:"wlan_bands,omitempty"`
+	No2GHzOUI bool     `json:"no2ghz_oui"` // steer known 5GHz capable clients away from 2.4GHz
+
+	// schedules, entries are formatted like `mon|0800-1700`
+	ScheduleEnabled bool     `json:"schedule_enabled"`
+	Schedule        []string `json:"schedule,omitempty"`
+
+	MACFilterEnabled bool     `json:"mac_filter_enabled"`
+	MACFilterPolicy  string   `json:"mac_filter_policy,omitempty"` // allow, deny
+	MACFilterList    []string `json:"mac_filter_list,omitempty"`
+
+	AttrNoDelete bool   `json:"attr_no_delete,omitempty"`
+	AttrHiddenID string `json:"attr_hidden_id,omitempty"`
+}
+
+// WLANConfResponse contains the typed WLAN configuration response
+type WLANConfResponse struct {
+	Meta CommonMeta `json:"meta"`
+	Data []WLANConf `json:"data"`
+}
+
+// ListWLANs will list the typed WLAN configurations
+// site - the site to query
+func (c *Client) ListWLANs(ctx context.Context, site string) (*WLANConfResponse, error) {
+	var resp WLANConfResponse
+	err := c.doSiteRequest(ctx, http.MethodGet, site, "rest/wlanconf", nil, &resp)
+	return &resp, err
+}
+
+// CreateWLAN will create a new WLAN
+// site - the site to modify
+// wlan - the WLAN configuration to create
+func (c *Client) CreateWLAN(ctx context.Context, site string, wlan *WLANConf) (*WLANConfResponse, error) {
+	if !wlan.Security.IsValid() {
+		return nil, fmt.Errorf("invalid security specified: %s", wlan.Security)
+	}
+	data, _ := json.Marshal(wlan)
+
+	var resp WLANConfResponse
+	err := c.doSiteRequest(ctx, http.MethodPost, site, "rest/wlanconf", bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// UpdateWLAN will update an existing WLAN
+// site - the site to modify
+// wlan - the WLAN configuration to update, the ID must be set
+func (c *Client) UpdateWLAN(ctx context.Context, site string, wlan *WLANConf) (*WLANConfResponse, error) {
+	if wlan.ID == "" {
+		return nil, fmt.Errorf("must specify the WLAN ID")
+	}
+	if !wlan.Security.IsValid() {
+		return nil, fmt.Errorf("invalid security specified: %s", wlan.Security)
+	}
+	data, _ := json.Marshal(wlan)
+
+	extPath := fmt.Sprintf("rest/wlanconf/%s", strings.TrimSpace(wlan.ID))
+
+	var resp WLANConfResponse
+	err := c.doSiteRequest(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// SetWLANPassphrase will only update the passphrase of an existing WLAN
+// site - the site to modify
+// wlanID - the ID of the WLAN
+// passphrase - the new WPA passphrase
+func (c *Client) SetWLANPassphrase(ctx context.Context, site string, wlanID string, passphrase string) (*WLANConfResponse, error) {
+	payload := map[string]interface{}{
+		"x_passphrase": passphrase,
+	}
+	data, _ := json.Marshal(payload)
+
+	extPath := fmt.Sprintf("rest/wlanconf/%s", strings.TrimSpace(wlanID))
+
+	var resp WLANConfResponse
+	err := c.doSiteRequest(ctx, http.MethodPut, site, extPath, bytes.NewReader(data), &resp)
+	return &resp, err
+}
+
+// DeleteWLAN will delete an existing WLAN
+// site - the site to modify
+// wlanID - the ID of the WLAN
+func (c *Client) DeleteWLAN(ctx context.Context, site string, wlanID string) (*GenericResponse, error) {
+	extPath := fmt.Sprintf("rest/wlanconf/%s", strings.TrimSpace(wlanID))
+
+	var resp GenericResponse
+	err := c.doSiteRequest(ctx, http.MethodDelete, site, extPath, nil, &resp)
 	return &resp, err
 }
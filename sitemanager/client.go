@@ -0,0 +1,190 @@
+// Package sitemanager is a client for the UniFi Site Manager API at api.ui.com, covering the hosts, sites,
+// devices and ISP metrics of every console linked to a UI account.
+// The API authenticates with an API key created in the Site Manager, no login is required.
+package sitemanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/platinummonkey/unifi"
+)
+
+// DefaultBaseURL is the base url of the Site Manager API
+const DefaultBaseURL = "https://api.ui.com"
+
+// Client is a Site Manager API client
+type Client struct {
+	baseURL    string
+	apiKey     string
+	userAgent  string
+	HTTPClient *http.Client
+}
+
+// Option configures the client, see NewClient
+type Option func(c *Client)
+
+// WithBaseURL overrides the API base url, defaults to DefaultBaseURL
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithHTTPClient uses the http client to talk to the API
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithUserAgent overrides the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// NewClient returns a Site Manager API client
+// apiKey - the API key created in the Site Manager
+func NewClient(apiKey string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    DefaultBaseURL,
+		apiKey:     apiKey,
+		userAgent:  unifi.UserAgentHeader,
+		HTTPClient: &http.Client{Timeout: unifi.DefaultTimeout},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// APIError is returned when the API rejects a request
+type APIError struct {
+	StatusCode int           // the HTTP status code
+	Code       string        // the error code, e.g. `unauthorized`
+	Message    string        // the error message
+	TraceID    string        // the trace ID to quote to UI support
+	RetryAfter time.Duration // the time to wait before retrying rate limited requests
+}
+
+// Error implements error
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("site manager api error: %s (status: %d, code: %s)", e.Message, e.StatusCode, e.Code)
+	}
+	return fmt.Sprintf("site manager api error: status: %d, code: %s", e.StatusCode, e.Code)
+}
+
+// IsRateLimited returns true if the request was rejected by the rate limit, see APIError.RetryAfter
+func IsRateLimited(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
+// newAPIError builds the error from a failed response, the body is consumed
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return apiErr
+	}
+	var errResp struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		TraceID string `json:"traceId"`
+	}
+	if json.Unmarshal(body, &errResp) == nil {
+		apiErr.Code, apiErr.Message, apiErr.TraceID = errResp.Code, errResp.Message, errResp.TraceID
+	}
+	return apiErr
+}
+
+// Response is the envelope of the API responses
+type Response[T any] struct {
+	Data           T      `json:"data"`
+	HTTPStatusCode int    `json:"httpStatusCode"`
+	TraceID        string `json:"traceId"`
+	NextToken      string `json:"nextToken,omitempty"` // the token of the next page, empty on the last page
+}
+
+// do issues a request and decodes the response into ret
+func (c *Client) do(ctx context.Context, method string, extPath string, query url.Values, sendBody io.Reader, ret interface{}) error {
+	u := c.baseURL + extPath
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, sendBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set(unifi.APIKeyHeader, c.apiKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return newAPIError(resp)
+	}
+	if ret == nil {
+		return nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, unifi.ErrInvalidResponseBody.Error())
+	}
+	if err := json.Unmarshal(body, ret); err != nil {
+		return errors.Wrap(err, unifi.ErrJSONDecode.Error())
+	}
+	return nil
+}
+
+// pageQuery returns the paging query parameters
+func pageQuery(pageSize int, nextToken string) url.Values {
+	query := url.Values{}
+	if pageSize > 0 {
+		query.Set("pageSize", strconv.Itoa(pageSize))
+	}
+	if nextToken != "" {
+		query.Set("nextToken", nextToken)
+	}
+	return query
+}
+
+// all fetches every page with fetch
+func all[T any](ctx context.Context, fetch func(ctx context.Context, nextToken string) (*Response[[]T], error)) ([]T, error) {
+	var (
+		items     []T
+		nextToken string
+	)
+	for {
+		resp, err := fetch(ctx, nextToken)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, resp.Data...)
+		if resp.NextToken == "" || resp.NextToken == nextToken {
+			return items, nil
+		}
+		nextToken = resp.NextToken
+	}
+}
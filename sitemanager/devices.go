@@ -0,0 +1,69 @@
+package sitemanager
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Device is a device managed by a host
+type Device struct {
+	ID              string    `json:"id"`
+	MAC             string    `json:"mac"`
+	Name            string    `json:"name"`
+	Model           string    `json:"model"`
+	Shortname       string    `json:"shortname"`
+	IP              string    `json:"ip"`
+	ProductLine     string    `json:"productLine"` // network, protect, access, ...
+	Status          string    `json:"status"`      // online, offline, ...
+	Version         string    `json:"version"`
+	FirmwareStatus  string    `json:"firmwareStatus"`
+	UpdateAvailable string    `json:"updateAvailable"`
+	IsConsole       bool      `json:"isConsole"`
+	IsManaged       bool      `json:"isManaged"`
+	StartupTime     time.Time `json:"startupTime"`
+	AdoptionTime    time.Time `json:"adoptionTime"`
+	Note            string    `json:"note"`
+}
+
+// HostDevices are the devices of a single host
+type HostDevices struct {
+	HostID    string    `json:"hostId"`
+	HostName  string    `json:"hostName"`
+	Devices   []Device  `json:"devices"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// DeviceFilter filters the devices
+type DeviceFilter struct {
+	HostIDs   []string  // only the devices of these hosts, all hosts when empty
+	Time      time.Time // only the devices changed since, all devices when zero
+	PageSize  int       // the page size, the API default when 0
+	NextToken string    // the NextToken of the previous page, empty for the first page
+}
+
+// ListDevices lists a single page of the devices grouped by host, see AllDevices
+// filter - the device filters
+func (c *Client) ListDevices(ctx context.Context, filter DeviceFilter) (*Response[[]HostDevices], error) {
+	query := pageQuery(filter.PageSize, filter.NextToken)
+	for _, id := range filter.HostIDs {
+		query.Add("hostIds[]", id)
+	}
+	if !filter.Time.IsZero() {
+		query.Set("time", filter.Time.UTC().Format(time.RFC3339))
+	}
+
+	var resp Response[[]HostDevices]
+	err := c.do(ctx, http.MethodGet, "/v1/devices", query, nil, &resp)
+	return &resp, err
+}
+
+// AllDevices lists the devices of every page, grouped by host
+// filter - the device filters, PageSize and NextToken are ignored
+func (c *Client) AllDevices(ctx context.Context, filter DeviceFilter) ([]HostDevices, error) {
+	return all(ctx, func(ctx context.Context, nextToken string) (*Response[[]HostDevices], error) {
+		f := filter
+		f.PageSize, f.NextToken = 0, nextToken
+		return c.ListDevices(ctx, f)
+	})
+}
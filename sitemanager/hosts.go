@@ -0,0 +1,59 @@
+package sitemanager
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Host is a console or self-hosted network server linked to the account
+type Host struct {
+	ID                        string                 `json:"id"`
+	HardwareID                string                 `json:"hardwareId"`
+	Type                      string                 `json:"type"` // console or network-server
+	IPAddress                 string                 `json:"ipAddress"`
+	Owner                     bool                   `json:"owner"`
+	IsBlocked                 bool                   `json:"isBlocked"`
+	RegistrationTime          time.Time              `json:"registrationTime"`
+	LastConnectionStateChange time.Time              `json:"lastConnectionStateChange"`
+	LatestBackupTime          time.Time              `json:"latestBackupTime"`
+	UserData                  map[string]interface{} `json:"userData"`
+	ReportedState             map[string]interface{} `json:"reportedState"` // the state reported by the host, the shape depends on the host type
+}
+
+// Name returns the name reported by the host, if any
+func (h Host) Name() string {
+	if name, ok := h.ReportedState["name"].(string); ok && name != "" {
+		return name
+	}
+	hostname, _ := h.ReportedState["hostname"].(string)
+	return hostname
+}
+
+// ListHosts lists a single page of the hosts, see AllHosts
+// pageSize - the page size, the API default when 0
+// nextToken - the NextToken of the previous page, empty for the first page
+func (c *Client) ListHosts(ctx context.Context, pageSize int, nextToken string) (*Response[[]Host], error) {
+	var resp Response[[]Host]
+	err := c.do(ctx, http.MethodGet, "/v1/hosts", pageQuery(pageSize, nextToken), nil, &resp)
+	return &resp, err
+}
+
+// AllHosts lists every host, fetching all pages
+func (c *Client) AllHosts(ctx context.Context) ([]Host, error) {
+	return all(ctx, func(ctx context.Context, nextToken string) (*Response[[]Host], error) {
+		return c.ListHosts(ctx, 0, nextToken)
+	})
+}
+
+// GetHost returns a single host
+// id - the host ID
+func (c *Client) GetHost(ctx context.Context, id string) (*Host, error) {
+	var resp Response[Host]
+	err := c.do(ctx, http.MethodGet, "/v1/hosts/"+url.PathEscape(id), nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
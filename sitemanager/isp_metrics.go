@@ -0,0 +1,133 @@
+package sitemanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// MetricInterval defines the granularity of the ISP metrics
+type MetricInterval string
+
+// The supported ISP metric intervals
+const (
+	MetricInterval5m MetricInterval = "5m" // kept for 24 hours
+	MetricInterval1h MetricInterval = "1h" // kept for 30 days
+)
+
+// IsValid returns true if it's a valid metric interval.
+// there are only a few valid types
+func (i MetricInterval) IsValid() bool {
+	switch i {
+	case MetricInterval5m, MetricInterval1h:
+		return true
+	default:
+		return false
+	}
+}
+
+// WANMetrics are the WAN metrics of a single period
+type WANMetrics struct {
+	AvgLatency   int     `json:"avgLatency"` // ms
+	MaxLatency   int     `json:"maxLatency"` // ms
+	PacketLoss   float64 `json:"packetLoss"` // percent
+	DownloadKbps int     `json:"download_kbps"`
+	UploadKbps   int     `json:"upload_kbps"`
+	Uptime       float64 `json:"uptime"`   // percent
+	Downtime     float64 `json:"downtime"` // percent
+	ISPName      string  `json:"ispName"`
+	ISPASN       string  `json:"ispAsn"`
+}
+
+// MetricPeriod is a single period of the ISP metrics
+type MetricPeriod struct {
+	MetricTime time.Time `json:"metricTime"`
+	Version    string    `json:"version"`
+	Data       struct {
+		WAN WANMetrics `json:"wan"`
+	} `json:"data"`
+}
+
+// SiteMetrics are the ISP metrics of a single site
+type SiteMetrics struct {
+	MetricType MetricInterval `json:"metricType"`
+	HostID     string         `json:"hostId"`
+	SiteID     string         `json:"siteId"`
+	Periods    []MetricPeriod `json:"periods"`
+}
+
+// ISPMetricsFilter selects the time range of the ISP metrics, either Start and End or Duration
+type ISPMetricsFilter struct {
+	Start    time.Time
+	End      time.Time
+	Duration string // 24h for 5m metrics, 7d or 30d for 1h metrics
+}
+
+// ISPMetrics returns the ISP metrics of all sites
+// interval - the metric granularity
+// filter - the time range, the API default when empty
+func (c *Client) ISPMetrics(ctx context.Context, interval MetricInterval, filter ISPMetricsFilter) ([]SiteMetrics, error) {
+	if !interval.IsValid() {
+		return nil, fmt.Errorf("invalid interval specified: %s", interval)
+	}
+	query := url.Values{}
+	if !filter.Start.IsZero() {
+		query.Set("beginTimestamp", strconv.FormatInt(filter.Start.UnixNano()/int64(time.Millisecond), 10))
+	}
+	if !filter.End.IsZero() {
+		query.Set("endTimestamp", strconv.FormatInt(filter.End.UnixNano()/int64(time.Millisecond), 10))
+	}
+	if filter.Duration != "" {
+		query.Set("duration", filter.Duration)
+	}
+
+	var resp Response[[]SiteMetrics]
+	err := c.do(ctx, http.MethodGet, "/ea/isp-metrics/"+string(interval), query, nil, &resp)
+	return resp.Data, err
+}
+
+// SiteMetricsQuery selects the sites and time range of QueryISPMetrics
+type SiteMetricsQuery struct {
+	HostID string
+	SiteID string
+	Start  time.Time // the API default when zero
+	End    time.Time // the API default when zero
+}
+
+// QueryISPMetrics returns the ISP metrics of the selected sites
+// interval - the metric granularity
+// sites - the sites to query, each with its own time range
+func (c *Client) QueryISPMetrics(ctx context.Context, interval MetricInterval, sites ...SiteMetricsQuery) ([]SiteMetrics, error) {
+	if !interval.IsValid() {
+		return nil, fmt.Errorf("invalid interval specified: %s", interval)
+	}
+	querySites := make([]map[string]interface{}, 0, len(sites))
+	for _, s := range sites {
+		q := map[string]interface{}{
+			"hostId": s.HostID,
+			"siteId": s.SiteID,
+		}
+		if !s.Start.IsZero() {
+			q["beginTimestamp"] = s.Start.UTC().Format(time.RFC3339)
+		}
+		if !s.End.IsZero() {
+			q["endTimestamp"] = s.End.UTC().Format(time.RFC3339)
+		}
+		querySites = append(querySites, q)
+	}
+	payload := map[string]interface{}{
+		"sites": querySites,
+	}
+	data, _ := json.Marshal(payload)
+
+	var resp Response[struct {
+		Metrics []SiteMetrics `json:"metrics"`
+	}]
+	err := c.do(ctx, http.MethodPost, "/ea/isp-metrics/"+string(interval)+"/query", nil, bytes.NewReader(data), &resp)
+	return resp.Data.Metrics, err
+}
@@ -0,0 +1,40 @@
+package sitemanager
+
+import (
+	"context"
+	"net/http"
+)
+
+// SiteMeta contains the descriptive site fields
+type SiteMeta struct {
+	Name       string `json:"name"` // the site name used by the network application, e.g. `default`
+	Desc       string `json:"desc"`
+	GatewayMAC string `json:"gatewayMac"`
+	Timezone   string `json:"timezone"`
+}
+
+// Site is a network application site of a host
+type Site struct {
+	SiteID     string                 `json:"siteId"`
+	HostID     string                 `json:"hostId"`
+	Meta       SiteMeta               `json:"meta"`
+	Statistics map[string]interface{} `json:"statistics"` // device and client counts, ISP info and percentages
+	Permission string                 `json:"permission"`
+	IsOwner    bool                   `json:"isOwner"`
+}
+
+// ListSites lists a single page of the sites of all hosts, see AllSites
+// pageSize - the page size, the API default when 0
+// nextToken - the NextToken of the previous page, empty for the first page
+func (c *Client) ListSites(ctx context.Context, pageSize int, nextToken string) (*Response[[]Site], error) {
+	var resp Response[[]Site]
+	err := c.do(ctx, http.MethodGet, "/v1/sites", pageQuery(pageSize, nextToken), nil, &resp)
+	return &resp, err
+}
+
+// AllSites lists every site of all hosts, fetching all pages
+func (c *Client) AllSites(ctx context.Context) ([]Site, error) {
+	return all(ctx, func(ctx context.Context, nextToken string) (*Response[[]Site], error) {
+		return c.ListSites(ctx, 0, nextToken)
+	})
+}
@@ -0,0 +1,281 @@
+// Package sshadopt adopts devices over SSH, the layer-3 adoption workflow for devices that can not discover the
+// controller on their own: log in to the factory-default device, point it at the controller with set-inform,
+// wait for it to show up as pending adoption and adopt it.
+package sshadopt
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/platinummonkey/unifi"
+	"golang.org/x/crypto/ssh"
+)
+
+// The factory-default SSH credentials of UniFi devices
+const (
+	DefaultUsername = "ubnt"
+	DefaultPassword = "ubnt"
+)
+
+// Options configures the SSH connection and the adoption
+type Options struct {
+	InformURL string // the inform url of the controller, e.g. `http://controller:8080/inform`
+	Username  string // defaults to DefaultUsername
+	Password  string // defaults to DefaultPassword
+	Port      int    // defaults to 22
+
+	// HostKeyCallback verifies the host key of the device, defaults to accepting any key since factory-default
+	// devices generate a new host key on every reset
+	HostKeyCallback ssh.HostKeyCallback
+
+	DialTimeout  time.Duration // defaults to 10 seconds
+	PollInterval time.Duration // device state poll interval, defaults to 5 seconds
+	Timeout      time.Duration // time for the device to appear and to connect after the adoption, defaults to 5 minutes
+}
+
+// withDefaults returns a copy of the options with the defaults applied
+func (o Options) withDefaults() Options {
+	if o.Username == "" {
+		o.Username = DefaultUsername
+	}
+	if o.Password == "" {
+		o.Password = DefaultPassword
+	}
+	if o.Port <= 0 {
+		o.Port = 22
+	}
+	if o.HostKeyCallback == nil {
+		o.HostKeyCallback = ssh.InsecureIgnoreHostKey()
+	}
+	if o.DialTimeout <= 0 {
+		o.DialTimeout = 10 * time.Second
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = 5 * time.Second
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = 5 * time.Minute
+	}
+	return o
+}
+
+// SetInform connects to the device and points it at the controller with set-inform, returning the device mac.
+// host - the address of the device, without the port
+// opts - the SSH and inform options
+func SetInform(ctx context.Context, host string, opts Options) (string, error) {
+	if err := validateInformURL(opts.InformURL); err != nil {
+		return "", err
+	}
+	opts = opts.withDefaults()
+
+	client, err := dial(ctx, host, opts)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	mac, err := readMAC(client)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to read the mac of %s", host)
+	}
+	if _, err := run(client, "mca-cli-op set-inform "+shellQuote(opts.InformURL)); err != nil {
+		return "", errors.Wrapf(err, "unable to set the inform url of %s", host)
+	}
+	return mac, nil
+}
+
+// validateInformURL checks the inform url is an absolute http or https url
+func validateInformURL(informURL string) error {
+	if informURL == "" {
+		return fmt.Errorf("no inform url specified")
+	}
+	u, err := url.Parse(informURL)
+	if err != nil {
+		return fmt.Errorf("invalid inform url specified: %s", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid inform url specified: %s, must be an http or https url", informURL)
+	}
+	return nil
+}
+
+// shellQuote quotes the argument for the POSIX shell of the device
+func shellQuote(arg string) string {
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// Adopt points the device at the controller with set-inform, waits for it to appear as pending adoption on the
+// site, adopts it and waits until it is connected. The inform url is set a second time after the adoption,
+// older firmware only keeps the url once the controller accepted the device.
+// A device already adopted and connected on the site is returned as is.
+// c - the controller client
+// site - the site to adopt the device onto
+// host - the address of the device, without the port
+// opts - the SSH and adoption options
+func Adopt(ctx context.Context, c *unifi.Client, site string, host string, opts Options) (*unifi.Device, error) {
+	opts = opts.withDefaults()
+
+	mac, err := SetInform(ctx, host, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	device, err := waitForDevice(ctx, c, site, mac, opts, func(d *unifi.Device) bool {
+		return d.State == unifi.DeviceStatePendingAdoption || (d.Adopted && d.State == unifi.DeviceStateConnected)
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "device %s did not appear on the controller", mac)
+	}
+	if device.Adopted {
+		return device, nil
+	}
+
+	if _, err := c.AdoptDevice(ctx, site, mac); err != nil {
+		return nil, errors.Wrapf(err, "unable to adopt %s", mac)
+	}
+	// best effort, the device may already be restarting with the controller configuration
+	_, _ = SetInform(ctx, host, opts)
+
+	device, err = waitForDevice(ctx, c, site, mac, opts, func(d *unifi.Device) bool {
+		return d.Adopted && d.State == unifi.DeviceStateConnected
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "device %s did not connect after the adoption", mac)
+	}
+	return device, nil
+}
+
+// waitForDevice polls the device until done returns true, the adoption failed or the timeout passed
+func waitForDevice(ctx context.Context, c *unifi.Client, site string, mac string, opts Options, done func(d *unifi.Device) bool) (*unifi.Device, error) {
+	deadline := time.Now().Add(opts.Timeout)
+	for {
+		resp, err := c.ListDevices(ctx, site, mac)
+		// the controller may be briefly unavailable, errors are retried until the timeout
+		if err == nil {
+			for i := range resp.Data {
+				d := &resp.Data[i]
+				if !strings.EqualFold(d.MAC, mac) {
+					continue
+				}
+				if d.State == unifi.DeviceStateAdoptionFailed {
+					return d, fmt.Errorf("adoption failed")
+				}
+				if done(d) {
+					return d, nil
+				}
+			}
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("timed out after %s", opts.Timeout)
+		}
+
+		t := time.NewTimer(opts.PollInterval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// dial opens the SSH connection to the device, the connection is closed when the context is done
+func dial(ctx context.Context, host string, opts Options) (*ssh.Client, error) {
+	addr := net.JoinHostPort(host, strconv.Itoa(opts.Port))
+	d := net.Dialer{Timeout: opts.DialTimeout}
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &ssh.ClientConfig{
+		User: opts.Username,
+		Auth: []ssh.AuthMethod{
+			ssh.Password(opts.Password),
+			// some firmware only offers keyboard-interactive
+			ssh.KeyboardInteractive(func(_ string, _ string, questions []string, _ []bool) ([]string, error) {
+				answers := make([]string, len(questions))
+				for i := range answers {
+					answers[i] = opts.Password
+				}
+				return answers, nil
+			}),
+		},
+		HostKeyCallback: opts.HostKeyCallback,
+		Timeout:         opts.DialTimeout,
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrapf(err, "unable to log in to %s", addr)
+	}
+	client := ssh.NewClient(sshConn, chans, reqs)
+
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			client.Close()
+		case <-stop:
+		}
+	}()
+	go func() {
+		client.Wait()
+		close(stop)
+	}()
+	return client, nil
+}
+
+// run runs the command in a new session and returns its output
+func run(client *ssh.Client, cmd string) (string, error) {
+	session, err := client.NewSession()
+	if err != nil {
+		return "", err
+	}
+	defer session.Close()
+
+	var stdout, stderr bytes.Buffer
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+	if err := session.Run(cmd); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %s", err, msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+// readMAC reads the device mac from the `info` output, falling back to the address of eth0
+func readMAC(client *ssh.Client) (string, error) {
+	if out, err := run(client, "mca-cli-op info"); err == nil {
+		for _, line := range strings.Split(out, "\n") {
+			key, value, ok := strings.Cut(line, ":")
+			if ok && strings.EqualFold(strings.TrimSpace(key), "MAC Address") {
+				if mac, err := net.ParseMAC(strings.TrimSpace(value)); err == nil {
+					return mac.String(), nil
+				}
+			}
+		}
+	}
+
+	out, err := run(client, "cat /sys/class/net/eth0/address")
+	if err != nil {
+		return "", err
+	}
+	mac, err := net.ParseMAC(strings.TrimSpace(out))
+	if err != nil {
+		return "", err
+	}
+	return mac.String(), nil
+}
@@ -0,0 +1,41 @@
+package sshadopt
+
+import (
+	"testing"
+)
+
+func TestValidateInformURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"http://controller:8080/inform", false},
+		{"https://controller.example.com:8080/inform", false},
+		{"", true},
+		{"controller:8080/inform", true},
+		{"ftp://controller/inform", true},
+		{"http:///inform", true},
+		{"http://controller/inform; reboot", false}, // quoted by SetInform
+	}
+	for _, tt := range tests {
+		if err := validateInformURL(tt.url); (err != nil) != tt.wantErr {
+			t.Errorf("validateInformURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want string
+	}{
+		{"http://controller:8080/inform", `'http://controller:8080/inform'`},
+		{"http://c/inform; reboot", `'http://c/inform; reboot'`},
+		{"http://c/it's", `'http://c/it'\''s'`},
+	}
+	for _, tt := range tests {
+		if got := shellQuote(tt.arg); got != tt.want {
+			t.Errorf("shellQuote(%q) = %s, want %s", tt.arg, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+module github.com/platinummonkey/unifi/sshadopt
+
+go 1.20
+
+require (
+	github.com/pkg/errors v0.9.1
+	github.com/platinummonkey/unifi v0.0.0-20261015081524-23943a0d633c
+	golang.org/x/crypto v0.17.0
+)
+
+require (
+	github.com/gorilla/websocket v1.4.2 // indirect
+	golang.org/x/sys v0.15.0 // indirect
+	gopkg.in/yaml.v2 v2.2.5 // indirect
+)
+
+// local development against the client in the same repository, drop it to build against the required version
+replace github.com/platinummonkey/unifi => ../
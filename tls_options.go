@@ -0,0 +1,143 @@
+package unifi
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// spkiHashPrefix is the prefix of the pins, as used by curl --pinnedpubkey
+const spkiHashPrefix = "sha256//"
+
+// tlsOptions collects the TLS options applied on top of WithTLSConfig or WithCertificationConfig
+type tlsOptions struct {
+	rootCAs      *x509.CertPool
+	rootCAFiles  []string
+	clientCerts  []tls.Certificate
+	clientFiles  [][2]string
+	pinnedHashes []string
+}
+
+// WithRootCAs verifies the controller certificate against the pool instead of the system roots
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(o *clientOptions) {
+		o.tls.rootCAs = pool
+	}
+}
+
+// WithRootCAFile verifies the controller certificate against the PEM certificates of the file instead of
+// the system roots, e.g. the self-signed certificate of the controller. Can be used multiple times.
+func WithRootCAFile(path string) Option {
+	return func(o *clientOptions) {
+		o.tls.rootCAFiles = append(o.tls.rootCAFiles, path)
+	}
+}
+
+// WithClientCertificate authenticates with the client certificate, e.g. for controllers behind an mTLS proxy
+func WithClientCertificate(cert tls.Certificate) Option {
+	return func(o *clientOptions) {
+		o.tls.clientCerts = append(o.tls.clientCerts, cert)
+	}
+}
+
+// WithClientCertificateFile authenticates with the client certificate and key of the PEM files
+func WithClientCertificateFile(certFile string, keyFile string) Option {
+	return func(o *clientOptions) {
+		o.tls.clientFiles = append(o.tls.clientFiles, [2]string{certFile, keyFile})
+	}
+}
+
+// WithPinnedPublicKeys only accepts controller certificates whose public key matches one of the pins.
+// The pin check replaces the certificate chain and host name verification, so self-signed controller
+// certificates are accepted safely. A pin is the base64 SHA-256 hash of the subject public key info,
+// with or without the `sha256//` prefix, see SPKIHash.
+func WithPinnedPublicKeys(pins ...string) Option {
+	return func(o *clientOptions) {
+		o.tls.pinnedHashes = append(o.tls.pinnedHashes, pins...)
+	}
+}
+
+// SPKIHash returns the pin of the certificate for WithPinnedPublicKeys, formatted like `sha256//<base64>`
+func SPKIHash(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return spkiHashPrefix + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// isSet returns true if any TLS option is configured
+func (t *tlsOptions) isSet() bool {
+	return t.rootCAs != nil || len(t.rootCAFiles) > 0 || len(t.clientCerts) > 0 || len(t.clientFiles) > 0 ||
+		len(t.pinnedHashes) > 0
+}
+
+// apply returns a copy of the TLS configuration with the options applied
+func (t *tlsOptions) apply(tlsConfig *tls.Config) (*tls.Config, error) {
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	} else {
+		tlsConfig = tlsConfig.Clone()
+	}
+
+	if t.rootCAs != nil && len(t.rootCAFiles) > 0 {
+		return nil, fmt.Errorf("WithRootCAs and WithRootCAFile are mutually exclusive")
+	}
+	if t.rootCAs != nil {
+		tlsConfig.RootCAs = t.rootCAs
+	}
+	if len(t.rootCAFiles) > 0 {
+		pool := x509.NewCertPool()
+		for _, path := range t.rootCAFiles {
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			if !pool.AppendCertsFromPEM(data) {
+				return nil, fmt.Errorf("no certificates found in %s", path)
+			}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	tlsConfig.Certificates = append(tlsConfig.Certificates, t.clientCerts...)
+	for _, files := range t.clientFiles {
+		cert, err := tls.LoadX509KeyPair(files[0], files[1])
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+	}
+
+	if len(t.pinnedHashes) > 0 {
+		pins := make([][]byte, 0, len(t.pinnedHashes))
+		for _, pin := range t.pinnedHashes {
+			hash, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(pin, spkiHashPrefix))
+			if err != nil || len(hash) != sha256.Size {
+				return nil, fmt.Errorf("invalid public key pin specified: %s", pin)
+			}
+			pins = append(pins, hash)
+		}
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return fmt.Errorf("controller did not present a certificate")
+			}
+			// only the leaf is checked, without chain verification intermediates prove nothing
+			leaf, err := x509.ParseCertificate(rawCerts[0])
+			if err != nil {
+				return err
+			}
+			sum := sha256.Sum256(leaf.RawSubjectPublicKeyInfo)
+			for _, pin := range pins {
+				if bytes.Equal(sum[:], pin) {
+					return nil
+				}
+			}
+			return fmt.Errorf("controller certificate public key %s does not match any pin", SPKIHash(leaf))
+		}
+	}
+	return tlsConfig, nil
+}
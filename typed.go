@@ -0,0 +1,92 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// Envelope is the meta/data shape of the site API responses
+type Envelope[T any] struct {
+	Meta CommonMeta `json:"meta"`
+	Data []T        `json:"data"`
+}
+
+// First returns the first data entry and whether there was one
+func (e *Envelope[T]) First() (T, bool) {
+	var zero T
+	if len(e.Data) == 0 {
+		return zero, false
+	}
+	return e.Data[0], true
+}
+
+// requestBody converts the body into a request body, io.Readers are sent as is and other values as JSON
+func requestBody(body interface{}) (io.Reader, error) {
+	switch b := body.(type) {
+	case nil:
+		return nil, nil
+	case io.Reader:
+		return b, nil
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(data), nil
+	}
+}
+
+// Do issues a request against the site API and decodes the data into T,
+// for endpoints this package does not wrap yet, e.g. Do[User](ctx, c, http.MethodGet, "default", "rest/user", nil).
+// A meta response code other than `ok` is returned as an *APIError.
+// site - the site to query, the default site when empty
+// path - the path below /api/s/<site>/, e.g. `stat/device`
+// body - the request body, nil for none, an io.Reader is sent as is, any other value is sent as JSON
+// opts - per-request options, e.g. WithRequestTimeout, applied on top of those carried by ctx
+func Do[T any](ctx context.Context, c *Client, method string, site string, path string, body interface{}, opts ...RequestOption) (*Envelope[T], error) {
+	ctx = withRequestOptions(ctx, opts)
+	sendBody, err := requestBody(body)
+	if err != nil {
+		return nil, err
+	}
+	var env Envelope[T]
+	err = c.doSiteRequest(ctx, method, site, path, sendBody, &env)
+	return &env, err
+}
+
+// Get issues a GET request against the site API, see Do
+// site - the site to query, the default site when empty
+// path - the path below /api/s/<site>/, e.g. `rest/user`
+// opts - per-request options, e.g. WithRequestTimeout
+func Get[T any](ctx context.Context, c *Client, site string, path string, opts ...RequestOption) (*Envelope[T], error) {
+	return Do[T](ctx, c, http.MethodGet, site, path, nil, opts...)
+}
+
+// Post issues a POST request against the site API, see Do
+// site - the site to query, the default site when empty
+// path - the path below /api/s/<site>/, e.g. `stat/sta`
+// body - the request body, sent as JSON unless it is an io.Reader
+// opts - per-request options, e.g. WithRequestTimeout
+func Post[T any](ctx context.Context, c *Client, site string, path string, body interface{}, opts ...RequestOption) (*Envelope[T], error) {
+	return Do[T](ctx, c, http.MethodPost, site, path, body, opts...)
+}
+
+// DoV2 issues a request against the v2 API of newer controllers and decodes the response into T,
+// these responses have no meta envelope.
+// site - the site to query, the default site when empty
+// path - the path below /v2/api/site/<site>/, e.g. `trafficrules`
+// body - the request body, nil for none, an io.Reader is sent as is, any other value is sent as JSON
+// opts - per-request options, e.g. WithRequestTimeout, applied on top of those carried by ctx
+func DoV2[T any](ctx context.Context, c *Client, method string, site string, path string, body interface{}, opts ...RequestOption) (T, error) {
+	ctx = withRequestOptions(ctx, opts)
+	var ret T
+	sendBody, err := requestBody(body)
+	if err != nil {
+		return ret, err
+	}
+	err = c.doSiteV2Request(ctx, method, site, path, sendBody, &ret)
+	return ret, err
+}
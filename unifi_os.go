@@ -0,0 +1,123 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+)
+
+// UniFiOSAPIPrefix is the path prefix where the network application API lives on UniFi OS consoles (UDM, UDM-Pro, CloudKey Gen2+)
+const UniFiOSAPIPrefix = "/proxy/network"
+
+// UniFi OS specific headers
+const (
+	CSRFTokenHeader        = "X-CSRF-Token"
+	UpdatedCSRFTokenHeader = "X-Updated-CSRF-Token"
+)
+
+// IsUniFiOS returns true if the client is talking to a UniFi OS console.
+// This is only accurate after DetectUniFiOS, Login or SetUniFiOS have been called.
+func (c *Client) IsUniFiOS() bool {
+	_, enabled := c.unifiOSState()
+	return enabled
+}
+
+// SetUniFiOS overrides the automatic UniFi OS detection.
+func (c *Client) SetUniFiOS(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.isUniFiOS = enabled
+	c.unifiOSDetected = true
+}
+
+// unifiOSState returns if the UniFi OS detection happened and its result
+func (c *Client) unifiOSState() (detected bool, enabled bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.unifiOSDetected, c.isUniFiOS
+}
+
+// DetectUniFiOS will detect if the controller is a UniFi OS console.
+// Classic controllers redirect the base URL to the login page, while UniFi OS consoles serve it directly.
+func (c *Client) DetectUniFiOS(ctx context.Context) (bool, error) {
+	// never follow redirects, the redirect itself is the signal
+	ctx = WithRequestOptions(ctx, withoutRedirects())
+	u := c.WithPathAndQueryParams("/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return false, err
+	}
+	c.SetHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+
+	enabled := resp.StatusCode == http.StatusOK
+	c.SetUniFiOS(enabled)
+	return enabled, nil
+}
+
+// apiPath returns the controller path for the network application API
+func (c *Client) apiPath(extPath string) string {
+	if c.IsUniFiOS() {
+		return path.Join(UniFiOSAPIPrefix, extPath)
+	}
+	return extPath
+}
+
+// loginUniFiOS will login against the UniFi OS authentication endpoint
+func (c *Client) loginUniFiOS(ctx context.Context, username string, password string, remember bool) error {
+	u := c.WithPathAndQueryParams("/api/auth/login")
+
+	auth := map[string]interface{}{
+		"username":   username,
+		"password":   password,
+		"rememberMe": remember,
+	}
+	data, _ := json.Marshal(auth)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	c.SetHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+	c.setSession(resp.Cookies(), resp.Header.Get(CSRFTokenHeader), remember)
+	return nil
+}
+
+// logoutUniFiOS will logout against the UniFi OS authentication endpoint
+func (c *Client) logoutUniFiOS(ctx context.Context) error {
+	u := c.WithPathAndQueryParams("/api/auth/logout")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	c.SetHeaders(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+	c.clearSession()
+	return nil
+}
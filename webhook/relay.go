@@ -0,0 +1,306 @@
+// Package webhook relays the controller event stream to HTTP endpoints, the controller has no outbound webhooks.
+//
+// Every event is POSTed as a JSON Payload, signed with HMAC-SHA256 when the endpoint has a secret and retried
+// with an exponential backoff on transport errors and 5xx responses:
+//
+//	r := webhook.New(c, webhook.Options{Endpoints: []webhook.Endpoint{{
+//		URL:    "https://hooks.example.com/unifi",
+//		Secret: "s3cr3t",
+//		Keys:   []string{unifi.EventKeyAPLostContact, unifi.EventKeyIPSAlert},
+//	}}})
+//	err := r.Run(ctx, "default")
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/platinummonkey/unifi"
+)
+
+// The headers sent with every delivery
+const (
+	SignatureHeader = "X-Unifi-Signature" // `sha256=<hex hmac of the body>`, only set when the endpoint has a secret
+	EventHeader     = "X-Unifi-Event"     // the event key, or the stream message type for non event messages
+	SiteHeader      = "X-Unifi-Site"      // the site the event was received for
+)
+
+// signaturePrefix is the prefix of the SignatureHeader value
+const signaturePrefix = "sha256="
+
+// Endpoint defines an HTTP endpoint events are relayed to
+type Endpoint struct {
+	URL      string
+	Secret   string            // the HMAC-SHA256 signing key, deliveries are unsigned when empty
+	Keys     []string          // the event keys to relay, e.g. unifi.EventKeyAPLostContact, all events when empty
+	Messages []string          // the stream message types to relay, defaults to `events`
+	Headers  map[string]string // additional request headers, e.g. an authorization header
+}
+
+// matches returns true if the event is relayed to the endpoint
+func (e *Endpoint) matches(p *Payload) bool {
+	messages := e.Messages
+	if len(messages) == 0 {
+		messages = []string{"events"}
+	}
+	if !contains(messages, p.Message) {
+		return false
+	}
+	return len(e.Keys) == 0 || contains(e.Keys, p.Key)
+}
+
+// Payload is the JSON body of a delivery
+type Payload struct {
+	Site    string          `json:"site"`
+	Message string          `json:"message"`        // the stream message type, e.g. `events`
+	Key     string          `json:"key,omitempty"`  // the event key, e.g. `EVT_AP_Lost_Contact`
+	Time    time.Time       `json:"time,omitempty"` // the time of the event, the receive time when the event has none
+	Data    json.RawMessage `json:"data"`           // the raw event as sent by the controller
+}
+
+// Options configures the relay
+type Options struct {
+	Endpoints   []Endpoint
+	HTTPClient  *http.Client  // defaults to a client with a 10 second timeout
+	MaxAttempts int           // the delivery attempts per event and endpoint, defaults to 5
+	Backoff     time.Duration // the wait before the first retry, doubled on every further retry, defaults to 1s
+	MaxBackoff  time.Duration // the upper bound of the wait between retries, defaults to 1 minute
+	QueueSize   int           // the events buffered per endpoint, further events are dropped, defaults to 100
+
+	// OnError is called when a delivery failed after all attempts or was dropped because the queue was full
+	OnError func(endpoint Endpoint, payload Payload, err error)
+}
+
+// Relay consumes the event stream of a controller and delivers the events to the endpoints
+type Relay struct {
+	client *unifi.Client
+	opts   Options
+}
+
+// New returns a new relay for the client, the client must be logged in
+// client - the controller client
+// opts - the relay options
+func New(client *unifi.Client, opts Options) *Relay {
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 5
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = time.Second
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = time.Minute
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 100
+	}
+	return &Relay{client: client, opts: opts}
+}
+
+// Run subscribes to the event streams of the sites and relays the events until the context is done, it then
+// returns the context error. Every endpoint is delivered to by its own worker, so a slow endpoint does not delay
+// the others. The streams already opened are closed when subscribing to a later site fails.
+// sites - the sites to subscribe to, the default site of the client when empty
+func (r *Relay) Run(ctx context.Context, sites ...string) error {
+	if len(r.opts.Endpoints) == 0 {
+		return fmt.Errorf("no webhook endpoints specified")
+	}
+	if len(sites) == 0 {
+		sites = []string{""}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	streams := make([]<-chan unifi.StreamEvent, 0, len(sites))
+	for _, site := range sites {
+		events, err := r.client.Events(ctx, site)
+		if err != nil {
+			return err
+		}
+		streams = append(streams, events)
+	}
+
+	var wg sync.WaitGroup
+	queues := make([]chan Payload, len(r.opts.Endpoints))
+	for i := range r.opts.Endpoints {
+		queues[i] = make(chan Payload, r.opts.QueueSize)
+		wg.Add(1)
+		go func(endpoint Endpoint, queue <-chan Payload) {
+			defer wg.Done()
+			for p := range queue {
+				if err := r.deliver(ctx, endpoint, p); err != nil && ctx.Err() == nil {
+					r.onError(endpoint, p, err)
+				}
+			}
+		}(r.opts.Endpoints[i], queues[i])
+	}
+
+	merged := make(chan unifi.StreamEvent)
+	var readers sync.WaitGroup
+	for _, events := range streams {
+		readers.Add(1)
+		go func(events <-chan unifi.StreamEvent) {
+			defer readers.Done()
+			for e := range events {
+				select {
+				case merged <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(events)
+	}
+	go func() {
+		readers.Wait()
+		close(merged)
+	}()
+
+	for e := range merged {
+		p := newPayload(e)
+		for i := range r.opts.Endpoints {
+			endpoint := &r.opts.Endpoints[i]
+			if !endpoint.matches(&p) {
+				continue
+			}
+			select {
+			case queues[i] <- p:
+			default:
+				r.onError(*endpoint, p, fmt.Errorf("webhook queue full, event dropped"))
+			}
+		}
+	}
+
+	for _, queue := range queues {
+		close(queue)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("event streams closed")
+}
+
+// newPayload returns the payload of the stream event
+func newPayload(e unifi.StreamEvent) Payload {
+	p := Payload{Site: e.Site, Message: e.Message, Time: time.Now().UTC(), Data: e.Data}
+	var base unifi.EventBase
+	if err := json.Unmarshal(e.Data, &base); err == nil {
+		p.Key = base.Key
+		if base.Time > 0 {
+			p.Time = base.EventTime()
+		}
+	}
+	return p
+}
+
+// deliver POSTs the payload to the endpoint, retrying transport errors, 429 and 5xx responses
+func (r *Relay) deliver(ctx context.Context, endpoint Endpoint, p Payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	backoff := r.opts.Backoff
+	for attempt := 1; ; attempt++ {
+		retry, err := r.post(ctx, endpoint, p, body)
+		if err == nil || !retry || attempt >= r.opts.MaxAttempts {
+			return err
+		}
+
+		t := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+		backoff *= 2
+		if backoff > r.opts.MaxBackoff {
+			backoff = r.opts.MaxBackoff
+		}
+	}
+}
+
+// post sends a single delivery attempt and returns whether a failed attempt should be retried
+func (r *Relay) post(ctx context.Context, endpoint Endpoint, p Payload, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range endpoint.Headers {
+		req.Header.Set(k, v)
+	}
+	event := p.Key
+	if event == "" {
+		event = p.Message
+	}
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(SiteHeader, p.Site)
+	if endpoint.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(endpoint.Secret, body))
+	}
+
+	resp, err := r.opts.HTTPClient.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return retry, fmt.Errorf("webhook %s returned %s", endpoint.URL, resp.Status)
+	}
+	return false, nil
+}
+
+// onError reports a failed delivery
+func (r *Relay) onError(endpoint Endpoint, p Payload, err error) {
+	if r.opts.OnError != nil {
+		r.opts.OnError(endpoint, p, err)
+	}
+}
+
+// Sign returns the SignatureHeader value of the body, `sha256=<hex hmac>`
+// secret - the endpoint secret
+// body - the request body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify returns true if the signature matches the body, for receivers of the deliveries
+// secret - the endpoint secret
+// body - the request body
+// signature - the SignatureHeader value
+func Verify(secret string, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// contains returns true if the value is in the values
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
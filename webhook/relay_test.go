@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/platinummonkey/unifi"
+)
+
+func TestRunClosesOpenedStreamsOnFailure(t *testing.T) {
+	closed := make(chan struct{})
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wss/s/good/events" {
+			http.NotFound(w, r)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				close(closed)
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+	c, err := unifi.NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetUniFiOS(false)
+
+	r := New(c, Options{Endpoints: []Endpoint{{URL: "http://127.0.0.1:0/hook"}}})
+	if err := r.Run(context.Background(), "good", "bad"); err == nil {
+		t.Fatal("expected the subscription of the bad site to fail")
+	}
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the stream of the good site was not closed")
+	}
+}
+
+func TestRunReturnsTheContextError(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+	c, err := unifi.NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetUniFiOS(false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	r := New(c, Options{Endpoints: []Endpoint{{URL: "http://127.0.0.1:0/hook"}}})
+	if err := r.Run(ctx, "default"); err != context.DeadlineExceeded {
+		t.Fatalf("expected the context error, got %v", err)
+	}
+}